@@ -12,8 +12,32 @@ import (
 
 var ConditionTypes = struct {
 	SynchronizationSucceeded string
+	Drifted                  string
+	Throttled                string
 }{
 	SynchronizationSucceeded: "Synchronized",
+	Drifted:                  "Drifted",
+	Throttled:                "Throttled",
+}
+
+// DriftReasons are the reasons of the Drifted condition.
+var DriftReasons = struct {
+	InSync   string
+	Missing  string
+	Diverged string
+}{
+	InSync:   "InSync",
+	Missing:  "Missing",
+	Diverged: "Diverged",
+}
+
+// ThrottleReasons are the reasons of the Throttled condition.
+var ThrottleReasons = struct {
+	MaxConcurrent           string
+	MaxSubmissionsPerMinute string
+}{
+	MaxConcurrent:           "MaxConcurrent",
+	MaxSubmissionsPerMinute: "MaxSubmissionsPerMinute",
 }
 
 func ConditionStatusForSynchronizationState(state SynchronizationState) metav1.ConditionStatus {
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type ArtifactLocator struct {
@@ -87,3 +91,54 @@ func (oa OutputArtifact) GetKey() string {
 func (oa OutputArtifact) GetValue() string {
 	return oa.Path.String()
 }
+
+// validateOutputArtifacts compiles the filter of each artifact path. When the
+// outputs of the referenced pipeline are declared, it also checks that the
+// component and artifact are produced by the pipeline.
+func validateOutputArtifacts(
+	path *field.Path,
+	artifacts []OutputArtifact,
+	pipeline *Pipeline,
+) (errors field.ErrorList) {
+	for i, artifact := range artifacts {
+		artifactPath := path.Index(i).Child("path")
+
+		if artifact.Path.Filter != "" {
+			if _, err := bexpr.CreateEvaluator(artifact.Path.Filter); err != nil {
+				errors = append(errors, field.Invalid(
+					artifactPath,
+					artifact.Path.String(),
+					fmt.Sprintf("invalid filter: %v", err),
+				))
+			}
+		}
+
+		if pipeline == nil || len(pipeline.Spec.Outputs) == 0 {
+			continue
+		}
+
+		output, found := lo.Find(pipeline.Spec.Outputs, func(output PipelineOutput) bool {
+			return output.Component == artifact.Path.Locator.Component
+		})
+		if !found {
+			errors = append(errors, field.NotSupported(
+				artifactPath,
+				artifact.Path.Locator.Component,
+				lo.Map(pipeline.Spec.Outputs, func(output PipelineOutput, _ int) string {
+					return output.Component
+				}),
+			))
+			continue
+		}
+
+		if !lo.Contains(output.Artifacts, artifact.Path.Locator.Artifact) {
+			errors = append(errors, field.NotSupported(
+				artifactPath,
+				artifact.Path.Locator.Artifact,
+				output.Artifacts,
+			))
+		}
+	}
+
+	return
+}
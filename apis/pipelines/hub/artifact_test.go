@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sky-uk/kfp-operator/apis"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var _ = Context("OutputArtifact", func() {
@@ -68,4 +69,58 @@ var _ = Context("OutputArtifact", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	var _ = Describe("validateOutputArtifacts", func() {
+		path := field.NewPath("spec").Child("artifacts")
+		outputArtifact := func(path string) OutputArtifact {
+			artifactPath, err := ArtifactPathFromString(path)
+			Expect(err).NotTo(HaveOccurred())
+			return OutputArtifact{Name: apis.RandomString(), Path: artifactPath}
+		}
+		pipeline := &Pipeline{
+			Spec: PipelineSpec{
+				Outputs: []PipelineOutput{
+					{Component: "trainer", Artifacts: []string{"model"}},
+				},
+			},
+		}
+
+		Specify("accepts valid filters", func() {
+			errs := validateOutputArtifacts(path, []OutputArtifact{
+				outputArtifact("trainer:model"),
+				outputArtifact("trainer:model[pushed == true]"),
+			}, nil)
+			Expect(errs).To(BeEmpty())
+		})
+
+		Specify("rejects filters that do not compile", func() {
+			errs := validateOutputArtifacts(path, []OutputArtifact{
+				outputArtifact("trainer:model"),
+				outputArtifact("trainer:model[pushed ==]"),
+			}, nil)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.artifacts[1].path"))
+		})
+
+		Specify("accepts components and artifacts declared by the pipeline", func() {
+			errs := validateOutputArtifacts(path, []OutputArtifact{outputArtifact("trainer:model")}, pipeline)
+			Expect(errs).To(BeEmpty())
+		})
+
+		Specify("rejects components not declared by the pipeline", func() {
+			errs := validateOutputArtifacts(path, []OutputArtifact{outputArtifact("evaluator:model")}, pipeline)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		})
+
+		Specify("rejects artifacts not declared by the pipeline", func() {
+			errs := validateOutputArtifacts(path, []OutputArtifact{outputArtifact("trainer:blessing")}, pipeline)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		})
+
+		Specify("skips interface checks when the pipeline declares no outputs", func() {
+			errs := validateOutputArtifacts(path, []OutputArtifact{outputArtifact("evaluator:blessing")}, &Pipeline{})
+			Expect(errs).To(BeEmpty())
+		})
+	})
 })
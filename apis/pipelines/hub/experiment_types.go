@@ -14,6 +14,9 @@ type ExperimentSpec struct {
 	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
 	Provider    common.NamespacedName `json:"provider" yaml:"provider"`
 	Description string                `json:"description,omitempty"`
+	// DeletionPolicy determines whether the provider resource is deleted
+	// when this resource is deleted. Defaults to Delete.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 func (es Experiment) ComputeHash() []byte {
@@ -62,6 +65,13 @@ func (e Experiment) GetKind() string {
 	return "experiment"
 }
 
+func (e Experiment) GetDeletionPolicy() DeletionPolicy {
+	if e.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return e.Spec.DeletionPolicy
+}
+
 //+kubebuilder:object:root=true
 
 type ExperimentList struct {
@@ -1,10 +1,66 @@
 package v1beta1
 
 import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func (e *Experiment) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, e).
 		Complete()
 }
+
+func NewExperimentValidatorWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &Experiment{}).
+		WithValidator(&ExperimentValidator{
+			reader: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-pipelines-kubeflow-org-v1beta1-experiment,mutating=false,failurePolicy=fail,sideEffects=None,groups=pipelines.kubeflow.org,resources=experiments,verbs=create;update,versions=v1beta1,name=vexperiment.kb.io,admissionReviewVersions=v1
+//+kubebuilder:object:generate=false
+
+type ExperimentValidator struct {
+	reader client.Reader
+}
+
+func (v *ExperimentValidator) validate(
+	ctx context.Context,
+	e *Experiment,
+) (admission.Warnings, error) {
+	provider, errors := findReferencedProvider(ctx, v.reader, field.NewPath("spec", "provider"), e.Spec.Provider)
+	errors = append(errors, validateNamespaceAllowed(ctx, v.reader, provider, e)...)
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(e.GroupVersionKind().GroupKind(), e.Name, errors)
+	}
+
+	return nil, nil
+}
+
+func (v *ExperimentValidator) ValidateCreate(
+	ctx context.Context,
+	e *Experiment,
+) (admission.Warnings, error) {
+	return v.validate(ctx, e)
+}
+
+func (v *ExperimentValidator) ValidateUpdate(
+	ctx context.Context,
+	_ *Experiment,
+	newE *Experiment,
+) (admission.Warnings, error) {
+	return v.validate(ctx, newE)
+}
+
+func (*ExperimentValidator) ValidateDelete(
+	_ context.Context,
+	_ *Experiment,
+) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -21,6 +21,17 @@ type PipelineSpec struct {
 	Image     string                `json:"image" yaml:"image"`
 	Env       []apis.NamedValue     `json:"env,omitempty" yaml:"env"`
 	Framework PipelineFramework     `json:"framework" yaml:"framework"`
+	// Outputs optionally declares the artifacts the pipeline produces so that
+	// artifact paths referencing it can be validated on admission.
+	Outputs []PipelineOutput `json:"outputs,omitempty" yaml:"outputs"`
+	// DeletionPolicy determines whether the provider resource is deleted
+	// when this resource is deleted. Defaults to Delete.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty" yaml:"deletionPolicy"`
+}
+
+type PipelineOutput struct {
+	Component string   `json:"component" yaml:"component"`
+	Artifacts []string `json:"artifacts" yaml:"artifacts"`
 }
 
 type PipelineFramework struct {
@@ -97,6 +108,13 @@ func (p Pipeline) GetKind() string {
 	return "pipeline"
 }
 
+func (p Pipeline) GetDeletionPolicy() DeletionPolicy {
+	if p.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return p.Spec.DeletionPolicy
+}
+
 //+kubebuilder:object:root=true
 
 type PipelineList struct {
@@ -2,7 +2,6 @@ package v1beta1
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/samber/lo"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -12,6 +11,37 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+// findReferencedPipeline returns the pipeline referenced at the given path by
+// a resource in the given namespace, or nil if it does not exist. Other
+// errors are returned as internal errors so that resources are not admitted
+// without validation.
+func findReferencedPipeline(
+	ctx context.Context,
+	reader client.Reader,
+	path *field.Path,
+	namespace string,
+	pipelineIdentifier PipelineIdentifier,
+) (*Pipeline, field.ErrorList) {
+	if reader == nil {
+		return nil, nil
+	}
+
+	pipeline := &Pipeline{}
+	if err := reader.Get(
+		ctx,
+		client.ObjectKey{Namespace: namespace, Name: pipelineIdentifier.Name},
+		pipeline,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, field.ErrorList{field.InternalError(path, err)}
+	}
+
+	return pipeline, nil
+}
+
 func NewPipelineValidatorWebhook(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &Pipeline{}).
 		WithValidator(
@@ -77,24 +107,11 @@ func (p *PipelineValidator) validate(
 		)
 	}
 
-	if len(provider.Spec.AllowedNamespaces) > 0 &&
-		!lo.Contains(
-			provider.Spec.AllowedNamespaces,
-			pipeline.GetNamespacedName().Namespace,
-		) {
+	if errs := validateNamespaceAllowed(ctx, p.reader, &provider, pipeline); len(errs) > 0 {
 		return nil, apierrors.NewInvalid(
 			pipeline.GetObjectKind().GroupVersionKind().GroupKind(),
 			pipeline.GetNamespacedName().String(),
-			[]*field.Error{
-				field.Forbidden(
-					field.NewPath("metadata", "namespace"),
-					fmt.Sprintf(
-						"namespace %s is not allowed by provider %s",
-						pipeline.GetNamespacedName().Namespace,
-						provider.GetNamespacedName().String(),
-					),
-				),
-			},
+			errs,
 		)
 	}
 
@@ -11,8 +11,11 @@ import (
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	"github.com/sky-uk/kfp-operator/pkg/common/testutil/mocks"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -142,6 +145,78 @@ var _ = Describe("PipelineValidator Webhook", func() {
 			})
 		})
 
+		When("the pipeline namespace matches the allowed namespace selector of the provider", func() {
+			It("should not error", func() {
+				mockReader.On(
+					"Get",
+					client.ObjectKey{
+						Namespace: pipeline.Spec.Provider.Namespace,
+						Name:      pipeline.Spec.Provider.Name,
+					},
+					mock.AnythingOfType("*v1beta1.Provider"),
+				).Return(nil).Run(
+					func(args mock.Arguments) {
+						provider := args.Get(1).(*Provider)
+						provider.Spec.AllowedNamespaces = []string{"some-other-namespace"}
+						provider.Spec.AllowedNamespaceSelector = &metav1.LabelSelector{
+							MatchLabels: map[string]string{"team": "a"},
+						}
+						provider.Spec.Frameworks = []Framework{{Name: pipeline.Spec.Framework.Name}}
+					},
+				)
+				mockReader.On(
+					"Get",
+					client.ObjectKey{Name: pipeline.GetNamespace()},
+					mock.AnythingOfType("*v1.Namespace"),
+				).Return(nil).Run(
+					func(args mock.Arguments) {
+						namespace := args.Get(1).(*corev1.Namespace)
+						namespace.Labels = map[string]string{"team": "a"}
+					},
+				)
+
+				warnings, err := validator.validate(ctx, &pipeline)
+				Expect(warnings).To(BeNil())
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("the pipeline namespace does not match the allowed namespace selector of the provider", func() {
+			It("should return a StatusError", func() {
+				mockReader.On(
+					"Get",
+					client.ObjectKey{
+						Namespace: pipeline.Spec.Provider.Namespace,
+						Name:      pipeline.Spec.Provider.Name,
+					},
+					mock.AnythingOfType("*v1beta1.Provider"),
+				).Return(nil).Run(
+					func(args mock.Arguments) {
+						provider := args.Get(1).(*Provider)
+						provider.Spec.AllowedNamespaceSelector = &metav1.LabelSelector{
+							MatchLabels: map[string]string{"team": "a"},
+						}
+					},
+				)
+				mockReader.On(
+					"Get",
+					client.ObjectKey{Name: pipeline.GetNamespace()},
+					mock.AnythingOfType("*v1.Namespace"),
+				).Return(nil).Run(
+					func(args mock.Arguments) {
+						namespace := args.Get(1).(*corev1.Namespace)
+						namespace.Labels = map[string]string{"team": "b"}
+					},
+				)
+
+				warnings, err := validator.validate(ctx, &pipeline)
+				Expect(warnings).To(BeNil())
+				var statusErr *apierrors.StatusError
+				Expect(errors.As(err, &statusErr)).To(BeTrue())
+				Expect(statusErr.Status().Details.Causes[0].Type).To(Equal(metav1.CauseTypeForbidden))
+			})
+		})
+
 		When("the provider allows all namespaces", func() {
 			It("should not error", func() {
 				mockReader.On(
@@ -167,4 +242,43 @@ var _ = Describe("PipelineValidator Webhook", func() {
 			})
 		})
 	})
+
+	Context("findReferencedPipeline", func() {
+		identifier := PipelineIdentifier{Name: "pipeline-name"}
+		path := field.NewPath("spec", "pipeline")
+
+		getPipeline := func() *mock.Call {
+			return mockReader.On(
+				"Get",
+				client.ObjectKey{Namespace: "pipeline-ns", Name: identifier.Name},
+				mock.AnythingOfType("*v1beta1.Pipeline"),
+			)
+		}
+
+		It("returns the pipeline", func() {
+			getPipeline().Return(nil)
+
+			pipeline, errs := findReferencedPipeline(ctx, &mockReader, path, "pipeline-ns", identifier)
+			Expect(pipeline).NotTo(BeNil())
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("returns nil when the pipeline does not exist", func() {
+			getPipeline().Return(apierrors.NewNotFound(schema.GroupResource{Resource: "pipelines"}, identifier.Name))
+
+			pipeline, errs := findReferencedPipeline(ctx, &mockReader, path, "pipeline-ns", identifier)
+			Expect(pipeline).To(BeNil())
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("returns an internal error when the pipeline cannot be read", func() {
+			getPipeline().Return(errors.New("unavailable"))
+
+			pipeline, errs := findReferencedPipeline(ctx, &mockReader, path, "pipeline-ns", identifier)
+			Expect(pipeline).To(BeNil())
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInternal))
+			Expect(errs[0].Field).To(Equal("spec.pipeline"))
+		})
+	})
 })
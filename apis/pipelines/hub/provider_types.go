@@ -1,12 +1,16 @@
 package v1beta1
 
 import (
+	"slices"
+
 	"github.com/sky-uk/kfp-operator/apis"
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +kubebuilder:object:root=true
@@ -33,9 +37,10 @@ type ProviderList struct {
 type ProviderSpec struct {
 	ServiceImage string `json:"serviceImage" yaml:"serviceImage"`
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:deprecatedversion=true
 	// +kubebuilder:default=""
-	// Deprecated: This field is ignored and will be removed in future versions.
+	// How the operator performs provider operations. In the direct mode, the
+	// provider-service is called from the controller for resources that need no
+	// compilation. Any other value uses Argo workflows for all operations.
 	ExecutionMode string `json:"executionMode" yaml:"executionMode"`
 	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
 	ServiceAccount      string                           `json:"serviceAccount" yaml:"serviceAccount"`
@@ -44,6 +49,10 @@ type ProviderSpec struct {
 	Frameworks          []Framework                      `json:"frameworks,omitempty" yaml:"frameworks,omitempty"`
 	AllowedNamespaces   []string                         `json:"allowedNamespaces,omitempty" yaml:"allowedNamespaces,omitempty"`
 	// +kubebuilder:validation:Optional
+	// Namespaces whose labels match this selector are allowed in addition to
+	// those listed in allowedNamespaces.
+	AllowedNamespaceSelector *metav1.LabelSelector `json:"allowedNamespaceSelector,omitempty" yaml:"allowedNamespaceSelector,omitempty"`
+	// +kubebuilder:validation:Optional
 	// Environment variables applied to the generated provider-service container,
 	// merged over the operator's defaultProviderValues env; per-provider values
 	// take precedence on name collision.
@@ -58,6 +67,57 @@ type ProviderSpec struct {
 	// over the operator's defaultProviderValues volume mounts; per-provider
 	// values take precedence on mount-path collision.
 	PodTemplateVolumeMounts []corev1.VolumeMount `json:"podTemplateVolumeMounts,omitempty" yaml:"podTemplateVolumeMounts,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Limits on the workflows submitted for the resources of this provider.
+	// Resources are held in their current state while a limit is reached.
+	WorkflowLimits *WorkflowLimits `json:"workflowLimits,omitempty" yaml:"workflowLimits,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Autoscaling of the provider-service deployment, replacing the operator's
+	// defaultProviderValues autoscaling.
+	Autoscaling *ProviderAutoscaling `json:"autoscaling,omitempty" yaml:"autoscaling,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Disruption budget of the provider-service pods, replacing the operator's
+	// defaultProviderValues disruption budget.
+	DisruptionBudget *ProviderDisruptionBudget `json:"disruptionBudget,omitempty" yaml:"disruptionBudget,omitempty"`
+}
+
+type ProviderAutoscaling struct {
+	// +kubebuilder:validation:Minimum=1
+	// The lower bound of replicas, defaults to 1.
+	MinReplicas *int32 `json:"minReplicas,omitempty" yaml:"minReplicas,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// The upper bound of replicas.
+	MaxReplicas int32 `json:"maxReplicas" yaml:"maxReplicas"`
+	// +kubebuilder:validation:Minimum=1
+	// The average CPU utilisation of the pods to scale to, defaults to 80.
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty" yaml:"targetCPUUtilizationPercentage,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type ProviderDisruptionBudget struct {
+	// The number or percentage of pods that must remain available during
+	// voluntary disruptions.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty" yaml:"minAvailable,omitempty"`
+	// The number or percentage of pods that may be unavailable during
+	// voluntary disruptions.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+}
+
+type WorkflowLimits struct {
+	// +kubebuilder:validation:Minimum=0
+	// The maximum number of workflows in flight at once. Zero is unlimited.
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty" yaml:"maxConcurrent,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// The maximum number of workflows submitted per minute. Zero is unlimited.
+	MaxSubmissionsPerMinute int32 `json:"maxSubmissionsPerMinute,omitempty" yaml:"maxSubmissionsPerMinute,omitempty"`
+}
+
+var ExecutionModes = struct {
+	Argo   string
+	Direct string
+}{
+	Argo:   "argo",
+	Direct: "direct",
 }
 
 type Framework struct {
@@ -103,6 +163,46 @@ func (p *Provider) GetKind() string {
 	return "provider"
 }
 
+// RestrictsNamespaces returns true if either allowedNamespaces or
+// allowedNamespaceSelector is set.
+func (p *Provider) RestrictsNamespaces() bool {
+	return len(p.Spec.AllowedNamespaces) > 0 || p.Spec.AllowedNamespaceSelector != nil
+}
+
+// AllowsNamespace returns true if resources in the namespace may use the
+// provider. The labels of the namespace are only looked up when the
+// allowedNamespaceSelector has to be evaluated.
+func (p *Provider) AllowsNamespace(
+	namespace string,
+	namespaceLabels func() (map[string]string, error),
+) (bool, error) {
+	if !p.RestrictsNamespaces() || slices.Contains(p.Spec.AllowedNamespaces, namespace) {
+		return true, nil
+	}
+
+	if p.Spec.AllowedNamespaceSelector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.AllowedNamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+
+	nsLabels, err := namespaceLabels()
+	if err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(nsLabels)), nil
+}
+
+// ExecutesDirectly returns true if the operator calls the provider-service
+// directly instead of submitting Argo workflows where possible.
+func (p *Provider) ExecutesDirectly() bool {
+	return p.Spec.ExecutionMode == ExecutionModes.Direct
+}
+
 func (p *Provider) StatusWithCondition(state apis.SynchronizationState, message string) {
 	p.Status.Conditions = p.Status.Conditions.MergeIntoConditions(metav1.Condition{
 		LastTransitionTime: metav1.Now().Rfc3339Copy(),
@@ -3,6 +3,8 @@
 package v1beta1
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sky-uk/kfp-operator/pkg/common"
@@ -27,4 +29,50 @@ var _ = Context("Provider", func() {
 			}))
 		})
 	})
+
+	var _ = Describe("AllowsNamespace", func() {
+		namespaceLabels := func(labels map[string]string) func() (map[string]string, error) {
+			return func() (map[string]string, error) {
+				return labels, nil
+			}
+		}
+
+		failOnLookup := func() (map[string]string, error) {
+			return nil, errors.New("namespace labels should not be looked up")
+		}
+
+		Specify("Should allow all namespaces when unrestricted", func() {
+			provider := Provider{}
+			Expect(provider.AllowsNamespace("any", failOnLookup)).To(BeTrue())
+		})
+
+		Specify("Should allow listed namespaces without looking up labels", func() {
+			provider := Provider{Spec: ProviderSpec{
+				AllowedNamespaces:        []string{"listed"},
+				AllowedNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			}}
+			Expect(provider.AllowsNamespace("listed", failOnLookup)).To(BeTrue())
+		})
+
+		Specify("Should not allow unlisted namespaces when no selector is set", func() {
+			provider := Provider{Spec: ProviderSpec{AllowedNamespaces: []string{"listed"}}}
+			Expect(provider.AllowsNamespace("unlisted", failOnLookup)).To(BeFalse())
+		})
+
+		Specify("Should allow namespaces matching the selector", func() {
+			provider := Provider{Spec: ProviderSpec{
+				AllowedNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			}}
+			Expect(provider.AllowsNamespace("selected", namespaceLabels(map[string]string{"team": "a"}))).To(BeTrue())
+			Expect(provider.AllowsNamespace("unselected", namespaceLabels(map[string]string{"team": "b"}))).To(BeFalse())
+		})
+
+		Specify("Should return an error when the namespace cannot be looked up", func() {
+			provider := Provider{Spec: ProviderSpec{
+				AllowedNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			}}
+			_, err := provider.AllowsNamespace("selected", failOnLookup)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -6,12 +6,87 @@ import (
 	"strings"
 
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+// findReferencedProvider returns the provider referenced at the given path,
+// or nil if it does not exist. Other errors are returned as internal errors
+// so that resources are not admitted without validation.
+func findReferencedProvider(
+	ctx context.Context,
+	reader client.Reader,
+	path *field.Path,
+	name common.NamespacedName,
+) (*Provider, field.ErrorList) {
+	if reader == nil {
+		return nil, nil
+	}
+
+	provider := &Provider{}
+	if err := reader.Get(
+		ctx,
+		client.ObjectKey{Namespace: name.Namespace, Name: name.Name},
+		provider,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, field.ErrorList{field.InternalError(path, err)}
+	}
+
+	return provider, nil
+}
+
+// validateNamespaceAllowed rejects resources in namespaces that the provider
+// does not allow. Namespace labels are read only if the provider's
+// allowedNamespaceSelector has to be evaluated. Resources that are being
+// deleted are exempt so that finalizers can still be removed.
+func validateNamespaceAllowed(
+	ctx context.Context,
+	reader client.Reader,
+	provider *Provider,
+	resource metav1.Object,
+) field.ErrorList {
+	if provider == nil || !resource.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	namespace := resource.GetNamespace()
+
+	path := field.NewPath("metadata", "namespace")
+
+	allowed, err := provider.AllowsNamespace(namespace, func() (map[string]string, error) {
+		ns := &corev1.Namespace{}
+		if err := reader.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+			return nil, err
+		}
+		return ns.Labels, nil
+	})
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+
+	if !allowed {
+		return field.ErrorList{field.Forbidden(
+			path,
+			fmt.Sprintf(
+				"namespace %s is not allowed by provider %s",
+				namespace,
+				provider.GetNamespacedName().String(),
+			),
+		)}
+	}
+
+	return nil
+}
+
 func (p *Provider) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, p).
 		Complete()
@@ -73,6 +148,16 @@ func (v *ProviderValidator) validate(
 		}
 	}
 
+	if provider.Spec.AllowedNamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(provider.Spec.AllowedNamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(
+				field.NewPath("spec", "allowedNamespaceSelector"),
+				provider.Spec.AllowedNamespaceSelector,
+				err.Error(),
+			))
+		}
+	}
+
 	if len(errs) == 0 {
 		return nil, nil
 	}
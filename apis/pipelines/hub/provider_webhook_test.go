@@ -3,11 +3,20 @@
 package v1beta1
 
 import (
+	"context"
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/testutil/mocks"
+	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("ProviderValidator Webhook", func() {
@@ -27,6 +36,19 @@ var _ = Describe("ProviderValidator Webhook", func() {
 	})
 
 	Context("validate", func() {
+		When("allowedNamespaceSelector is invalid", func() {
+			It("should return a StatusError", func() {
+				provider.Spec.AllowedNamespaceSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: "Unknown"},
+					},
+				}
+
+				_, err := validator.validate(&provider)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			})
+		})
+
 		When("podTemplateEnv contains no reserved names", func() {
 			It("should not error", func() {
 				provider.Spec.PodTemplateEnv = []corev1.EnvVar{
@@ -87,4 +109,52 @@ var _ = Describe("ProviderValidator Webhook", func() {
 			})
 		})
 	})
+
+	Context("findReferencedProvider", func() {
+		var (
+			mockReader mocks.MockK8sClientReader
+			name       common.NamespacedName
+			path       *field.Path
+		)
+
+		BeforeEach(func() {
+			mockReader = mocks.MockK8sClientReader{}
+			name = common.NamespacedName{Name: "provider-name", Namespace: "provider-ns"}
+			path = field.NewPath("spec", "provider")
+		})
+
+		getProvider := func() *mock.Call {
+			return mockReader.On(
+				"Get",
+				client.ObjectKey{Namespace: name.Namespace, Name: name.Name},
+				mock.AnythingOfType("*v1beta1.Provider"),
+			)
+		}
+
+		It("returns the provider", func() {
+			getProvider().Return(nil)
+
+			provider, errs := findReferencedProvider(context.Background(), &mockReader, path, name)
+			Expect(provider).NotTo(BeNil())
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("returns nil when the provider does not exist", func() {
+			getProvider().Return(apierrors.NewNotFound(schema.GroupResource{Resource: "providers"}, name.Name))
+
+			provider, errs := findReferencedProvider(context.Background(), &mockReader, path, name)
+			Expect(provider).To(BeNil())
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("returns an internal error when the provider cannot be read", func() {
+			getProvider().Return(errors.New("unavailable"))
+
+			provider, errs := findReferencedProvider(context.Background(), &mockReader, path, name)
+			Expect(provider).To(BeNil())
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInternal))
+			Expect(errs[0].Field).To(Equal("spec.provider"))
+		})
+	})
 })
@@ -1,11 +1,46 @@
 package v1beta1
 
 import (
+	"github.com/sky-uk/kfp-operator/apis"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// AdoptProviderIdAnnotation makes the operator adopt the existing provider
+// resource with the annotated id instead of creating a new one.
+const AdoptProviderIdAnnotation = apis.Group + "/adopt-provider-id"
+
+// DryRunAnnotation makes the operator render what it would submit to the
+// provider instead of synchronising the resource when set to "true".
+const DryRunAnnotation = apis.Group + "/dry-run"
+
+// IsDryRun returns true if the resource is annotated with DryRunAnnotation.
+func IsDryRun(resource metav1.Object) bool {
+	return resource.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+// RetryMaxAttemptsAnnotation overrides the number of times the operator
+// retries a failed synchronisation of the annotated resource.
+const RetryMaxAttemptsAnnotation = apis.Group + "/retry-max-attempts"
+
+// DeletionPolicy determines whether the provider resource is deleted together
+// with the resource that manages it.
+// +kubebuilder:validation:Enum=Delete;Retain
+type DeletionPolicy string
+
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// +kubebuilder:object:generate=false
+type ResourceWithDeletionPolicy interface {
+	// GetDeletionPolicy returns the deletion policy of the resource, which
+	// defaults to DeletionPolicyDelete.
+	GetDeletionPolicy() DeletionPolicy
+}
+
 // +kubebuilder:object:generate=false
 type Resource interface {
 	metav1.Object
@@ -152,6 +152,10 @@ func (r Run) ComputeVersion() string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// RunCompletionTTLAnnotation overrides how long a run is kept after its
+// completion with a duration such as "72h".
+const RunCompletionTTLAnnotation = apis.Group + "/completion-ttl"
+
 type CompletionState string
 
 var CompletionStates = struct {
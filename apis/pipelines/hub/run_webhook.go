@@ -7,21 +7,26 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func NewRunValidatorWebhook(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &Run{}).
-		WithValidator(&RunValidator{}).
+		WithValidator(&RunValidator{
+			reader: mgr.GetClient(),
+		}).
 		Complete()
 }
 
 // +kubebuilder:webhook:path=/validate-pipelines-kubeflow-org-v1beta1-run,mutating=false,failurePolicy=fail,sideEffects=None,groups=pipelines.kubeflow.org,resources=runs,verbs=create;update,versions=v1beta1,name=vrun.kb.io,admissionReviewVersions=v1
 // +kubebuilder:object:generate=false
 
-type RunValidator struct{}
+type RunValidator struct {
+	reader client.Reader
+}
 
-func (*RunValidator) ValidateCreate(
+func (v *RunValidator) ValidateCreate(
 	ctx context.Context,
 	r *Run,
 ) (admission.Warnings, error) {
@@ -37,6 +42,20 @@ func (*RunValidator) ValidateCreate(
 		}
 	}
 
+	pipeline, errors := findReferencedPipeline(ctx, v.reader, field.NewPath("spec", "pipeline"), r.Namespace, r.Spec.Pipeline)
+	errors = append(errors, validateOutputArtifacts(
+		field.NewPath("spec").Child("artifacts"),
+		r.Spec.Artifacts,
+		pipeline,
+	)...)
+	provider, providerErrors := findReferencedProvider(ctx, v.reader, field.NewPath("spec", "provider"), r.Spec.Provider)
+	errors = append(errors, providerErrors...)
+	errors = append(errors, validateNamespaceAllowed(ctx, v.reader, provider, r)...)
+
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(r.GroupVersionKind().GroupKind(), r.Name, errors)
+	}
+
 	return nil, nil
 }
 
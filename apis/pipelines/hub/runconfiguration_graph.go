@@ -0,0 +1,95 @@
+package v1beta1
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// dependencies returns the RunConfigurations referenced by rc, with
+// namespaces defaulted to that of rc. RunConfigurations that are only
+// referenced by optional parameters are excluded as they may legitimately
+// form recursive dependencies, e.g. a RunConfiguration consuming its own
+// previous output.
+func (rc *RunConfiguration) dependencies() []types.NamespacedName {
+	requiredArtifactRcs := lo.FilterMap(rc.GetReferencedRCArtifacts(), func(r RunConfigurationRef, _ int) (common.NamespacedName, bool) {
+		return r.Name, !r.Optional
+	})
+
+	return lo.FilterMap(rc.GetReferencedRCs(), func(reference common.NamespacedName, _ int) (types.NamespacedName, bool) {
+		if reference.Name == "" {
+			return types.NamespacedName{}, false
+		}
+
+		if !slices.Contains(rc.Spec.Triggers.RunConfigurations, reference) && !slices.Contains(requiredArtifactRcs, reference) {
+			return types.NamespacedName{}, false
+		}
+
+		namespace := reference.Namespace
+		if namespace == "" {
+			namespace = rc.Namespace
+		}
+
+		return types.NamespacedName{Namespace: namespace, Name: reference.Name}, true
+	})
+}
+
+// DependencyCycle is a path of RunConfigurations that starts and ends with the
+// same RunConfiguration.
+type DependencyCycle []types.NamespacedName
+
+func (dc DependencyCycle) String() string {
+	return strings.Join(lo.Map(dc, func(nsn types.NamespacedName, _ int) string {
+		return nsn.String()
+	}), " -> ")
+}
+
+// FindDependencyCycle returns a dependency cycle through rc, or nil if there is
+// none. The graph is built from rc and the given RunConfigurations, with rc
+// taking precedence over a stored version of itself.
+func FindDependencyCycle(rc *RunConfiguration, runConfigurations []RunConfiguration) DependencyCycle {
+	start := rc.GetNamespacedName()
+
+	edges := lo.SliceToMap(runConfigurations, func(other RunConfiguration) (types.NamespacedName, []types.NamespacedName) {
+		return other.GetNamespacedName(), other.dependencies()
+	})
+	edges[start] = rc.dependencies()
+
+	visited := map[types.NamespacedName]bool{}
+	var path []types.NamespacedName
+
+	var visit func(current types.NamespacedName) bool
+	visit = func(current types.NamespacedName) bool {
+		path = append(path, current)
+
+		for _, next := range edges[current] {
+			if next == start {
+				path = append(path, next)
+				return true
+			}
+
+			if !visited[next] {
+				visited[next] = true
+				if visit(next) {
+					return true
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if !visit(start) {
+		return nil
+	}
+
+	// dependencies point upstream, so reverse the path to follow the direction
+	// in which runs are triggered
+	slices.Reverse(path)
+
+	return path
+}
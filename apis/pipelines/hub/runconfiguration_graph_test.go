@@ -0,0 +1,119 @@
+//go:build unit
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Context("RunConfiguration dependency graph", func() {
+	runConfiguration := func(name string) *RunConfiguration {
+		return &RunConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+	}
+
+	triggeredBy := func(rc *RunConfiguration, names ...string) *RunConfiguration {
+		for _, name := range names {
+			rc.Spec.Triggers.RunConfigurations = append(
+				rc.Spec.Triggers.RunConfigurations,
+				common.NamespacedName{Name: name},
+			)
+		}
+		return rc
+	}
+
+	parameterFrom := func(rc *RunConfiguration, name string, optional bool) *RunConfiguration {
+		rc.Spec.Run.Parameters = append(rc.Spec.Run.Parameters, Parameter{
+			Name: name,
+			ValueFrom: &ValueFrom{
+				RunConfigurationRef: RunConfigurationRef{
+					Name:           common.NamespacedName{Namespace: "default", Name: name},
+					OutputArtifact: "artifact",
+					Optional:       optional,
+				},
+			},
+		})
+		return rc
+	}
+
+	nsn := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "default", Name: name}
+	}
+
+	Describe("dependencies", func() {
+		It("defaults namespaces and excludes optional parameters", func() {
+			rc := triggeredBy(runConfiguration("a"), "b")
+			rc = parameterFrom(rc, "c", false)
+			rc = parameterFrom(rc, "d", true)
+
+			Expect(rc.dependencies()).To(ConsistOf(nsn("b"), nsn("c")))
+		})
+
+		It("includes RunConfigurations that are referenced both optionally and as a trigger", func() {
+			rc := triggeredBy(runConfiguration("a"), "b")
+			rc = parameterFrom(rc, "b", true)
+
+			Expect(rc.dependencies()).To(ConsistOf(nsn("b")))
+		})
+	})
+
+	Describe("FindDependencyCycle", func() {
+		It("returns nil for an acyclic graph", func() {
+			a := triggeredBy(runConfiguration("a"), "b")
+			b := triggeredBy(runConfiguration("b"), "c")
+			c := runConfiguration("c")
+
+			Expect(FindDependencyCycle(a, []RunConfiguration{*b, *c})).To(BeNil())
+		})
+
+		It("detects mutual triggers", func() {
+			a := triggeredBy(runConfiguration("a"), "b")
+			b := triggeredBy(runConfiguration("b"), "a")
+
+			cycle := FindDependencyCycle(a, []RunConfiguration{*b})
+			Expect(cycle).To(Equal(DependencyCycle{nsn("a"), nsn("b"), nsn("a")}))
+			Expect(cycle.String()).To(Equal("default/a -> default/b -> default/a"))
+		})
+
+		It("detects self triggers", func() {
+			a := triggeredBy(runConfiguration("a"), "a")
+
+			Expect(FindDependencyCycle(a, nil)).To(Equal(DependencyCycle{nsn("a"), nsn("a")}))
+		})
+
+		It("detects cycles through required parameters", func() {
+			a := parameterFrom(runConfiguration("a"), "b", false)
+			b := triggeredBy(runConfiguration("b"), "c")
+			c := triggeredBy(runConfiguration("c"), "a")
+
+			Expect(FindDependencyCycle(a, []RunConfiguration{*b, *c})).To(HaveLen(4))
+		})
+
+		It("ignores cycles through optional parameters", func() {
+			a := parameterFrom(runConfiguration("a"), "a", true)
+
+			Expect(FindDependencyCycle(a, nil)).To(BeNil())
+		})
+
+		It("prefers the given version of the RunConfiguration over the stored one", func() {
+			a := runConfiguration("a")
+			storedA := triggeredBy(runConfiguration("a"), "b")
+			b := triggeredBy(runConfiguration("b"), "a")
+
+			Expect(FindDependencyCycle(a, []RunConfiguration{*storedA, *b})).To(BeNil())
+		})
+
+		It("ignores cycles that do not include the RunConfiguration", func() {
+			a := triggeredBy(runConfiguration("a"), "b")
+			b := triggeredBy(runConfiguration("b"), "c")
+			c := triggeredBy(runConfiguration("c"), "b")
+
+			Expect(FindDependencyCycle(a, []RunConfiguration{*b, *c})).To(BeNil())
+		})
+	})
+})
@@ -30,6 +30,33 @@ var OnChangeTypes = struct {
 type RunConfigurationSpec struct {
 	Run      RunSpec  `json:"run,omitempty"`
 	Triggers Triggers `json:"triggers,omitempty"`
+	// SuccessfulRunsHistoryLimit is the number of succeeded runs to keep.
+	// All runs are kept when it is not set.
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+	// FailedRunsHistoryLimit is the number of failed runs to keep. All runs
+	// are kept when it is not set.
+	// +kubebuilder:validation:Minimum=0
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+}
+
+// RunsHistoryLimit returns the number of runs to keep that completed in the
+// given state and false if they are not limited.
+func (rcs RunConfigurationSpec) RunsHistoryLimit(state CompletionState) (int, bool) {
+	var limit *int32
+
+	switch state {
+	case CompletionStates.Succeeded:
+		limit = rcs.SuccessfulRunsHistoryLimit
+	case CompletionStates.Failed:
+		limit = rcs.FailedRunsHistoryLimit
+	}
+
+	if limit == nil {
+		return 0, false
+	}
+
+	return int(*limit), true
 }
 
 type TriggeredRunReference struct {
@@ -2,24 +2,33 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/samber/lo"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func NewRunConfigurationValidatorWebhook(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &RunConfiguration{}).
-		WithValidator(&RunConfigurationValidator{}).
+		WithValidator(&RunConfigurationValidator{
+			reader: mgr.GetClient(),
+		}).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/validate-pipelines-kubeflow-org-v1beta1-runconfiguration,mutating=false,failurePolicy=fail,sideEffects=None,groups=pipelines.kubeflow.org,resources=runconfigurations,verbs=create;update,versions=v1beta1,name=vrunconfiguration.kb.io,admissionReviewVersions=v1
 // +kubebuilder:object:generate=false
 
-type RunConfigurationValidator struct{}
+type RunConfigurationValidator struct {
+	reader client.Reader
+}
 
 func (rc *RunConfiguration) validateUniqueStructures() (errors field.ErrorList) {
 	duplicateSchedules := lo.FindDuplicates(rc.Spec.Triggers.Schedules)
@@ -50,29 +59,104 @@ func (rc *RunConfiguration) validateRunParameters() (errors field.ErrorList) {
 	return
 }
 
-func (rc *RunConfiguration) validate() (admission.Warnings, error) {
+// validateSchedules only rejects expired end times on schedules that were
+// not already present on the previous version of the resource.
+func (rc *RunConfiguration) validateSchedules(
+	old *RunConfiguration,
+	now time.Time,
+) (warnings admission.Warnings, errors field.ErrorList) {
+	schedulesPath := field.NewPath("spec").Child("triggers").Child("schedules")
+	for i, schedule := range rc.Spec.Triggers.Schedules {
+		checkExpiry := old == nil || !slices.ContainsFunc(old.Spec.Triggers.Schedules, func(oldSchedule Schedule) bool {
+			return reflect.DeepEqual(oldSchedule, schedule)
+		})
+
+		scheduleWarnings, scheduleErrors := schedule.validate(schedulesPath.Index(i), now, checkExpiry)
+		warnings = append(warnings, scheduleWarnings...)
+		errors = append(errors, scheduleErrors...)
+	}
+
+	return
+}
+
+// validateDependencyCycles rejects triggers and required parameter references
+// that would close a cycle in the RunConfiguration dependency graph.
+func (rc *RunConfiguration) validateDependencyCycles(runConfigurations []RunConfiguration) (errors field.ErrorList) {
+	cycle := FindDependencyCycle(rc, runConfigurations)
+	if cycle == nil {
+		return
+	}
+
+	return field.ErrorList{
+		field.Forbidden(
+			field.NewPath("spec"),
+			fmt.Sprintf("dependency cycle detected: %s", cycle),
+		),
+	}
+}
+
+func (rc *RunConfiguration) validate(
+	old *RunConfiguration,
+	pipeline *Pipeline,
+	runConfigurations []RunConfiguration,
+	now time.Time,
+) (admission.Warnings, error) {
 	errors := append(rc.validateRunParameters(), rc.validateUniqueStructures()...)
+	errors = append(errors, validateOutputArtifacts(
+		field.NewPath("spec").Child("run").Child("artifacts"),
+		rc.Spec.Run.Artifacts,
+		pipeline,
+	)...)
+	errors = append(errors, rc.validateDependencyCycles(runConfigurations)...)
+	warnings, scheduleErrors := rc.validateSchedules(old, now)
+	errors = append(errors, scheduleErrors...)
 
 	if len(errors) > 0 {
 		return nil, apierrors.NewInvalid(rc.GroupVersionKind().GroupKind(), rc.Name, errors)
 	}
 
-	return nil, nil
+	return warnings, nil
+}
+
+func (v *RunConfigurationValidator) validate(
+	ctx context.Context,
+	old *RunConfiguration,
+	rc *RunConfiguration,
+) (admission.Warnings, error) {
+	provider, errors := findReferencedProvider(ctx, v.reader, field.NewPath("spec", "run", "provider"), rc.Spec.Run.Provider)
+	errors = append(errors, validateNamespaceAllowed(ctx, v.reader, provider, rc)...)
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(rc.GroupVersionKind().GroupKind(), rc.Name, errors)
+	}
+
+	pipeline, errors := findReferencedPipeline(ctx, v.reader, field.NewPath("spec", "run", "pipeline"), rc.Namespace, rc.Spec.Run.Pipeline)
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(rc.GroupVersionKind().GroupKind(), rc.Name, errors)
+	}
+
+	runConfigurations := &RunConfigurationList{}
+	if v.reader != nil {
+		if err := v.reader.List(ctx, runConfigurations); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+
+	return rc.validate(old, pipeline, runConfigurations.Items, time.Now())
 }
 
-func (*RunConfigurationValidator) ValidateCreate(
+func (v *RunConfigurationValidator) ValidateCreate(
 	ctx context.Context,
 	rc *RunConfiguration,
 ) (admission.Warnings, error) {
-	return rc.validate()
+	return v.validate(ctx, nil, rc)
 }
 
-func (*RunConfigurationValidator) ValidateUpdate(
+func (v *RunConfigurationValidator) ValidateUpdate(
 	ctx context.Context,
-	_ *RunConfiguration,
+	oldRc *RunConfiguration,
 	rc *RunConfiguration,
 ) (admission.Warnings, error) {
-	return rc.validate()
+	return v.validate(ctx, oldRc, rc)
 }
 
 func (*RunConfigurationValidator) ValidateDelete(
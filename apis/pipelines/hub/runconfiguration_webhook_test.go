@@ -3,10 +3,14 @@
 package v1beta1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sky-uk/kfp-operator/apis"
+	"github.com/sky-uk/kfp-operator/pkg/common"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Context("RunConfiguration Webhook", func() {
@@ -22,7 +26,7 @@ var _ = Context("RunConfiguration Webhook", func() {
 			},
 		}
 
-		_, err := runConfiguration.validate()
+		_, err := runConfiguration.validate(nil, nil, nil, time.Now())
 		Expect(errors.IsInvalid(err)).To(BeTrue())
 	})
 
@@ -38,7 +42,7 @@ var _ = Context("RunConfiguration Webhook", func() {
 			},
 		}
 
-		_, err := runConfiguration.validate()
+		_, err := runConfiguration.validate(nil, nil, nil, time.Now())
 		Expect(errors.IsInvalid(err)).To(BeTrue())
 	})
 
@@ -65,7 +69,7 @@ var _ = Context("RunConfiguration Webhook", func() {
 			},
 		}
 
-		_, err := runConfiguration.validate()
+		_, err := runConfiguration.validate(nil, nil, nil, time.Now())
 		Expect(errors.IsInvalid(err)).To(BeTrue())
 	})
 
@@ -95,8 +99,67 @@ var _ = Context("RunConfiguration Webhook", func() {
 			},
 		}
 
-		warnings, err := runConfiguration.validate()
-		Expect(warnings).To(BeNil())
+		warnings, err := runConfiguration.validate(nil, nil, nil, time.Now())
+		Expect(warnings).To(HaveLen(1))
 		Expect(err).ToNot(HaveOccurred())
 	})
+
+	Specify("Invalid schedules fail the validation", func() {
+		runConfiguration := RunConfiguration{
+			Spec: RunConfigurationSpec{
+				Triggers: Triggers{
+					Schedules: []Schedule{
+						{CronExpression: "0 0 * *"},
+					},
+				},
+			},
+		}
+
+		_, err := runConfiguration.validate(nil, nil, nil, time.Now())
+		Expect(errors.IsInvalid(err)).To(BeTrue())
+	})
+
+	Specify("Expired schedules only fail the validation when they are added", func() {
+		expiredSchedule := Schedule{
+			CronExpression: "0 0 * * *",
+			EndTime:        &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		}
+		oldRunConfiguration := RunConfiguration{
+			Spec: RunConfigurationSpec{
+				Triggers: Triggers{
+					Schedules: []Schedule{expiredSchedule},
+				},
+			},
+		}
+		runConfiguration := oldRunConfiguration.DeepCopy()
+		runConfiguration.Spec.Triggers.OnChange = []OnChangeType{OnChangeTypes.RunSpec}
+
+		_, err := runConfiguration.validate(nil, nil, nil, time.Now())
+		Expect(errors.IsInvalid(err)).To(BeTrue())
+
+		_, err = runConfiguration.validate(&oldRunConfiguration, nil, nil, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Specify("Dependency cycles fail the validation", func() {
+		runConfiguration := RunConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+			Spec: RunConfigurationSpec{
+				Triggers: Triggers{
+					RunConfigurations: []common.NamespacedName{{Name: "b"}},
+				},
+			},
+		}
+		dependency := RunConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+			Spec: RunConfigurationSpec{
+				Triggers: Triggers{
+					RunConfigurations: []common.NamespacedName{{Name: "a"}},
+				},
+			},
+		}
+
+		_, err := runConfiguration.validate(nil, nil, []RunConfiguration{dependency}, time.Now())
+		Expect(errors.IsInvalid(err)).To(BeTrue())
+	})
 })
@@ -19,6 +19,9 @@ type RunScheduleSpec struct {
 	Parameters     []apis.NamedValue     `json:"parameters,omitempty"`
 	Artifacts      []OutputArtifact      `json:"artifacts,omitempty"`
 	Schedule       Schedule              `json:"schedule,omitempty"`
+	// DeletionPolicy determines whether the provider resource is deleted
+	// when this resource is deleted. Defaults to Delete.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 type Schedule struct {
@@ -91,6 +94,13 @@ func (rs *RunSchedule) GetKind() string {
 	return "runschedule"
 }
 
+func (rs *RunSchedule) GetDeletionPolicy() DeletionPolicy {
+	if rs.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return rs.Spec.DeletionPolicy
+}
+
 //+kubebuilder:object:root=true
 
 type RunScheduleList struct {
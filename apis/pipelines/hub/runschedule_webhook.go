@@ -1,10 +1,218 @@
 package v1beta1
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/samber/lo"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	standardCronFields = 5
+	goCronFields       = 6
+	fireTimePreviews   = 3
 )
 
+// cronParser mirrors the dialects accepted by the providers: KFP takes six
+// fields with a leading seconds field, Vertex AI takes the standard five.
+// Five field expressions are normalised to six before parsing.
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+func parseCronExpression(expression string) (cron.Schedule, error) {
+	fields := strings.Fields(expression)
+
+	switch len(fields) {
+	case standardCronFields:
+		fields = append([]string{"0"}, fields...)
+	case goCronFields:
+	default:
+		return nil, fmt.Errorf(
+			"expected %d or %d fields but found %d",
+			standardCronFields,
+			goCronFields,
+			len(fields),
+		)
+	}
+
+	return cronParser.Parse(strings.Join(fields, " "))
+}
+
+// validate checks the cron syntax and the start and end times of a schedule
+// and returns warnings previewing its next fire times. Expired end times are
+// only rejected when checkExpiry is set, so that unrelated updates to
+// resources with schedules that have run their course are still admitted.
+func (s Schedule) validate(
+	path *field.Path,
+	now time.Time,
+	checkExpiry bool,
+) (warnings admission.Warnings, errors field.ErrorList) {
+	if s.EndTime != nil {
+		if s.StartTime != nil && !s.EndTime.After(s.StartTime.Time) {
+			errors = append(errors, field.Invalid(
+				path.Child("endTime"),
+				s.EndTime.String(),
+				"must be after startTime",
+			))
+		}
+
+		if checkExpiry && !s.EndTime.After(now) {
+			errors = append(errors, field.Invalid(
+				path.Child("endTime"),
+				s.EndTime.String(),
+				"must not be in the past",
+			))
+		}
+	}
+
+	if s.CronExpression == "" {
+		return nil, append(errors, field.Required(path.Child("cronExpression"), ""))
+	}
+
+	cronSchedule, err := parseCronExpression(s.CronExpression)
+	if err != nil {
+		return nil, append(errors, field.Invalid(
+			path.Child("cronExpression"),
+			s.CronExpression,
+			err.Error(),
+		))
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	fireTimes := s.nextFireTimes(cronSchedule, now, fireTimePreviews)
+	if len(fireTimes) == 0 {
+		return admission.Warnings{
+			fmt.Sprintf("%s: schedule will not fire before its end time", path),
+		}, nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf(
+			"%s: next runs at %s",
+			path,
+			strings.Join(lo.Map(fireTimes, func(t time.Time, _ int) string {
+				return t.UTC().Format(time.RFC3339)
+			}), ", "),
+		),
+	}, nil
+}
+
+func (s Schedule) nextFireTimes(cronSchedule cron.Schedule, now time.Time, count int) (fireTimes []time.Time) {
+	next := now
+	if s.StartTime != nil && s.StartTime.After(now) {
+		// cron.Schedule.Next is exclusive, so step back to include the start time itself
+		next = s.StartTime.Add(-time.Nanosecond)
+	}
+
+	for len(fireTimes) < count {
+		next = cronSchedule.Next(next.UTC())
+		if next.IsZero() || (s.EndTime != nil && next.After(s.EndTime.Time)) {
+			break
+		}
+		fireTimes = append(fireTimes, next)
+	}
+
+	return
+}
+
 func (rs *RunSchedule) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, rs).
 		Complete()
 }
+
+func NewRunScheduleValidatorWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &RunSchedule{}).
+		WithValidator(&RunScheduleValidator{
+			reader: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-pipelines-kubeflow-org-v1beta1-runschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=pipelines.kubeflow.org,resources=runschedules,verbs=create;update,versions=v1beta1,name=vrunschedule.kb.io,admissionReviewVersions=v1
+//+kubebuilder:object:generate=false
+
+type RunScheduleValidator struct {
+	reader client.Reader
+}
+
+// validate rejects expired end times only for schedules that are new or have
+// changed. RunSchedules controlled by a RunConfiguration are exempt as their
+// schedules have already been validated on the owner.
+func (rs *RunSchedule) validate(
+	old *RunSchedule,
+	pipeline *Pipeline,
+	now time.Time,
+) (admission.Warnings, error) {
+	checkExpiry := metav1.GetControllerOf(rs) == nil &&
+		(old == nil || !reflect.DeepEqual(old.Spec.Schedule, rs.Spec.Schedule))
+
+	warnings, errors := rs.Spec.Schedule.validate(
+		field.NewPath("spec").Child("schedule"),
+		now,
+		checkExpiry,
+	)
+	errors = append(errors, validateOutputArtifacts(
+		field.NewPath("spec").Child("artifacts"),
+		rs.Spec.Artifacts,
+		pipeline,
+	)...)
+
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(rs.GroupVersionKind().GroupKind(), rs.Name, errors)
+	}
+
+	return warnings, nil
+}
+
+func (v *RunScheduleValidator) validate(
+	ctx context.Context,
+	old *RunSchedule,
+	rs *RunSchedule,
+) (admission.Warnings, error) {
+	provider, errors := findReferencedProvider(ctx, v.reader, field.NewPath("spec", "provider"), rs.Spec.Provider)
+	errors = append(errors, validateNamespaceAllowed(ctx, v.reader, provider, rs)...)
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(rs.GroupVersionKind().GroupKind(), rs.Name, errors)
+	}
+
+	pipeline, errors := findReferencedPipeline(ctx, v.reader, field.NewPath("spec", "pipeline"), rs.Namespace, rs.Spec.Pipeline)
+	if len(errors) > 0 {
+		return nil, apierrors.NewInvalid(rs.GroupVersionKind().GroupKind(), rs.Name, errors)
+	}
+
+	return rs.validate(old, pipeline, time.Now())
+}
+
+func (v *RunScheduleValidator) ValidateCreate(
+	ctx context.Context,
+	rs *RunSchedule,
+) (admission.Warnings, error) {
+	return v.validate(ctx, nil, rs)
+}
+
+func (v *RunScheduleValidator) ValidateUpdate(
+	ctx context.Context,
+	oldRs *RunSchedule,
+	newRs *RunSchedule,
+) (admission.Warnings, error) {
+	return v.validate(ctx, oldRs, newRs)
+}
+
+func (*RunScheduleValidator) ValidateDelete(
+	_ context.Context,
+	_ *RunSchedule,
+) (admission.Warnings, error) {
+	return nil, nil
+}
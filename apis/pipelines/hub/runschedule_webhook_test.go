@@ -0,0 +1,155 @@
+//go:build unit
+
+package v1beta1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/apis"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Context("Schedule validation", func() {
+	now := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	path := field.NewPath("spec").Child("schedule")
+	at := func(t time.Time) *metav1.Time {
+		return &metav1.Time{Time: t}
+	}
+
+	DescribeTable("accepts cron expressions in the dialects of the providers",
+		func(expression string) {
+			_, errs := Schedule{CronExpression: expression}.validate(path, now, true)
+			Expect(errs).To(BeEmpty())
+		},
+		Entry("standard", "0 * * * *"),
+		Entry("with seconds", "0 0 * * * *"),
+		Entry("ranges and steps", "*/15 9-17 * * 1-5"),
+		Entry("names", "0 0 1 jan mon"),
+	)
+
+	DescribeTable("rejects invalid cron expressions",
+		func(expression string) {
+			_, errs := Schedule{CronExpression: expression}.validate(path, now, true)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.schedule.cronExpression"))
+		},
+		Entry("empty", ""),
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * * *"),
+		Entry("out of range", "0 25 * * *"),
+		Entry("descriptor", "@daily"),
+		Entry("time zone", "TZ=UTC 0 * * * *"),
+		Entry("garbage", "a b c d e"),
+	)
+
+	Specify("rejects an end time that is not after the start time", func() {
+		_, errs := Schedule{
+			CronExpression: "0 * * * *",
+			StartTime:      at(now.Add(2 * time.Hour)),
+			EndTime:        at(now.Add(time.Hour)),
+		}.validate(path, now, true)
+
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.schedule.endTime"))
+	})
+
+	Specify("rejects an end time in the past when expiry is checked", func() {
+		schedule := Schedule{
+			CronExpression: "0 * * * *",
+			EndTime:        at(now.Add(-time.Hour)),
+		}
+
+		_, errs := schedule.validate(path, now, true)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.schedule.endTime"))
+
+		_, errs = schedule.validate(path, now, false)
+		Expect(errs).To(BeEmpty())
+	})
+
+	Specify("previews the next fire times", func() {
+		warnings, errs := Schedule{CronExpression: "0 * * * *"}.validate(path, now, true)
+
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(ConsistOf(
+			"spec.schedule: next runs at 2024-01-01T13:00:00Z, 2024-01-01T14:00:00Z, 2024-01-01T15:00:00Z",
+		))
+	})
+
+	Specify("previews fire times between the start and end time", func() {
+		warnings, errs := Schedule{
+			CronExpression: "0 0 * * *",
+			StartTime:      at(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			EndTime:        at(time.Date(2024, 2, 2, 12, 0, 0, 0, time.UTC)),
+		}.validate(path, now, true)
+
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(ConsistOf(
+			"spec.schedule: next runs at 2024-02-01T00:00:00Z, 2024-02-02T00:00:00Z",
+		))
+	})
+
+	Specify("warns when the schedule never fires", func() {
+		warnings, errs := Schedule{
+			CronExpression: "0 0 * * *",
+			EndTime:        at(now.Add(time.Hour)),
+		}.validate(path, now, true)
+
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(ConsistOf("spec.schedule: schedule will not fire before its end time"))
+	})
+})
+
+var _ = Context("RunSchedule Webhook", func() {
+	now := time.Now()
+	expiredSchedule := Schedule{
+		CronExpression: "0 * * * *",
+		EndTime:        &metav1.Time{Time: now.Add(-time.Hour)},
+	}
+
+	Specify("An invalid schedule fails the validation", func() {
+		runSchedule := RunSchedule{
+			Spec: RunScheduleSpec{Schedule: Schedule{CronExpression: apis.RandomString()}},
+		}
+
+		_, err := runSchedule.validate(nil, nil, now)
+		Expect(errors.IsInvalid(err)).To(BeTrue())
+	})
+
+	Specify("A new expired schedule fails the validation", func() {
+		runSchedule := RunSchedule{Spec: RunScheduleSpec{Schedule: expiredSchedule}}
+
+		_, err := runSchedule.validate(nil, nil, now)
+		Expect(errors.IsInvalid(err)).To(BeTrue())
+	})
+
+	Specify("An unchanged expired schedule passes the validation", func() {
+		runSchedule := RunSchedule{Spec: RunScheduleSpec{Schedule: expiredSchedule}}
+		oldRunSchedule := runSchedule.DeepCopy()
+
+		_, err := runSchedule.validate(oldRunSchedule, nil, now)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Specify("An expired schedule controlled by a RunConfiguration passes the validation", func() {
+		runSchedule := RunSchedule{Spec: RunScheduleSpec{Schedule: expiredSchedule}}
+		runSchedule.OwnerReferences = []metav1.OwnerReference{
+			{Kind: "RunConfiguration", Name: apis.RandomString(), Controller: &[]bool{true}[0]},
+		}
+
+		_, err := runSchedule.validate(nil, nil, now)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Specify("A valid schedule passes the validation with a preview warning", func() {
+		runSchedule := RunSchedule{Spec: RunScheduleSpec{Schedule: RandomSchedule()}}
+
+		warnings, err := runSchedule.validate(nil, nil, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+})
@@ -3,6 +3,7 @@ package v1beta1
 import (
 	"github.com/sky-uk/kfp-operator/apis"
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +kubebuilder:object:generate=true
@@ -17,4 +18,27 @@ type Status struct {
 	Version            string          `json:"version,omitempty"`
 	ObservedGeneration int64           `json:"observedGeneration,omitempty"`
 	Conditions         apis.Conditions `json:"conditions,omitempty"`
+	// Retry records the automatic retries of a failed synchronisation.
+	Retry *RetryStatus `json:"retry,omitempty"`
+	// Operation records the provider operation in progress for providers in
+	// the direct execution mode.
+	Operation *OperationStatus `json:"operation,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type RetryStatus struct {
+	// Attempts is the number of retries made since the last change.
+	Attempts int32 `json:"attempts,omitempty"`
+	// NextRetryTime is when the synchronisation is retried next. It is not
+	// set once all attempts have been made.
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type OperationStatus struct {
+	// Name is the provider operation, i.e. create, update or delete.
+	Name string `json:"name"`
+	// Id identifies the operation and is sent to the provider-service as an
+	// idempotency key.
+	Id string `json:"id"`
 }
@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func RandomPipeline(provider common.NamespacedName) *Pipeline {
@@ -60,6 +61,14 @@ func RandomPipelineSpec(provider common.NamespacedName) PipelineSpec {
 			Name:       RandomString(),
 			Parameters: randomParameters,
 		},
+		Outputs: RandomList(RandomPipelineOutput),
+	}
+}
+
+func RandomPipelineOutput() PipelineOutput {
+	return PipelineOutput{
+		Component: RandomString(),
+		Artifacts: RandomList(RandomString),
 	}
 }
 
@@ -100,6 +109,9 @@ func RandomProviderSpec() ProviderSpec {
 		Frameworks:          RandomList(RandomFramework),
 		Parameters:          randomParameters,
 		AllowedNamespaces:   RandomList(RandomString),
+		AllowedNamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{RandomLowercaseString(): RandomLowercaseString()},
+		},
 		PodTemplateEnv: []corev1.EnvVar{{
 			Name:  RandomString(),
 			Value: RandomString(),
@@ -114,6 +126,17 @@ func RandomProviderSpec() ProviderSpec {
 			Name:      volumeName,
 			MountPath: "/" + RandomLowercaseString(),
 		}},
+		WorkflowLimits: &WorkflowLimits{
+			MaxConcurrent:           rand.Int31n(10) + 1,
+			MaxSubmissionsPerMinute: rand.Int31n(10) + 1,
+		},
+		Autoscaling: &ProviderAutoscaling{
+			MinReplicas: &[]int32{rand.Int31n(3) + 1}[0],
+			MaxReplicas: rand.Int31n(3) + 4,
+		},
+		DisruptionBudget: &ProviderDisruptionBudget{
+			MinAvailable: &[]intstr.IntOrString{intstr.FromInt32(1)}[0],
+		},
 	}
 }
 
@@ -143,8 +166,10 @@ func RandomRunConfiguration(provider common.NamespacedName) *RunConfiguration {
 
 func RandomRunConfigurationSpec(provider common.NamespacedName) RunConfigurationSpec {
 	return RunConfigurationSpec{
-		Run:      RandomRunSpec(provider),
-		Triggers: RandomTriggers(),
+		Run:                        RandomRunSpec(provider),
+		Triggers:                   RandomTriggers(),
+		SuccessfulRunsHistoryLimit: &[]int32{rand.Int31n(10) + 1}[0],
+		FailedRunsHistoryLimit:     &[]int32{rand.Int31n(10) + 1}[0],
 	}
 }
 
@@ -158,12 +183,23 @@ func RandomTime() *metav1.Time {
 	return &metav1.Time{Time: randTime}
 }
 
+// RandomSchedule generates a schedule that passes admission validation.
 func RandomSchedule() Schedule {
-	return Schedule{
-		CronExpression: RandomString(),
-		StartTime:      RandomTime(),
-		EndTime:        RandomTime(),
+	schedule := Schedule{
+		CronExpression: fmt.Sprintf("%d %d %d * *", rand.Intn(60), rand.Intn(24), rand.Intn(28)+1),
+	}
+
+	if rand.Intn(2) == 1 {
+		startTime := time.Now().Add(time.Duration(rand.Intn(1000)+1) * time.Hour).Truncate(time.Second)
+		schedule.StartTime = &metav1.Time{Time: startTime}
+
+		if rand.Intn(2) == 1 {
+			endTime := startTime.Add(time.Duration(rand.Intn(1000)+1) * time.Hour)
+			schedule.EndTime = &metav1.Time{Time: endTime}
+		}
 	}
+
+	return schedule
 }
 
 func RandomTriggers() Triggers {
@@ -304,6 +340,14 @@ func RandomStatus(provider common.NamespacedName) Status {
 				RandomSynchronizationState(),
 			),
 		},
+		Retry: &RetryStatus{
+			Attempts:      rand.Int31(),
+			NextRetryTime: RandomTime(),
+		},
+		Operation: &OperationStatus{
+			Name: RandomString(),
+			Id:   RandomString(),
+		},
 	}
 }
 
@@ -323,6 +367,7 @@ type TestResource struct {
 	Kind            string
 	Status          Status
 	ComputedVersion string
+	DeletionPolicy  DeletionPolicy
 }
 
 func (tr *TestResource) GetStatus() Status {
@@ -353,6 +398,13 @@ func (tr *TestResource) GetKind() string {
 	return tr.Kind
 }
 
+func (tr *TestResource) GetDeletionPolicy() DeletionPolicy {
+	if tr.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return tr.DeletionPolicy
+}
+
 func RandomResource() *TestResource {
 	return &TestResource{
 		Status:          RandomStatus(common.RandomNamespacedName()),
@@ -28,6 +28,9 @@ func (src *Experiment) ConvertTo(dstRaw conversion.Hub) error {
 		src.Status.Provider.Name,
 		remainder.ProviderStatusNamespace,
 	)
+	dst.Spec.DeletionPolicy = remainder.DeletionPolicy
+	dst.Status.Operation = remainder.Operation
+	dst.Status.Retry = remainder.Retry
 	dst.TypeMeta.APIVersion = dstApiVersion
 
 	return nil
@@ -46,6 +49,9 @@ func (dst *Experiment) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Status.Provider.Name = src.Status.Provider.Name.Name
 	remainder.ProviderNamespace = src.Spec.Provider.Namespace
 	remainder.ProviderStatusNamespace = src.Status.Provider.Name.Namespace
+	remainder.DeletionPolicy = src.Spec.DeletionPolicy
+	remainder.Operation = src.Status.Operation
+	remainder.Retry = src.Status.Retry
 
 	dst.Status.SynchronizationState = src.Status.Conditions.GetSyncStateFromReason()
 	dst.TypeMeta.APIVersion = dstApiVersion
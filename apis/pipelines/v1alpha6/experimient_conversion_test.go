@@ -56,6 +56,7 @@ var _ = Context("Experiment Conversion", PropertyBased, func() {
 	var _ = Describe("Roundtrip backward", func() {
 		Specify("converts to and from the same object", func() {
 			src := hub.RandomExperiment(common.RandomNamespacedName())
+			src.Spec.DeletionPolicy = hub.DeletionPolicyRetain
 			intermediate := &Experiment{}
 			dst := &hub.Experiment{}
 
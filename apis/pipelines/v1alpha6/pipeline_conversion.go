@@ -29,6 +29,10 @@ func (src *Pipeline) ConvertTo(dstRaw conversion.Hub) error {
 		remainder.ProviderStatusNamespace,
 	)
 	dst.TypeMeta.APIVersion = dstApiVersion
+	dst.Spec.Outputs = remainder.Outputs
+	dst.Spec.DeletionPolicy = remainder.DeletionPolicy
+	dst.Status.Operation = remainder.Operation
+	dst.Status.Retry = remainder.Retry
 
 	tfxComponents := src.Spec.TfxComponents
 	if remainder.Framework.Name != "" {
@@ -63,6 +67,10 @@ func (dst *Pipeline) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Status.Provider.Name = src.Status.Provider.Name.Name
 	remainder.ProviderNamespace = src.Spec.Provider.Namespace
 	remainder.ProviderStatusNamespace = src.Status.Provider.Name.Namespace
+	remainder.Outputs = src.Spec.Outputs
+	remainder.DeletionPolicy = src.Spec.DeletionPolicy
+	remainder.Operation = src.Status.Operation
+	remainder.Retry = src.Status.Retry
 
 	dst.TypeMeta.APIVersion = dstApiVersion
 	status := src.Status.Conditions.GetSyncStateFromReason()
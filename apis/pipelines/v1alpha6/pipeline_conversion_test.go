@@ -64,6 +64,7 @@ var _ = Context("Pipeline Conversion", PropertyBased, func() {
 		Specify("converts to and from the same object when the framework is tfx", func() {
 			src := hub.RandomPipeline(common.RandomNamespacedName())
 			hub.AddTfxValues(&src.Spec)
+			src.Spec.DeletionPolicy = hub.DeletionPolicyRetain
 
 			intermediate := &Pipeline{}
 			dst := &hub.Pipeline{}
@@ -56,9 +56,15 @@ func (src *Provider) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Frameworks = []hub.Framework{tfxFramework}
 	dst.Spec.AllowedNamespaces = remainderFromConvertFrom.AllowedNamespaces
+	dst.Spec.AllowedNamespaceSelector = remainderFromConvertFrom.AllowedNamespaceSelector
 	dst.Spec.PodTemplateEnv = remainderFromConvertFrom.PodTemplateEnv
 	dst.Spec.PodTemplateVolumes = remainderFromConvertFrom.PodTemplateVolumes
 	dst.Spec.PodTemplateVolumeMounts = remainderFromConvertFrom.PodTemplateVolumeMounts
+	dst.Spec.WorkflowLimits = remainderFromConvertFrom.WorkflowLimits
+	dst.Spec.Autoscaling = remainderFromConvertFrom.Autoscaling
+	dst.Spec.DisruptionBudget = remainderFromConvertFrom.DisruptionBudget
+	dst.Status.Operation = remainderFromConvertFrom.Operation
+	dst.Status.Retry = remainderFromConvertFrom.Retry
 
 	if err := pipelines.TransformInto(src, &dst); err != nil {
 		return err
@@ -109,9 +115,15 @@ func (dst *Provider) ConvertFrom(srcRaw conversion.Hub) error {
 
 	remainder := ProviderConversionRemainder{}
 	remainder.AllowedNamespaces = src.Spec.AllowedNamespaces
+	remainder.AllowedNamespaceSelector = src.Spec.AllowedNamespaceSelector
 	remainder.PodTemplateEnv = src.Spec.PodTemplateEnv
 	remainder.PodTemplateVolumes = src.Spec.PodTemplateVolumes
 	remainder.PodTemplateVolumeMounts = src.Spec.PodTemplateVolumeMounts
+	remainder.WorkflowLimits = src.Spec.WorkflowLimits
+	remainder.Autoscaling = src.Spec.Autoscaling
+	remainder.DisruptionBudget = src.Spec.DisruptionBudget
+	remainder.Operation = src.Status.Operation
+	remainder.Retry = src.Status.Retry
 
 	return pipelines.SetConversionAnnotations(dst, &remainder)
 }
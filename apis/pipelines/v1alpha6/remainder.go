@@ -3,15 +3,18 @@ package v1alpha6
 import (
 	hub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type RunConversionRemainder struct {
-	ProviderNamespace       string `json:"providerNamespace"`
-	ProviderStatusNamespace string `json:"providerStatusNamespace"`
+	ProviderNamespace       string               `json:"providerNamespace"`
+	ProviderStatusNamespace string               `json:"providerStatusNamespace"`
+	Operation               *hub.OperationStatus `json:"operation,omitempty"`
+	Retry                   *hub.RetryStatus     `json:"retry,omitempty"`
 }
 
 func (rcr RunConversionRemainder) Empty() bool {
-	return rcr.ProviderNamespace == "" && rcr.ProviderStatusNamespace == ""
+	return rcr.ProviderNamespace == "" && rcr.ProviderStatusNamespace == "" && rcr.Operation == nil && rcr.Retry == nil
 }
 
 func (RunConversionRemainder) ConversionAnnotation() string {
@@ -19,12 +22,15 @@ func (RunConversionRemainder) ConversionAnnotation() string {
 }
 
 type RunScheduleConversionRemainder struct {
-	ProviderNamespace       string `json:"providerNamespace"`
-	ProviderStatusNamespace string `json:"providerStatusNamespace"`
+	ProviderNamespace       string               `json:"providerNamespace"`
+	ProviderStatusNamespace string               `json:"providerStatusNamespace"`
+	DeletionPolicy          hub.DeletionPolicy   `json:"deletionPolicy,omitempty"`
+	Operation               *hub.OperationStatus `json:"operation,omitempty"`
+	Retry                   *hub.RetryStatus     `json:"retry,omitempty"`
 }
 
 func (rsr RunScheduleConversionRemainder) Empty() bool {
-	return rsr.ProviderNamespace == "" && rsr.ProviderStatusNamespace == ""
+	return rsr.ProviderNamespace == "" && rsr.ProviderStatusNamespace == "" && rsr.DeletionPolicy == "" && rsr.Operation == nil && rsr.Retry == nil
 }
 
 func (RunScheduleConversionRemainder) ConversionAnnotation() string {
@@ -32,12 +38,17 @@ func (RunScheduleConversionRemainder) ConversionAnnotation() string {
 }
 
 type RunConfigurationConversionRemainder struct {
-	ProviderNamespace       string `json:"providerNamespace"`
-	ProviderStatusNamespace string `json:"providerStatusNamespace"`
+	ProviderNamespace          string `json:"providerNamespace"`
+	ProviderStatusNamespace    string `json:"providerStatusNamespace"`
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+	FailedRunsHistoryLimit     *int32 `json:"failedRunsHistoryLimit,omitempty"`
 }
 
 func (rccr RunConfigurationConversionRemainder) Empty() bool {
-	return rccr.ProviderNamespace == "" && rccr.ProviderStatusNamespace == ""
+	return rccr.ProviderNamespace == "" &&
+		rccr.ProviderStatusNamespace == "" &&
+		rccr.SuccessfulRunsHistoryLimit == nil &&
+		rccr.FailedRunsHistoryLimit == nil
 }
 
 func (RunConfigurationConversionRemainder) ConversionAnnotation() string {
@@ -48,10 +59,15 @@ type PipelineConversionRemainder struct {
 	ProviderNamespace       string                `json:"providerNamespace"`
 	ProviderStatusNamespace string                `json:"providerStatusNamespace"`
 	Framework               hub.PipelineFramework `json:"framework"`
+	Outputs                 []hub.PipelineOutput  `json:"outputs,omitempty"`
+	DeletionPolicy          hub.DeletionPolicy    `json:"deletionPolicy,omitempty"`
+	Operation               *hub.OperationStatus  `json:"operation,omitempty"`
+	Retry                   *hub.RetryStatus      `json:"retry,omitempty"`
 }
 
 func (pcr PipelineConversionRemainder) Empty() bool {
-	return pcr.ProviderNamespace == "" && pcr.Framework.Name == "" && pcr.ProviderStatusNamespace == ""
+	return pcr.ProviderNamespace == "" && pcr.Framework.Name == "" && pcr.ProviderStatusNamespace == "" &&
+		len(pcr.Outputs) == 0 && pcr.DeletionPolicy == "" && pcr.Operation == nil && pcr.Retry == nil
 }
 
 func (PipelineConversionRemainder) ConversionAnnotation() string {
@@ -59,12 +75,15 @@ func (PipelineConversionRemainder) ConversionAnnotation() string {
 }
 
 type ExperimentConversionRemainder struct {
-	ProviderNamespace       string `json:"providerNamespace"`
-	ProviderStatusNamespace string `json:"providerStatusNamespace"`
+	ProviderNamespace       string               `json:"providerNamespace"`
+	ProviderStatusNamespace string               `json:"providerStatusNamespace"`
+	DeletionPolicy          hub.DeletionPolicy   `json:"deletionPolicy,omitempty"`
+	Operation               *hub.OperationStatus `json:"operation,omitempty"`
+	Retry                   *hub.RetryStatus     `json:"retry,omitempty"`
 }
 
 func (er ExperimentConversionRemainder) Empty() bool {
-	return er.ProviderNamespace == "" && er.ProviderStatusNamespace == ""
+	return er.ProviderNamespace == "" && er.ProviderStatusNamespace == "" && er.DeletionPolicy == "" && er.Operation == nil && er.Retry == nil
 }
 
 func (ExperimentConversionRemainder) ConversionAnnotation() string {
@@ -72,16 +91,25 @@ func (ExperimentConversionRemainder) ConversionAnnotation() string {
 }
 
 type ProviderConversionRemainder struct {
-	Image                   string               `json:"image"`
-	AllowedNamespaces       []string             `json:"allowedNamespaces"`
-	PodTemplateEnv          []corev1.EnvVar      `json:"podTemplateEnv"`
-	PodTemplateVolumes      []corev1.Volume      `json:"podTemplateVolumes"`
-	PodTemplateVolumeMounts []corev1.VolumeMount `json:"podTemplateVolumeMounts"`
+	Image                    string                        `json:"image"`
+	AllowedNamespaces        []string                      `json:"allowedNamespaces"`
+	AllowedNamespaceSelector *metav1.LabelSelector         `json:"allowedNamespaceSelector,omitempty"`
+	PodTemplateEnv           []corev1.EnvVar               `json:"podTemplateEnv"`
+	PodTemplateVolumes       []corev1.Volume               `json:"podTemplateVolumes"`
+	PodTemplateVolumeMounts  []corev1.VolumeMount          `json:"podTemplateVolumeMounts"`
+	WorkflowLimits           *hub.WorkflowLimits           `json:"workflowLimits,omitempty"`
+	Autoscaling              *hub.ProviderAutoscaling      `json:"autoscaling,omitempty"`
+	DisruptionBudget         *hub.ProviderDisruptionBudget `json:"disruptionBudget,omitempty"`
+	Operation                *hub.OperationStatus          `json:"operation,omitempty"`
+	Retry                    *hub.RetryStatus              `json:"retry,omitempty"`
 }
 
 func (pcr ProviderConversionRemainder) Empty() bool {
-	return pcr.Image == "" && len(pcr.AllowedNamespaces) == 0 && len(pcr.PodTemplateEnv) == 0 &&
-		len(pcr.PodTemplateVolumes) == 0 && len(pcr.PodTemplateVolumeMounts) == 0
+	return pcr.Image == "" && len(pcr.AllowedNamespaces) == 0 && pcr.AllowedNamespaceSelector == nil &&
+		len(pcr.PodTemplateEnv) == 0 &&
+		len(pcr.PodTemplateVolumes) == 0 && len(pcr.PodTemplateVolumeMounts) == 0 &&
+		pcr.WorkflowLimits == nil && pcr.Autoscaling == nil && pcr.DisruptionBudget == nil &&
+		pcr.Operation == nil && pcr.Retry == nil
 }
 
 func (ProviderConversionRemainder) ConversionAnnotation() string {
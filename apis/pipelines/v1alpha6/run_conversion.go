@@ -28,6 +28,8 @@ func (src *Run) ConvertTo(dstRaw conversion.Hub) error {
 		src.Status.Provider.Name,
 		remainder.ProviderStatusNamespace,
 	)
+	dst.Status.Operation = remainder.Operation
+	dst.Status.Retry = remainder.Retry
 	dst.TypeMeta.APIVersion = dstApiVersion
 
 	if len(src.Spec.RuntimeParameters) > 0 {
@@ -53,6 +55,8 @@ func (dst *Run) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Status.Provider.Name = src.Status.Provider.Name.Name
 	remainder.ProviderNamespace = src.Spec.Provider.Namespace
 	remainder.ProviderStatusNamespace = src.Status.Provider.Name.Namespace
+	remainder.Operation = src.Status.Operation
+	remainder.Retry = src.Status.Retry
 	dst.Status.SynchronizationState = src.Status.Conditions.GetSyncStateFromReason()
 	dst.TypeMeta.APIVersion = dstApiVersion
 
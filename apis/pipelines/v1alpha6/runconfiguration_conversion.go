@@ -29,6 +29,8 @@ func (src *RunConfiguration) ConvertTo(dstRaw conversion.Hub) error {
 		src.Status.Provider,
 		remainder.ProviderStatusNamespace,
 	)
+	dst.Spec.SuccessfulRunsHistoryLimit = remainder.SuccessfulRunsHistoryLimit
+	dst.Spec.FailedRunsHistoryLimit = remainder.FailedRunsHistoryLimit
 	dst.TypeMeta.APIVersion = dstApiVersion
 
 	if len(src.Spec.Run.RuntimeParameters) > 0 {
@@ -56,6 +58,8 @@ func (dst *RunConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Status.Provider = src.Status.Provider.Name
 	remainder.ProviderNamespace = src.Spec.Run.Provider.Namespace
 	remainder.ProviderStatusNamespace = src.Status.Provider.Namespace
+	remainder.SuccessfulRunsHistoryLimit = src.Spec.SuccessfulRunsHistoryLimit
+	remainder.FailedRunsHistoryLimit = src.Spec.FailedRunsHistoryLimit
 
 	if len(dst.Spec.Run.Parameters) > 0 {
 		dst.Spec.Run.RuntimeParameters = dst.Spec.Run.Parameters
@@ -55,6 +55,7 @@ var _ = Context("RunSchedule Conversion", PropertyBased, func() {
 	var _ = Describe("Roundtrip backward", func() {
 		Specify("converts to and from the same object", func() {
 			src := hub.RandomRunSchedule(common.RandomNamespacedName())
+			src.Spec.DeletionPolicy = hub.DeletionPolicyRetain
 			intermediate := &RunSchedule{}
 			dst := &hub.RunSchedule{}
 
@@ -6,9 +6,11 @@ package v1alpha6
 
 import (
 	"github.com/sky-uk/kfp-operator/apis"
+	"github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -95,6 +97,16 @@ func (in *Experiment) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExperimentConversionRemainder) DeepCopyInto(out *ExperimentConversionRemainder) {
 	*out = *in
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(v1beta1.OperationStatus)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1beta1.RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExperimentConversionRemainder.
@@ -217,6 +229,23 @@ func (in *Pipeline) DeepCopyObject() runtime.Object {
 func (in *PipelineConversionRemainder) DeepCopyInto(out *PipelineConversionRemainder) {
 	*out = *in
 	in.Framework.DeepCopyInto(&out.Framework)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]v1beta1.PipelineOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(v1beta1.OperationStatus)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1beta1.RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConversionRemainder.
@@ -351,6 +380,11 @@ func (in *ProviderConversionRemainder) DeepCopyInto(out *ProviderConversionRemai
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedNamespaceSelector != nil {
+		in, out := &in.AllowedNamespaceSelector, &out.AllowedNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodTemplateEnv != nil {
 		in, out := &in.PodTemplateEnv, &out.PodTemplateEnv
 		*out = make([]corev1.EnvVar, len(*in))
@@ -372,6 +406,31 @@ func (in *ProviderConversionRemainder) DeepCopyInto(out *ProviderConversionRemai
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WorkflowLimits != nil {
+		in, out := &in.WorkflowLimits, &out.WorkflowLimits
+		*out = new(v1beta1.WorkflowLimits)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(v1beta1.ProviderAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(v1beta1.ProviderDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(v1beta1.OperationStatus)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1beta1.RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConversionRemainder.
@@ -509,6 +568,16 @@ func (in *RunConfiguration) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunConfigurationConversionRemainder) DeepCopyInto(out *RunConfigurationConversionRemainder) {
 	*out = *in
+	if in.SuccessfulRunsHistoryLimit != nil {
+		in, out := &in.SuccessfulRunsHistoryLimit, &out.SuccessfulRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedRunsHistoryLimit != nil {
+		in, out := &in.FailedRunsHistoryLimit, &out.FailedRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunConfigurationConversionRemainder.
@@ -614,6 +683,16 @@ func (in *RunConfigurationStatus) DeepCopy() *RunConfigurationStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunConversionRemainder) DeepCopyInto(out *RunConversionRemainder) {
 	*out = *in
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(v1beta1.OperationStatus)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1beta1.RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunConversionRemainder.
@@ -708,6 +787,16 @@ func (in *RunSchedule) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunScheduleConversionRemainder) DeepCopyInto(out *RunScheduleConversionRemainder) {
 	*out = *in
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(v1beta1.OperationStatus)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1beta1.RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunScheduleConversionRemainder.
@@ -3,6 +3,7 @@ package pipelines
 import (
 	"context"
 	"fmt"
+	"time"
 
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/sky-uk/kfp-operator/apis"
@@ -28,10 +29,18 @@ var EventReasons = struct {
 	Syncing    string
 	Synced     string
 	SyncFailed string
+	Drifted    string
+	Orphaned   string
+	Throttled  string
+	Planned    string
 }{
 	Syncing:    "Syncing",
 	Synced:     "Synced",
 	SyncFailed: "SyncFailed",
+	Drifted:    "Drifted",
+	Orphaned:   "Orphaned",
+	Throttled:  "Throttled",
+	Planned:    "Planned",
 }
 
 type K8sExecutionContext struct {
@@ -39,6 +48,8 @@ type K8sExecutionContext struct {
 	Recorder           record.EventRecorder
 	Scheme             *runtime.Scheme
 	WorkflowRepository WorkflowRepository
+	Metrics            *ResourceMetrics
+	Scope              Scope
 }
 
 type Command interface {
@@ -127,6 +138,12 @@ func (sps *SetStatus) WithProvider(providerId pipelineshub.ProviderAndId) *SetSt
 	return sps
 }
 
+func (sps *SetStatus) WithOperation(operation *pipelineshub.OperationStatus) *SetStatus {
+	sps.Status.Operation = operation
+
+	return sps
+}
+
 func eventMessage(sps SetStatus) (message string) {
 	message = fmt.Sprintf(`%s [version: "%s"]`, string(sps.Status.Conditions.GetSyncStateFromReason()), sps.Status.Version)
 
@@ -172,11 +189,13 @@ func (sps SetStatus) execute(
 		sps.Status,
 	)
 
+	oldStatus := resource.GetStatus()
 	resource.SetStatus(sps.Status)
 
 	err := ec.Client.Status().Update(ctx, resource)
 
 	if err == nil {
+		ec.Metrics.RecordTransition(ctx, resource.GetKind(), resource, oldStatus.ObservedGeneration, oldStatus.Conditions, sps.Status.Conditions)
 		ec.Recorder.Event(
 			resource,
 			eventType(sps),
@@ -212,6 +231,8 @@ func (cw CreateWorkflow) execute(
 		return err
 	}
 
+	ec.Metrics.RecordWorkflowCreated(ctx, &cw.Workflow)
+
 	return nil
 }
 
@@ -219,12 +240,18 @@ type MarkWorkflowsAsProcessed struct {
 	Workflows []argo.Workflow
 }
 
+// execute records failed workflows in the metrics. Their failure details are
+// reported by the SyncFailed event of the status that fails the resource.
 func (dw MarkWorkflowsAsProcessed) execute(ctx context.Context, ec K8sExecutionContext, _ pipelineshub.Resource) error {
 	for i := range dw.Workflows {
 		workflow := &dw.Workflows[i]
 		if err := ec.WorkflowRepository.MarkWorkflowAsProcessed(ctx, workflow); err != nil {
 			return err
 		}
+
+		if workflow.Status.Phase == argo.WorkflowFailed || workflow.Status.Phase == argo.WorkflowError {
+			ec.Metrics.RecordWorkflowFailed(ctx, workflow)
+		}
 	}
 
 	return nil
@@ -260,3 +287,70 @@ func (rp ReleaseResource) execute(ctx context.Context, ec K8sExecutionContext, r
 
 	return nil
 }
+
+// SetThrottled sets the Throttled condition of a resource while keeping the
+// rest of its status.
+type SetThrottled struct {
+	Condition metav1.Condition
+}
+
+func (st SetThrottled) execute(ctx context.Context, ec K8sExecutionContext, resource pipelineshub.Resource) error {
+	status := resource.GetStatus()
+	status.Conditions = status.Conditions.MergeIntoConditions(st.Condition)
+	resource.SetStatus(status)
+
+	if err := ec.Client.Status().Update(ctx, resource); err != nil {
+		return err
+	}
+
+	ec.Recorder.Event(resource, EventTypes.Normal, EventReasons.Throttled, st.Condition.Message)
+
+	return nil
+}
+
+// Requeue reconciles the resource again after the given duration.
+type Requeue struct {
+	After time.Duration
+}
+
+func (r Requeue) execute(_ context.Context, _ K8sExecutionContext, _ pipelineshub.Resource) error {
+	return nil
+}
+
+// StartDirectOperation calls the provider-service in the background. It
+// follows the SetStatus command that records the operation, so that the call
+// is only made once the operation has been persisted.
+type StartDirectOperation struct {
+	Operations *DirectOperations
+	Id         string
+	Operation  ProviderOperation
+	Workflow   argo.Workflow
+}
+
+func (sdo StartDirectOperation) execute(ctx context.Context, _ K8sExecutionContext, _ pipelineshub.Resource) error {
+	sdo.Operations.Start(ctx, sdo.Id, sdo.Operation, &sdo.Workflow)
+
+	return nil
+}
+
+// Abort stops executing the remaining commands and returns the error, so that
+// the resource is reconciled again with backoff.
+type Abort struct {
+	Err error
+}
+
+func (a Abort) execute(_ context.Context, _ K8sExecutionContext, _ pipelineshub.Resource) error {
+	return a.Err
+}
+
+// requeueAfter returns the shortest duration of the Requeue commands, or zero
+// if there are none.
+func requeueAfter(commands []Command) (after time.Duration) {
+	for _, command := range commands {
+		if requeue, ok := command.(Requeue); ok && (after == 0 || requeue.After < after) {
+			after = requeue.After
+		}
+	}
+
+	return
+}
@@ -90,7 +90,7 @@ func (dr DependingOnPipelineReconciler[R]) setupWithManager(mgr ctrl.Manager, co
 	return controllerBuilder.Watches(
 		&pipelineshub.Pipeline{},
 		handler.EnqueueRequestsFromMapFunc(reconciliationRequestsForPipeline),
-		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, dr.EC.Scope.Predicate()),
 	), nil
 }
 
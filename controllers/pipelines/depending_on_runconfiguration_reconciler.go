@@ -136,6 +136,6 @@ func (dr DependingOnRunConfigurationReconciler[R]) setupWithManager(mgr ctrl.Man
 	return controllerBuilder.Watches(
 		&pipelineshub.RunConfiguration{},
 		handler.EnqueueRequestsFromMapFunc(reconciliationRequestsForPipeline),
-		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, dr.EC.Scope.Predicate()),
 	), nil
 }
@@ -0,0 +1,203 @@
+package pipelines
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowutil"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ProviderOperation string
+
+var ProviderOperations = struct {
+	Create ProviderOperation
+	Update ProviderOperation
+	Delete ProviderOperation
+}{
+	Create: "create",
+	Update: "update",
+	Delete: "delete",
+}
+
+// DirectExecutor performs the provider-service call of a workflow from the
+// controller instead of submitting the workflow to Argo. The id is sent as
+// the idempotency key of the call, so that the provider service does not
+// perform an operation that is repeated with the same id twice.
+type DirectExecutor interface {
+	Execute(ctx context.Context, operation ProviderOperation, id string, workflow *argo.Workflow) (providers.Output, error)
+}
+
+const (
+	DirectExecutionTimeout = time.Minute
+	// DirectOperationPollInterval is how often resources are reconciled while
+	// their operation is in progress.
+	DirectOperationPollInterval = 5 * time.Second
+	// DirectOperationRetention is how long the results of operations are kept
+	// for the resources to record them.
+	DirectOperationRetention = 10 * time.Minute
+)
+
+type HttpDirectExecutor struct {
+	Client *http.Client
+}
+
+func NewHttpDirectExecutor() HttpDirectExecutor {
+	return HttpDirectExecutor{
+		Client: &http.Client{Timeout: DirectExecutionTimeout},
+	}
+}
+
+func (hde HttpDirectExecutor) newRequest(
+	ctx context.Context,
+	operation ProviderOperation,
+	workflow *argo.Workflow,
+) (*http.Request, error) {
+	resourceUrl := fmt.Sprintf(
+		"http://%s/resource/%s",
+		workflowutil.GetWorkflowParameter(workflow, workflowconstants.ProviderServiceUrl),
+		workflowutil.GetWorkflowParameter(workflow, workflowconstants.ResourceKindParameterName),
+	)
+	definition := workflowutil.GetWorkflowParameter(workflow, workflowconstants.ResourceDefinitionParameterName)
+	resourceId := url.PathEscape(workflowutil.GetWorkflowParameter(workflow, workflowconstants.ResourceIdParameterName))
+
+	switch operation {
+	case ProviderOperations.Create:
+		return http.NewRequestWithContext(ctx, http.MethodPost, resourceUrl, strings.NewReader(definition))
+	case ProviderOperations.Update:
+		return http.NewRequestWithContext(ctx, http.MethodPut, resourceUrl+"/"+resourceId, strings.NewReader(definition))
+	case ProviderOperations.Delete:
+		return http.NewRequestWithContext(ctx, http.MethodDelete, resourceUrl+"/"+resourceId, nil)
+	default:
+		return nil, fmt.Errorf("unsupported provider operation %s", operation)
+	}
+}
+
+// Execute calls the provider-service like the templates of the
+// kfp-provider-workflows chart do. As with the workflows, only unimplemented
+// operations fail the call; other errors are reported in the provider output.
+func (hde HttpDirectExecutor) Execute(
+	ctx context.Context,
+	operation ProviderOperation,
+	id string,
+	workflow *argo.Workflow,
+) (providers.Output, error) {
+	logger := log.FromContext(ctx)
+	output := providers.Output{}
+
+	request, err := hde.newRequest(ctx, operation, workflow)
+	if err != nil {
+		return output, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(providers.IdempotencyKeyHeader, id)
+
+	logger.V(1).Info("calling provider-service", "method", request.Method, "url", request.URL.String())
+
+	response, err := hde.Client.Do(request)
+	if err != nil {
+		return output, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return output, err
+	}
+
+	if response.StatusCode == http.StatusNotImplemented {
+		return output, fmt.Errorf("provider-service does not implement %s for %s: %s", operation, request.URL.Path, body)
+	}
+
+	err = json.Unmarshal(body, &output)
+
+	return output, err
+}
+
+// DirectOperationResult is the outcome of a provider-service call.
+type DirectOperationResult struct {
+	Output providers.Output
+	Err    error
+}
+
+type directOperation struct {
+	result     *DirectOperationResult
+	finishedAt time.Time
+}
+
+// DirectOperations runs the provider-service calls of resources in the direct
+// execution mode in the background, so that they do not block
+// reconciliation, and keeps their results for the resources to record them.
+// Operations are identified by the id that is recorded on the status of the
+// resource before the call is made.
+type DirectOperations struct {
+	Executor   DirectExecutor
+	mutex      sync.Mutex
+	operations map[string]*directOperation
+}
+
+func NewDirectOperations(executor DirectExecutor) *DirectOperations {
+	return &DirectOperations{
+		Executor:   executor,
+		operations: map[string]*directOperation{},
+	}
+}
+
+// Start calls the provider-service in the background unless the operation
+// has already been started.
+func (do *DirectOperations) Start(ctx context.Context, id string, operation ProviderOperation, workflow *argo.Workflow) {
+	do.mutex.Lock()
+	defer do.mutex.Unlock()
+
+	do.evict(time.Now())
+
+	if _, started := do.operations[id]; started {
+		return
+	}
+
+	current := &directOperation{}
+	do.operations[id] = current
+
+	go func() {
+		output, err := do.Executor.Execute(context.WithoutCancel(ctx), operation, id, workflow)
+
+		do.mutex.Lock()
+		defer do.mutex.Unlock()
+
+		current.result = &DirectOperationResult{Output: output, Err: err}
+		current.finishedAt = time.Now()
+	}()
+}
+
+// Result returns the result of the operation, which is nil while the
+// operation is running. It returns false if the operation has not been
+// started, e.g. by a previous instance of the manager.
+func (do *DirectOperations) Result(id string) (*DirectOperationResult, bool) {
+	do.mutex.Lock()
+	defer do.mutex.Unlock()
+
+	current, started := do.operations[id]
+	if !started {
+		return nil, false
+	}
+
+	return current.result, true
+}
+
+func (do *DirectOperations) evict(now time.Time) {
+	for id, current := range do.operations {
+		if current.result != nil && now.Sub(current.finishedAt) > DirectOperationRetention {
+			delete(do.operations, id)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
+)
+
+var _ = Describe("HttpDirectExecutor", func() {
+	var (
+		server        *httptest.Server
+		requestMethod string
+		requestPath   string
+		requestBody   string
+		requestKey    string
+		statusCode    int
+		responseBody  string
+	)
+
+	BeforeEach(func() {
+		statusCode = http.StatusOK
+		responseBody = `{"id":"some-id"}`
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requestMethod = r.Method
+			requestPath = r.URL.EscapedPath()
+			requestBody = string(body)
+			requestKey = r.Header.Get(providers.IdempotencyKeyHeader)
+			w.WriteHeader(statusCode)
+			w.Write([]byte(responseBody))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	workflow := func(parameters map[string]string) *argo.Workflow {
+		workflow := &argo.Workflow{}
+		for name, value := range parameters {
+			workflow.Spec.Arguments.Parameters = append(workflow.Spec.Arguments.Parameters, argo.Parameter{
+				Name:  name,
+				Value: argo.AnyStringPtr(value),
+			})
+		}
+		return workflow
+	}
+
+	serviceUrl := func() string {
+		return strings.TrimPrefix(server.URL, "http://")
+	}
+
+	It("creates resources", func() {
+		output, err := NewHttpDirectExecutor().Execute(context.Background(), ProviderOperations.Create, "operation-id", workflow(map[string]string{
+			workflowconstants.ProviderServiceUrl:              serviceUrl(),
+			workflowconstants.ResourceKindParameterName:       "experiment",
+			workflowconstants.ResourceDefinitionParameterName: `{"name":"experiment"}`,
+		}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(Equal(providers.Output{Id: "some-id"}))
+		Expect(requestMethod).To(Equal(http.MethodPost))
+		Expect(requestPath).To(Equal("/resource/experiment"))
+		Expect(requestBody).To(Equal(`{"name":"experiment"}`))
+		Expect(requestKey).To(Equal("operation-id"))
+	})
+
+	It("updates resources with an encoded id", func() {
+		_, err := NewHttpDirectExecutor().Execute(context.Background(), ProviderOperations.Update, "operation-id", workflow(map[string]string{
+			workflowconstants.ProviderServiceUrl:              serviceUrl(),
+			workflowconstants.ResourceKindParameterName:       "runschedule",
+			workflowconstants.ResourceDefinitionParameterName: `{"name":"runschedule"}`,
+			workflowconstants.ResourceIdParameterName:         "projects/p/schedules/s",
+		}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requestMethod).To(Equal(http.MethodPut))
+		Expect(requestPath).To(Equal("/resource/runschedule/projects%2Fp%2Fschedules%2Fs"))
+		Expect(requestBody).To(Equal(`{"name":"runschedule"}`))
+	})
+
+	It("deletes resources", func() {
+		responseBody = `{}`
+
+		output, err := NewHttpDirectExecutor().Execute(context.Background(), ProviderOperations.Delete, "operation-id", workflow(map[string]string{
+			workflowconstants.ProviderServiceUrl:        serviceUrl(),
+			workflowconstants.ResourceKindParameterName: "experiment",
+			workflowconstants.ResourceIdParameterName:   "some-id",
+		}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(Equal(providers.Output{}))
+		Expect(requestMethod).To(Equal(http.MethodDelete))
+		Expect(requestPath).To(Equal("/resource/experiment/some-id"))
+	})
+
+	It("returns provider errors in the output", func() {
+		statusCode = http.StatusBadRequest
+		responseBody = `{"providerError":"invalid"}`
+
+		output, err := NewHttpDirectExecutor().Execute(context.Background(), ProviderOperations.Create, "operation-id", workflow(map[string]string{
+			workflowconstants.ProviderServiceUrl:        serviceUrl(),
+			workflowconstants.ResourceKindParameterName: "experiment",
+		}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.ProviderError).To(Equal("invalid"))
+	})
+
+	It("fails when the operation is not implemented", func() {
+		statusCode = http.StatusNotImplemented
+		responseBody = `{"providerError":"not implemented"}`
+
+		_, err := NewHttpDirectExecutor().Execute(context.Background(), ProviderOperations.Create, "operation-id", workflow(map[string]string{
+			workflowconstants.ProviderServiceUrl:        serviceUrl(),
+			workflowconstants.ResourceKindParameterName: "experiment",
+		}))
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("DirectOperations", func() {
+	It("runs operations in the background and keeps their results", func() {
+		executor := &StubbedDirectExecutor{Output: providers.Output{Id: "some-id"}}
+		operations := NewDirectOperations(executor)
+
+		_, started := operations.Result("operation-id")
+		Expect(started).To(BeFalse())
+
+		operations.Start(context.Background(), "operation-id", ProviderOperations.Create, &argo.Workflow{})
+
+		Eventually(func() *DirectOperationResult {
+			result, _ := operations.Result("operation-id")
+			return result
+		}).Should(Equal(&DirectOperationResult{Output: providers.Output{Id: "some-id"}}))
+	})
+
+	It("starts each operation once", func() {
+		executor := &StubbedDirectExecutor{}
+		operations := NewDirectOperations(executor)
+
+		operations.Start(context.Background(), "operation-id", ProviderOperations.Create, &argo.Workflow{})
+		operations.Start(context.Background(), "operation-id", ProviderOperations.Create, &argo.Workflow{})
+
+		Eventually(executor.Calls).Should(Equal([]ProviderOperation{ProviderOperations.Create}))
+		Consistently(executor.Calls).Should(HaveLen(1))
+	})
+
+	It("continues operations after the reconciliation has finished", func() {
+		executor := &StubbedDirectExecutor{}
+		operations := NewDirectOperations(executor)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		operations.Start(ctx, "operation-id", ProviderOperations.Delete, &argo.Workflow{})
+
+		Eventually(func() *DirectOperationResult {
+			result, _ := operations.Result("operation-id")
+			return result
+		}).Should(Equal(&DirectOperationResult{}))
+	})
+})
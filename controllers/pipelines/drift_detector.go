@@ -0,0 +1,282 @@
+package pipelines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const DefaultDriftDetectionInterval = 10 * time.Minute
+
+// DriftDetector periodically checks that synchronised pipelines, run
+// schedules and experiments still exist on their provider in the version the
+// operator last synchronised. Drift is reported with the Drifted condition.
+// When recreation is enabled, drifted resources are handed back to their
+// controllers for resynchronisation.
+type DriftDetector struct {
+	Client          controllers.OptInClient
+	Recorder        record.EventRecorder
+	Config          config.ConfigSpec
+	ServiceManager  ServiceResourceManager
+	ResourceGetter  ProviderResourceGetter
+	Scope           Scope
+	driftedCounter  metric.Int64Counter
+	currentTimeFunc func() time.Time
+}
+
+func NewDriftDetector(ec K8sExecutionContext, config config.ConfigSpec) (*DriftDetector, error) {
+	meter := otel.Meter("drift_detector")
+	driftedCounter, err := meter.Int64Counter(
+		"drift_detector_drifted_resources",
+		metric.WithDescription("Total number of drifted resources found by the drift detector"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create drifted resources counter: %w", err)
+	}
+
+	return &DriftDetector{
+		Client:   ec.Client,
+		Recorder: ec.Recorder,
+		Config:   config,
+		ServiceManager: ServiceManager{
+			client: &ec.Client,
+			scheme: ec.Scheme,
+			config: &config,
+		},
+		ResourceGetter:  NewHttpProviderResourceClient(config.DefaultProviderValues.ServicePort),
+		Scope:           ec.Scope,
+		driftedCounter:  driftedCounter,
+		currentTimeFunc: time.Now,
+	}, nil
+}
+
+// NeedLeaderElection ensures that only the leading manager detects drift.
+func (dd *DriftDetector) NeedLeaderElection() bool {
+	return true
+}
+
+func (dd *DriftDetector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("drift-detector")
+	ctx = log.IntoContext(ctx, logger)
+
+	interval := DefaultDriftDetectionInterval
+	if dd.Config.DriftDetection.Interval != nil {
+		interval = dd.Config.DriftDetection.Interval.Duration
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := dd.Detect(ctx); err != nil {
+			logger.Error(err, "drift detection failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Detect checks all synchronised resources for drift. Failures to check
+// individual resources are logged and do not stop the detection.
+func (dd *DriftDetector) Detect(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	resources, err := dd.synchronisedResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.V(2).Info("detecting drift", "resources", len(resources))
+
+	providerSvcs := map[common.NamespacedName]*corev1.Service{}
+
+	for _, resource := range resources {
+		resourceLogger := logger.WithValues("kind", resource.GetKind(), "resource", resource.GetNamespacedName())
+		resourceCtx := log.IntoContext(ctx, resourceLogger)
+
+		if err := dd.detectResource(resourceCtx, resource, providerSvcs); err != nil {
+			resourceLogger.Error(err, "failed to detect drift")
+		}
+	}
+
+	return nil
+}
+
+func (dd *DriftDetector) synchronisedResources(ctx context.Context) ([]pipelineshub.Resource, error) {
+	var resources []pipelineshub.Resource
+
+	var pipelines pipelineshub.PipelineList
+	if err := dd.Client.NonCached.List(ctx, &pipelines); err != nil {
+		return nil, err
+	}
+	for i := range pipelines.Items {
+		resources = append(resources, &pipelines.Items[i])
+	}
+
+	var runSchedules pipelineshub.RunScheduleList
+	if err := dd.Client.NonCached.List(ctx, &runSchedules); err != nil {
+		return nil, err
+	}
+	for i := range runSchedules.Items {
+		resources = append(resources, &runSchedules.Items[i])
+	}
+
+	var experiments pipelineshub.ExperimentList
+	if err := dd.Client.NonCached.List(ctx, &experiments); err != nil {
+		return nil, err
+	}
+	for i := range experiments.Items {
+		resources = append(resources, &experiments.Items[i])
+	}
+
+	var synchronised []pipelineshub.Resource
+	for _, resource := range resources {
+		status := resource.GetStatus()
+		if resource.GetDeletionTimestamp().IsZero() &&
+			status.Conditions.GetSyncStateFromReason() == apis.Succeeded &&
+			status.Provider.Id != "" &&
+			dd.Scope.Contains(ctx, resource) {
+			synchronised = append(synchronised, resource)
+		}
+	}
+
+	return synchronised, nil
+}
+
+func (dd *DriftDetector) providerService(
+	ctx context.Context,
+	providerName common.NamespacedName,
+	providerSvcs map[common.NamespacedName]*corev1.Service,
+) (*corev1.Service, error) {
+	if providerSvc, ok := providerSvcs[providerName]; ok {
+		return providerSvc, nil
+	}
+
+	provider := pipelineshub.Provider{}
+	if err := dd.Client.NonCached.Get(
+		ctx,
+		types.NamespacedName{Namespace: providerName.Namespace, Name: providerName.Name},
+		&provider,
+	); err != nil {
+		return nil, err
+	}
+
+	providerSvc, err := dd.ServiceManager.Get(ctx, &provider)
+	if err != nil {
+		return nil, err
+	}
+	providerSvcs[providerName] = providerSvc
+
+	return providerSvc, nil
+}
+
+func (dd *DriftDetector) detectResource(
+	ctx context.Context,
+	resource pipelineshub.Resource,
+	providerSvcs map[common.NamespacedName]*corev1.Service,
+) error {
+	status := resource.GetStatus()
+
+	providerSvc, err := dd.providerService(ctx, status.Provider.Name, providerSvcs)
+	if err != nil {
+		return err
+	}
+
+	output, err := dd.ResourceGetter.Get(ctx, *providerSvc, resource.GetKind(), status.Provider.Id)
+
+	switch {
+	case errors.Is(err, ErrProviderResourceUnimplemented):
+		log.FromContext(ctx).V(1).Info("skipping drift detection", "reason", err.Error())
+		return nil
+	case errors.Is(err, ErrProviderResourceNotFound):
+		return dd.setDriftCondition(ctx, resource, apis.DriftReasons.Missing,
+			fmt.Sprintf(`%s "%s" not found on provider`, resource.GetKind(), status.Provider.Id))
+	case err != nil:
+		return err
+	case output.Version != "" && output.Version != status.Version:
+		return dd.setDriftCondition(ctx, resource, apis.DriftReasons.Diverged,
+			fmt.Sprintf(`provider has version "%s" instead of "%s"`, output.Version, status.Version))
+	default:
+		return dd.setDriftCondition(ctx, resource, apis.DriftReasons.InSync, "")
+	}
+}
+
+func (dd *DriftDetector) setDriftCondition(
+	ctx context.Context,
+	resource pipelineshub.Resource,
+	reason string,
+	message string,
+) error {
+	logger := log.FromContext(ctx)
+	status := resource.GetStatus()
+	drifted := reason != apis.DriftReasons.InSync
+	recreate := drifted && dd.Config.DriftDetection.Recreate
+
+	existing, found := lo.Find(status.Conditions, func(condition metav1.Condition) bool {
+		return condition.Type == apis.ConditionTypes.Drifted
+	})
+	if !found && !drifted {
+		return nil
+	}
+	if found && existing.Reason == reason && existing.Message == message && !recreate {
+		return nil
+	}
+
+	conditionStatus := metav1.ConditionFalse
+	if drifted {
+		conditionStatus = metav1.ConditionTrue
+	}
+
+	status.Conditions = status.Conditions.MergeIntoConditions(metav1.Condition{
+		Type:               apis.ConditionTypes.Drifted,
+		Status:             conditionStatus,
+		ObservedGeneration: status.ObservedGeneration,
+		LastTransitionTime: metav1.NewTime(dd.currentTimeFunc()),
+		Reason:             reason,
+		Message:            message,
+	})
+
+	if recreate {
+		logger.Info("resynchronising drifted resource", "reason", reason)
+		status.Version = ""
+		if reason == apis.DriftReasons.Missing {
+			status.Provider.Id = ""
+		}
+	}
+
+	resource.SetStatus(status)
+	if err := dd.Client.Status().Update(ctx, resource); err != nil {
+		return err
+	}
+
+	if drifted {
+		logger.Info("resource drifted", "reason", reason, "message", message)
+		dd.driftedCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("kind", resource.GetKind()),
+			attribute.String("reason", reason),
+		))
+		dd.Recorder.Event(resource, EventTypes.Warning, EventReasons.Drifted, message)
+	}
+
+	return nil
+}
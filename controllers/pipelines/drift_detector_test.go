@@ -0,0 +1,216 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type StubbedProviderResourceGetter struct {
+	Outputs map[string]providers.Output
+	Errors  map[string]error
+}
+
+func (sprg StubbedProviderResourceGetter) Get(_ context.Context, _ corev1.Service, _ string, id string) (providers.Output, error) {
+	return sprg.Outputs[id], sprg.Errors[id]
+}
+
+var _ = Describe("DriftDetector", func() {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		provider    *pipelineshub.Provider
+		k8sClient   client.Client
+		getter      StubbedProviderResourceGetter
+		driftCfg    config.DriftDetection
+		newDetector func() *DriftDetector
+	)
+
+	synchronisedPipeline := func(id string, version string) *pipelineshub.Pipeline {
+		pipeline := pipelineshub.RandomPipeline(provider.GetCommonNamespacedName())
+		pipeline.Status = pipelineshub.Status{
+			Provider: pipelineshub.ProviderAndId{Name: provider.GetCommonNamespacedName(), Id: id},
+			Version:  version,
+			Conditions: apis.Conditions{{
+				Type:   apis.ConditionTypes.SynchronizationSucceeded,
+				Status: metav1.ConditionTrue,
+				Reason: string(apis.Succeeded),
+			}},
+		}
+		return pipeline
+	}
+
+	driftCondition := func(pipeline *pipelineshub.Pipeline) (*metav1.Condition, pipelineshub.Status) {
+		fetched := &pipelineshub.Pipeline{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: pipeline.Namespace, Name: pipeline.Name}, fetched)).To(Succeed())
+
+		for i := range fetched.Status.Conditions {
+			if fetched.Status.Conditions[i].Type == apis.ConditionTypes.Drifted {
+				return &fetched.Status.Conditions[i], fetched.Status
+			}
+		}
+		return nil, fetched.Status
+	}
+
+	build := func(objects ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+		Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "provider-service",
+				Namespace: provider.Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: pipelineshub.GroupVersion.String(),
+					Kind:       "Provider",
+					Name:       provider.Name,
+					UID:        provider.UID,
+					Controller: &[]bool{true}[0],
+				}},
+			},
+		}
+
+		k8sClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&pipelineshub.Pipeline{}).
+			WithObjects(append(objects, provider, service)...).
+			Build()
+	}
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		provider.UID = "provider-uid"
+		getter = StubbedProviderResourceGetter{Outputs: map[string]providers.Output{}, Errors: map[string]error{}}
+		driftCfg = config.DriftDetection{Enabled: true}
+
+		newDetector = func() *DriftDetector {
+			optInClient := controllers.OptInClient{Writer: k8sClient, StatusClient: k8sClient, Cached: k8sClient, NonCached: k8sClient}
+			cfg := config.ConfigSpec{DriftDetection: driftCfg}
+			detector, err := NewDriftDetector(K8sExecutionContext{
+				Client:   optInClient,
+				Recorder: record.NewFakeRecorder(10),
+				Scheme:   k8sClient.Scheme(),
+			}, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			detector.ResourceGetter = getter
+			detector.currentTimeFunc = func() time.Time { return now }
+			return detector
+		}
+	})
+
+	It("does not add a condition to resources that are in sync", func() {
+		pipeline := synchronisedPipeline("in-sync", "v1")
+		getter.Outputs["in-sync"] = providers.Output{Id: "in-sync", Version: "v1"}
+		build(pipeline)
+
+		Expect(newDetector().Detect(ctx)).To(Succeed())
+
+		condition, _ := driftCondition(pipeline)
+		Expect(condition).To(BeNil())
+	})
+
+	It("marks missing resources as drifted", func() {
+		pipeline := synchronisedPipeline("missing", "v1")
+		getter.Errors["missing"] = ErrProviderResourceNotFound
+		build(pipeline)
+
+		Expect(newDetector().Detect(ctx)).To(Succeed())
+
+		condition, status := driftCondition(pipeline)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(apis.DriftReasons.Missing))
+		Expect(status.Provider.Id).To(Equal("missing"))
+		Expect(status.Version).To(Equal("v1"))
+	})
+
+	It("marks resources with a different provider version as drifted", func() {
+		pipeline := synchronisedPipeline("diverged", "v1")
+		getter.Outputs["diverged"] = providers.Output{Id: "diverged", Version: "v0"}
+		build(pipeline)
+
+		Expect(newDetector().Detect(ctx)).To(Succeed())
+
+		condition, _ := driftCondition(pipeline)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(apis.DriftReasons.Diverged))
+	})
+
+	It("marks previously drifted resources as in sync", func() {
+		pipeline := synchronisedPipeline("recovered", "v1")
+		pipeline.Status.Conditions = append(pipeline.Status.Conditions, metav1.Condition{
+			Type:   apis.ConditionTypes.Drifted,
+			Status: metav1.ConditionTrue,
+			Reason: apis.DriftReasons.Missing,
+		})
+		build(pipeline)
+
+		Expect(newDetector().Detect(ctx)).To(Succeed())
+
+		condition, _ := driftCondition(pipeline)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(apis.DriftReasons.InSync))
+	})
+
+	It("skips resources the provider cannot get", func() {
+		pipeline := synchronisedPipeline("unimplemented", "v1")
+		getter.Errors["unimplemented"] = ErrProviderResourceUnimplemented
+		build(pipeline)
+
+		Expect(newDetector().Detect(ctx)).To(Succeed())
+
+		condition, _ := driftCondition(pipeline)
+		Expect(condition).To(BeNil())
+	})
+
+	When("recreation is enabled", func() {
+		BeforeEach(func() {
+			driftCfg.Recreate = true
+		})
+
+		It("hands missing resources back for recreation", func() {
+			pipeline := synchronisedPipeline("missing", "v1")
+			getter.Errors["missing"] = ErrProviderResourceNotFound
+			build(pipeline)
+
+			Expect(newDetector().Detect(ctx)).To(Succeed())
+
+			_, status := driftCondition(pipeline)
+			Expect(status.Provider.Id).To(BeEmpty())
+			Expect(status.Provider.Name).To(Equal(provider.GetCommonNamespacedName()))
+			Expect(status.Version).To(BeEmpty())
+		})
+
+		It("hands diverged resources back for an update", func() {
+			pipeline := synchronisedPipeline("diverged", "v1")
+			getter.Outputs["diverged"] = providers.Output{Id: "diverged", Version: "v0"}
+			build(pipeline)
+
+			Expect(newDetector().Detect(ctx)).To(Succeed())
+
+			_, status := driftCondition(pipeline)
+			Expect(status.Provider.Id).To(Equal("diverged"))
+			Expect(status.Version).To(BeEmpty())
+		})
+	})
+})
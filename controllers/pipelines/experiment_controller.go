@@ -8,6 +8,7 @@ import (
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
 	"github.com/sky-uk/kfp-operator/internal/config"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -30,6 +31,11 @@ func NewExperimentReconciler(
 		StateHandler: StateHandler[*pipelineshub.Experiment]{
 			WorkflowRepository: workflowRepository,
 			WorkflowFactory:    workflowfactory.ExperimentWorkflowFactory(config),
+			Namespaces:         ec.Client.Cached,
+			DirectOperations:   NewDirectOperations(NewHttpDirectExecutor()),
+			ResourceGetter:     NewHttpProviderResourceClient(config.DefaultProviderValues.ServicePort),
+			Throttle:           NewWorkflowLimitThrottle(ec.Client.Cached),
+			Retry:              config.Retry,
 		},
 		ResourceReconciler: ResourceReconciler[*pipelineshub.Experiment]{
 			EC:     ec,
@@ -59,6 +65,11 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !r.EC.Scope.Contains(ctx, experiment) {
+		logger.V(2).Info("ignoring resource outside of the scope")
+		return ctrl.Result{}, nil
+	}
+
 	logger.V(3).Info("found experiment", "resource", experiment)
 
 	provider, err := r.LoadProvider(ctx, experiment.Spec.Provider)
@@ -83,15 +94,15 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	duration := time.Now().Sub(startTime)
 	logger.V(2).Info("reconciliation ended", logkeys.Duration, duration)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter(commands)}, nil
 }
 
 func (r *ExperimentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	experiment := &pipelineshub.Experiment{}
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(experiment)
+		For(experiment, builder.WithPredicates(r.EC.Scope.Predicate()))
 
-	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, experiment)
+	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, experiment, &pipelineshub.ExperimentList{})
 
 	return controllerBuilder.Complete(r)
 }
@@ -0,0 +1,53 @@
+//go:build unit
+
+package mocks
+
+import (
+	"context"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+type MockAutoscalerManager struct {
+	mock.Mock
+}
+
+func (m *MockAutoscalerManager) Create(_ context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler, provider *pipelineshub.Provider) error {
+	args := m.Called(hpa, provider)
+	return args.Error(0)
+}
+
+func (m *MockAutoscalerManager) Update(_ context.Context, old, new *autoscalingv2.HorizontalPodAutoscaler, provider *pipelineshub.Provider) error {
+	args := m.Called(old, new, provider)
+	return args.Error(0)
+}
+
+func (m *MockAutoscalerManager) Delete(_ context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	args := m.Called(hpa)
+	return args.Error(0)
+}
+
+func (m *MockAutoscalerManager) Get(_ context.Context, provider *pipelineshub.Provider) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	args := m.Called(provider)
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	if args.Get(0) != nil {
+		hpa = args.Get(0).(*autoscalingv2.HorizontalPodAutoscaler)
+	}
+	return hpa, args.Error(1)
+}
+
+func (m *MockAutoscalerManager) Equal(a, b *autoscalingv2.HorizontalPodAutoscaler) bool {
+	args := m.Called(a, b)
+	return args.Bool(0)
+}
+
+func (m *MockAutoscalerManager) Construct(provider *pipelineshub.Provider, deployment *appsv1.Deployment) *autoscalingv2.HorizontalPodAutoscaler {
+	args := m.Called(provider, deployment)
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	if args.Get(0) != nil {
+		hpa = args.Get(0).(*autoscalingv2.HorizontalPodAutoscaler)
+	}
+	return hpa
+}
@@ -37,7 +37,7 @@ func (m *MockDeploymentManager) Equal(a, b *appsv1.Deployment) bool {
 	return args.Bool(0)
 }
 
-func (m *MockDeploymentManager) Construct(provider *pipelineshub.Provider) (*appsv1.Deployment, error) {
+func (m *MockDeploymentManager) Construct(_ context.Context, provider *pipelineshub.Provider) (*appsv1.Deployment, error) {
 	args := m.Called(provider)
 	var deployment *appsv1.Deployment
 	if args.Get(0) != nil {
@@ -0,0 +1,52 @@
+//go:build unit
+
+package mocks
+
+import (
+	"context"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/stretchr/testify/mock"
+	policyv1 "k8s.io/api/policy/v1"
+)
+
+type MockDisruptionBudgetManager struct {
+	mock.Mock
+}
+
+func (m *MockDisruptionBudgetManager) Create(_ context.Context, pdb *policyv1.PodDisruptionBudget, provider *pipelineshub.Provider) error {
+	args := m.Called(pdb, provider)
+	return args.Error(0)
+}
+
+func (m *MockDisruptionBudgetManager) Update(_ context.Context, old, new *policyv1.PodDisruptionBudget, provider *pipelineshub.Provider) error {
+	args := m.Called(old, new, provider)
+	return args.Error(0)
+}
+
+func (m *MockDisruptionBudgetManager) Delete(_ context.Context, pdb *policyv1.PodDisruptionBudget) error {
+	args := m.Called(pdb)
+	return args.Error(0)
+}
+
+func (m *MockDisruptionBudgetManager) Get(_ context.Context, provider *pipelineshub.Provider) (*policyv1.PodDisruptionBudget, error) {
+	args := m.Called(provider)
+	var pdb *policyv1.PodDisruptionBudget
+	if args.Get(0) != nil {
+		pdb = args.Get(0).(*policyv1.PodDisruptionBudget)
+	}
+	return pdb, args.Error(1)
+}
+
+func (m *MockDisruptionBudgetManager) Equal(a, b *policyv1.PodDisruptionBudget) bool {
+	args := m.Called(a, b)
+	return args.Bool(0)
+}
+
+func (m *MockDisruptionBudgetManager) Construct(provider *pipelineshub.Provider) *policyv1.PodDisruptionBudget {
+	args := m.Called(provider)
+	var pdb *policyv1.PodDisruptionBudget
+	if args.Get(0) != nil {
+		pdb = args.Get(0).(*policyv1.PodDisruptionBudget)
+	}
+	return pdb
+}
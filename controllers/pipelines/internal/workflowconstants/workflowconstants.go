@@ -11,6 +11,7 @@ const (
 	OwnerKindLabelKey                   = apis.Group + "/owner.kind"
 	OwnerNameLabelKey                   = apis.Group + "/owner.name"
 	OwnerNamespaceLabelKey              = apis.Group + "/owner.namespace"
+	ProviderNameLabelKey                = apis.Group + "/provider.name"
 	ConstructionFailedError             = "error constructing workflow"
 	ProviderNameParameterName           = "provider-name"
 	ProviderServiceUrl                  = "provider-service-url"
@@ -0,0 +1,75 @@
+package workflowfactory
+
+import (
+	"encoding/json"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Plan describes what a workflow would submit to the provider.
+type Plan struct {
+	WorkflowTemplate string `json:"workflowTemplate"`
+	// Patches are the patches of the provider that have been applied to the
+	// resource definition.
+	Patches            []pipelineshub.Patch `json:"patches,omitempty"`
+	ResourceDefinition json.RawMessage      `json:"resourceDefinition,omitempty"`
+	// Parameters are the remaining parameters of the workflow.
+	Parameters map[string]string `json:"parameters"`
+}
+
+// PlanForWorkflow describes the workflow and the patches applied to the
+// resource definition it contains.
+func PlanForWorkflow(workflow *argo.Workflow, patches []pipelineshub.Patch) Plan {
+	plan := Plan{
+		Patches:    patches,
+		Parameters: map[string]string{},
+	}
+
+	if workflow.Spec.WorkflowTemplateRef != nil {
+		plan.WorkflowTemplate = workflow.Spec.WorkflowTemplateRef.Name
+	}
+
+	for _, parameter := range workflow.Spec.Arguments.Parameters {
+		if parameter.Value == nil {
+			continue
+		}
+
+		if parameter.Name == workflowconstants.ResourceDefinitionParameterName && json.Valid([]byte(parameter.Value.String())) {
+			plan.ResourceDefinition = json.RawMessage(parameter.Value.String())
+		} else {
+			plan.Parameters[parameter.Name] = parameter.Value.String()
+		}
+	}
+
+	return plan
+}
+
+// ConstructPlan renders the workflow that would synchronise the resource with
+// the provider without submitting it. Resources that already exist on the
+// provider are planned to be updated, all others to be created.
+func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructPlan(
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource R,
+) (*Plan, error) {
+	construct := workflows.ConstructCreationWorkflow
+	if resource.GetStatus().Provider.Id != "" {
+		construct = workflows.ConstructUpdateWorkflow
+	}
+
+	workflow, err := construct(provider, providerSvc, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	patches, _, err := workflows.DefinitionCreator(provider, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := PlanForWorkflow(workflow, patches)
+	return &plan, nil
+}
@@ -0,0 +1,49 @@
+//go:build unit
+
+package workflowfactory
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("ConstructPlan", func() {
+	patches := []pipelineshub.Patch{{Type: "merge", Payload: `{"patched": true}`}}
+
+	factory := ResourceWorkflowFactory[*pipelineshub.TestResource, map[string]string]{
+		TemplateSuffix: SimpleSuffix,
+		DefinitionCreator: func(_ pipelineshub.Provider, resource *pipelineshub.TestResource) ([]pipelineshub.Patch, map[string]string, error) {
+			return patches, map[string]string{"name": resource.GetName()}, nil
+		},
+		WorkflowParamsCreator: WorkflowParamsCreatorNoop[*pipelineshub.TestResource],
+	}
+
+	It("plans the creation of resources that do not exist on the provider", func() {
+		resource := pipelineshub.RandomResource()
+		resource.Status.Provider.Id = ""
+
+		plan, err := factory.ConstructPlan(*pipelineshub.RandomProvider(), corev1.Service{}, resource)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.WorkflowTemplate).To(Equal("create-simple"))
+		Expect(plan.Patches).To(Equal(patches))
+		Expect(plan.ResourceDefinition).To(MatchJSON(`{"name": "` + resource.GetName() + `", "patched": true}`))
+		Expect(plan.Parameters).To(HaveKeyWithValue(workflowconstants.ResourceKindParameterName, resource.GetKind()))
+		Expect(plan.Parameters).NotTo(HaveKey(workflowconstants.ResourceDefinitionParameterName))
+		Expect(plan.Parameters).NotTo(HaveKey(workflowconstants.ResourceIdParameterName))
+	})
+
+	It("plans the update of resources that exist on the provider", func() {
+		resource := pipelineshub.RandomResource()
+		resource.Status.Provider.Id = "provider-id"
+
+		plan, err := factory.ConstructPlan(*pipelineshub.RandomProvider(), corev1.Service{}, resource)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.WorkflowTemplate).To(Equal("update-simple"))
+		Expect(plan.Parameters).To(HaveKeyWithValue(workflowconstants.ResourceIdParameterName, "provider-id"))
+	})
+})
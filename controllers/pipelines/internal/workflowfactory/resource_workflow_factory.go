@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
-	"slices"
 	"strconv"
 
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -15,7 +14,6 @@ import (
 	"github.com/sky-uk/kfp-operator/internal/config"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 type WorkflowFactory[R pipelineshub.Resource] interface {
@@ -36,9 +34,16 @@ type WorkflowFactory[R pipelineshub.Resource] interface {
 		providerSvc corev1.Service,
 		resource R,
 	) (*argo.Workflow, error)
+
+	ConstructPlan(
+		provider pipelineshub.Provider,
+		providerSvc corev1.Service,
+		resource R,
+	) (*Plan, error)
 }
 
-func createProviderServiceUrl(svc corev1.Service, port int) string {
+// ProviderServiceUrl is the in-cluster address of a provider-service.
+func ProviderServiceUrl(svc corev1.Service, port int) string {
 	return net.JoinHostPort(fmt.Sprintf("%s.%s", svc.Name, svc.Namespace), strconv.Itoa(port))
 }
 
@@ -79,10 +84,13 @@ func (workflows ResourceWorkflowFactory[R, ResourceDefinition]) CommonWorkflowMe
 	owner pipelineshub.Resource,
 	provider pipelineshub.Provider,
 ) *metav1.ObjectMeta {
+	labels := workflowconstants.CommonWorkflowLabels(owner)
+	labels[workflowconstants.ProviderNameLabelKey] = provider.Name
+
 	return &metav1.ObjectMeta{
 		GenerateName: fmt.Sprintf("%s-%s-", owner.GetKind(), owner.GetName()),
 		Namespace:    provider.Namespace,
-		Labels:       workflowconstants.CommonWorkflowLabels(owner),
+		Labels:       labels,
 	}
 }
 
@@ -105,16 +113,6 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) resourceDefinit
 	return patchedJsonString, nil
 }
 
-func checkResourceNamespaceAllowed(
-	resourceNamespacedName types.NamespacedName,
-	provider pipelineshub.Provider,
-) error {
-	if len(provider.Spec.AllowedNamespaces) > 0 && !slices.Contains(provider.Spec.AllowedNamespaces, resourceNamespacedName.Namespace) {
-		return fmt.Errorf("resource %s in namespace %s is not allowed by provider %s", resourceNamespacedName.Name, resourceNamespacedName.Namespace, provider.Name)
-	}
-	return nil
-}
-
 func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructCreationWorkflow(
 	provider pipelineshub.Provider,
 	providerSvc corev1.Service,
@@ -130,10 +128,6 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructCreati
 		return nil, err
 	}
 
-	if err = checkResourceNamespaceAllowed(resource.GetNamespacedName(), provider); err != nil {
-		return nil, err
-	}
-
 	params := []argo.Parameter{
 		{
 			Name:  workflowconstants.ResourceKindParameterName,
@@ -150,7 +144,7 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructCreati
 		{
 			Name: workflowconstants.ProviderServiceUrl,
 			Value: argo.AnyStringPtr(
-				createProviderServiceUrl(
+				ProviderServiceUrl(
 					providerSvc,
 					workflows.Config.DefaultProviderValues.ServicePort,
 				),
@@ -192,10 +186,6 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructUpdate
 		return nil, err
 	}
 
-	if err = checkResourceNamespaceAllowed(resource.GetNamespacedName(), provider); err != nil {
-		return nil, err
-	}
-
 	params := []argo.Parameter{
 		{
 			Name:  workflowconstants.ResourceKindParameterName,
@@ -216,7 +206,7 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructUpdate
 		{
 			Name: workflowconstants.ProviderServiceUrl,
 			Value: argo.AnyStringPtr(
-				createProviderServiceUrl(
+				ProviderServiceUrl(
 					providerSvc,
 					workflows.Config.DefaultProviderValues.ServicePort,
 				),
@@ -254,10 +244,6 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructDeleti
 		return nil, err
 	}
 
-	if err = checkResourceNamespaceAllowed(resource.GetNamespacedName(), provider); err != nil {
-		return nil, err
-	}
-
 	return &argo.Workflow{
 		ObjectMeta: *workflows.CommonWorkflowMeta(resource, provider),
 		Spec: argo.WorkflowSpec{
@@ -278,7 +264,7 @@ func (workflows *ResourceWorkflowFactory[R, ResourceDefinition]) ConstructDeleti
 					{
 						Name: workflowconstants.ProviderServiceUrl,
 						Value: argo.AnyStringPtr(
-							createProviderServiceUrl(
+							ProviderServiceUrl(
 								providerSvc,
 								workflows.Config.DefaultProviderValues.ServicePort,
 							),
@@ -8,7 +8,6 @@ import (
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
 	"github.com/sky-uk/kfp-operator/internal/config"
-	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ = Describe("CommonWorkflowMeta", func() {
@@ -24,6 +23,7 @@ var _ = Describe("CommonWorkflowMeta", func() {
 		Expect(meta.Labels).To(HaveKeyWithValue(workflowconstants.OwnerKindLabelKey, owner.GetKind()))
 		Expect(meta.Labels).To(HaveKeyWithValue(workflowconstants.OwnerNameLabelKey, owner.GetName()))
 		Expect(meta.Labels).To(HaveKeyWithValue(workflowconstants.OwnerNamespaceLabelKey, owner.GetNamespace()))
+		Expect(meta.Labels).To(HaveKeyWithValue(workflowconstants.ProviderNameLabelKey, provider.Name))
 	})
 
 	It("uses the provider namespace regardless of config", func() {
@@ -40,36 +40,3 @@ var _ = Describe("CommonWorkflowMeta", func() {
 		Expect(meta.Namespace).To(Equal("provider-namespace"))
 	})
 })
-
-var _ = Describe("checkResourceNamespaceAllowed", func() {
-	It("fails when the resource namespace is not in the provider allowed namespaces", func() {
-		provider := pipelineshub.RandomProvider()
-		provider.Spec.AllowedNamespaces = []string{"bar"}
-		provider.Name = "test"
-		err := checkResourceNamespaceAllowed(types.NamespacedName{
-			Namespace: "foo",
-			Name:      "test-resource",
-		}, *provider)
-		Expect(err).To(MatchError("resource test-resource in namespace foo is not allowed by provider test"))
-	})
-
-	It("succeeds when the provider allowed namespaces is empty", func() {
-		provider := pipelineshub.RandomProvider()
-		provider.Spec.AllowedNamespaces = []string{}
-		err := checkResourceNamespaceAllowed(types.NamespacedName{
-			Namespace: "foo",
-			Name:      "test-resource",
-		}, *provider)
-		Expect(err).NotTo(HaveOccurred())
-	})
-
-	It("succeeds when the resource namespace is in the provider allowed namespaces", func() {
-		provider := pipelineshub.RandomProvider()
-		provider.Spec.AllowedNamespaces = []string{"foo"}
-		err := checkResourceNamespaceAllowed(types.NamespacedName{
-			Namespace: "foo",
-			Name:      "test-resource",
-		}, *provider)
-		Expect(err).NotTo(HaveOccurred())
-	})
-})
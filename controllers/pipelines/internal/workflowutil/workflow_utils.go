@@ -3,6 +3,7 @@ package workflowutil
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
@@ -50,6 +51,105 @@ func GetWorkflowOutput(
 	return output, err
 }
 
+// MaxFailureMessageLength limits the failure details of workflows so that
+// they fit into conditions and events.
+const MaxFailureMessageLength = 512
+
+// GetFailureMessage returns why a workflow failed. The provider error of the
+// provider-service takes precedence over the message of the failed pod,
+// which in turn takes precedence over the message of the workflow. The
+// message is truncated to MaxFailureMessageLength.
+func GetFailureMessage(workflow *argo.Workflow) string {
+	message := workflow.Status.Message
+
+	if providerError := getProviderError(workflow); providerError != "" {
+		message = providerError
+	} else if node := failedNode(workflow); node != nil {
+		message = fmt.Sprintf("%s: %s", node.DisplayName, node.Message)
+	}
+
+	return Truncate(message, MaxFailureMessageLength)
+}
+
+// getProviderError returns the provider error of the output of the workflow,
+// falling back to the outputs of its nodes for workflows that failed before
+// the output reached the entrypoint.
+func getProviderError(workflow *argo.Workflow) string {
+	if output, err := GetWorkflowOutput(workflow, workflowconstants.ProviderOutputParameterName); err == nil {
+		return output.ProviderError
+	}
+
+	for _, node := range sortedNodes(workflow) {
+		if node.Outputs == nil {
+			continue
+		}
+
+		value, ok := mapParams(node.Outputs.Parameters)[workflowconstants.ProviderOutputParameterName]
+		if !ok {
+			continue
+		}
+
+		output := providers.Output{}
+		if err := json.Unmarshal([]byte(value), &output); err == nil && output.ProviderError != "" {
+			return output.ProviderError
+		}
+	}
+
+	return ""
+}
+
+// failedNode returns the pod that failed last with a message.
+func failedNode(workflow *argo.Workflow) *argo.NodeStatus {
+	var failed *argo.NodeStatus
+
+	for _, node := range sortedNodes(workflow) {
+		if node.Type != argo.NodeTypePod || node.Message == "" ||
+			(node.Phase != argo.NodeFailed && node.Phase != argo.NodeError) {
+			continue
+		}
+
+		if failed == nil || !node.FinishedAt.Before(&failed.FinishedAt) {
+			failed = &node
+		}
+	}
+
+	return failed
+}
+
+// sortedNodes returns the nodes of the workflow in the order of their ids so
+// that the failure message is stable.
+func sortedNodes(workflow *argo.Workflow) []argo.NodeStatus {
+	ids := make([]string, 0, len(workflow.Status.Nodes))
+	for id := range workflow.Status.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	nodes := make([]argo.NodeStatus, len(ids))
+	for i, id := range ids {
+		nodes[i] = workflow.Status.Nodes[id]
+	}
+
+	return nodes
+}
+
+const ellipsis = "..."
+
+// Truncate shortens the message to at most maxLength runes, marking the
+// truncation with an ellipsis when there is room for it.
+func Truncate(message string, maxLength int) string {
+	runes := []rune(message)
+	if len(runes) <= maxLength {
+		return message
+	}
+
+	if maxLength < len(ellipsis) {
+		return string(runes[:max(maxLength, 0)])
+	}
+
+	return string(runes[:maxLength-len(ellipsis)]) + ellipsis
+}
+
 func SetWorkflowProvider(
 	workflow *argo.Workflow,
 	provider pipelineshub.Provider,
@@ -3,11 +3,15 @@
 package workflowutil
 
 import (
+	"strings"
+	"testing"
+	"time"
+
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"testing"
 )
 
 func TestWorkflowUtilsUnitSuite(t *testing.T) {
@@ -107,4 +111,85 @@ var _ = Describe("Utils", func() {
 			Expect(failed).To(BeNil())
 		})
 	})
+
+	Describe("GetFailureMessage", func() {
+		now := time.Now()
+
+		failedPod := func(name, message string, finishedAt time.Time) argo.NodeStatus {
+			return argo.NodeStatus{
+				DisplayName: name,
+				Type:        argo.NodeTypePod,
+				Phase:       argo.NodeFailed,
+				Message:     message,
+				FinishedAt:  metav1.Time{Time: finishedAt},
+			}
+		}
+
+		failedWorkflow := func(nodes map[string]argo.NodeStatus) *argo.Workflow {
+			return &argo.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-workflow"},
+				Status: argo.WorkflowStatus{
+					Phase:   argo.WorkflowFailed,
+					Message: "child 'a-workflow-1' failed",
+					Nodes:   nodes,
+				},
+			}
+		}
+
+		It("returns the provider error of a node", func() {
+			workflow := failedWorkflow(map[string]argo.NodeStatus{
+				"a-workflow":   {Type: argo.NodeTypeSteps, Phase: argo.NodeFailed},
+				"a-workflow-1": failedPod("update", "Error (exit code 1)", now),
+				"a-workflow-2": {
+					Outputs: &argo.Outputs{Parameters: []argo.Parameter{{
+						Name:  workflowconstants.ProviderOutputParameterName,
+						Value: argo.AnyStringPtr(`{"providerError":"pipeline not found"}`),
+					}}},
+				},
+			})
+
+			Expect(GetFailureMessage(workflow)).To(Equal("pipeline not found"))
+		})
+
+		It("returns the message of the pod that failed last", func() {
+			workflow := failedWorkflow(map[string]argo.NodeStatus{
+				"a-workflow":   {Type: argo.NodeTypeSteps, Phase: argo.NodeFailed, Message: "child 'a-workflow-2' failed"},
+				"a-workflow-1": failedPod("compile", "OOMKilled (exit code 137)", now.Add(-time.Minute)),
+				"a-workflow-2": failedPod("upload", "Error (exit code 1)", now),
+			})
+
+			Expect(GetFailureMessage(workflow)).To(Equal("upload: Error (exit code 1)"))
+		})
+
+		It("falls back to the message of the workflow", func() {
+			Expect(GetFailureMessage(failedWorkflow(nil))).To(Equal("child 'a-workflow-1' failed"))
+		})
+
+		It("truncates long messages", func() {
+			workflow := failedWorkflow(map[string]argo.NodeStatus{
+				"a-workflow-1": failedPod("upload", strings.Repeat("x", 1000), now),
+			})
+
+			message := GetFailureMessage(workflow)
+			Expect(message).To(HaveLen(MaxFailureMessageLength))
+			Expect(message).To(HavePrefix("upload: x"))
+			Expect(message).To(HaveSuffix("..."))
+		})
+	})
+
+	Describe("Truncate", func() {
+		It("leaves short messages unchanged", func() {
+			Expect(Truncate("message", 7)).To(Equal("message"))
+		})
+
+		It("does not split runes", func() {
+			Expect(Truncate("ääääää", 5)).To(Equal("ää..."))
+		})
+
+		It("omits the ellipsis when there is no room for it", func() {
+			Expect(Truncate("message", 2)).To(Equal("me"))
+			Expect(Truncate("message", 0)).To(BeEmpty())
+			Expect(Truncate("message", -1)).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,294 @@
+package pipelines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const DefaultOrphanReapingInterval = 10 * time.Minute
+
+// Labels stamped on provider resources by the provider-services.
+const (
+	providerNameLabel      = "provider-name"
+	providerNamespaceLabel = "provider-namespace"
+)
+
+var orphanReaperKinds = []string{"pipeline", "runschedule", "experiment"}
+
+var OrphanActions = struct {
+	Reported string
+	Deleted  string
+}{
+	Reported: "reported",
+	Deleted:  "deleted",
+}
+
+// Orphan is a provider resource that is not owned by any resource of the
+// operator.
+type Orphan struct {
+	Provider common.NamespacedName
+	Kind     string
+	Id       string
+}
+
+func (o Orphan) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", o.Provider.Namespace, o.Provider.Name, o.Kind, o.Id)
+}
+
+// OrphanReaper periodically lists the resources of each provider by the
+// labels of the provider and reports those that no pipeline, run schedule or
+// experiment owns. Only providers within the scope are reaped, but resources
+// outside of it still count as owners since they may be managed by another
+// instance of the operator. When deletion is enabled, orphans are deleted once they
+// have been found by two consecutive reapings, sparing resources whose
+// status has not yet been updated after creation. Provider resources retained
+// by the deletion policy of the resources that owned them are never orphans,
+// and are forgotten once they no longer exist on the provider.
+type OrphanReaper struct {
+	Client         controllers.OptInClient
+	Recorder       record.EventRecorder
+	Config         config.OrphanReaping
+	ServiceManager ServiceResourceManager
+	ResourceLister ProviderResourceLister
+	Scope          Scope
+	orphanCounter  metric.Int64Counter
+	suspects       map[string]bool
+}
+
+func NewOrphanReaper(ec K8sExecutionContext, config config.ConfigSpec) (*OrphanReaper, error) {
+	meter := otel.Meter("orphan_reaper")
+	orphanCounter, err := meter.Int64Counter(
+		"orphan_reaper_orphaned_resources",
+		metric.WithDescription("Total number of orphaned provider resources found by the orphan reaper"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orphaned resources counter: %w", err)
+	}
+
+	return &OrphanReaper{
+		Client:   ec.Client,
+		Recorder: ec.Recorder,
+		Config:   config.OrphanReaping,
+		ServiceManager: ServiceManager{
+			client: &ec.Client,
+			scheme: ec.Scheme,
+			config: &config,
+		},
+		ResourceLister: NewHttpProviderResourceClient(config.DefaultProviderValues.ServicePort),
+		Scope:          ec.Scope,
+		orphanCounter:  orphanCounter,
+		suspects:       map[string]bool{},
+	}, nil
+}
+
+// NeedLeaderElection ensures that only the leading manager reaps orphans.
+func (or *OrphanReaper) NeedLeaderElection() bool {
+	return true
+}
+
+func (or *OrphanReaper) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphan-reaper")
+	ctx = log.IntoContext(ctx, logger)
+
+	interval := DefaultOrphanReapingInterval
+	if or.Config.Interval != nil {
+		interval = or.Config.Interval.Duration
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := or.Reap(ctx); err != nil {
+			logger.Error(err, "orphan reaping failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reap reports the orphaned resources of all providers and deletes those
+// found by the previous reaping when deletion is enabled. Failures to list
+// or delete the resources of individual providers are logged and do not stop
+// the reaping.
+func (or *OrphanReaper) Reap(ctx context.Context) ([]Orphan, error) {
+	logger := log.FromContext(ctx)
+
+	owned, err := or.ownedResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var providers pipelineshub.ProviderList
+	if err := or.Client.NonCached.List(ctx, &providers); err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	suspects := map[string]bool{}
+
+	for i := range providers.Items {
+		provider := &providers.Items[i]
+		if !or.Scope.Contains(ctx, provider) {
+			continue
+		}
+
+		providerLogger := logger.WithValues("provider", provider.GetCommonNamespacedName())
+		providerCtx := log.IntoContext(ctx, providerLogger)
+
+		providerOrphans, err := or.providerOrphans(providerCtx, provider, owned)
+		if err != nil {
+			providerLogger.Error(err, "failed to find orphaned resources")
+			continue
+		}
+
+		for _, orphan := range providerOrphans {
+			suspects[orphan.key()] = true
+			or.handleOrphan(providerCtx, provider, orphan)
+		}
+		orphans = append(orphans, providerOrphans...)
+	}
+
+	or.suspects = suspects
+	logger.V(2).Info("reaped orphaned resources", "orphans", len(orphans))
+
+	return orphans, nil
+}
+
+func (or *OrphanReaper) ownedResources(ctx context.Context) (map[string]bool, error) {
+	var resources []pipelineshub.Resource
+
+	var pipelines pipelineshub.PipelineList
+	if err := or.Client.NonCached.List(ctx, &pipelines); err != nil {
+		return nil, err
+	}
+	for i := range pipelines.Items {
+		resources = append(resources, &pipelines.Items[i])
+	}
+
+	var runSchedules pipelineshub.RunScheduleList
+	if err := or.Client.NonCached.List(ctx, &runSchedules); err != nil {
+		return nil, err
+	}
+	for i := range runSchedules.Items {
+		resources = append(resources, &runSchedules.Items[i])
+	}
+
+	var experiments pipelineshub.ExperimentList
+	if err := or.Client.NonCached.List(ctx, &experiments); err != nil {
+		return nil, err
+	}
+	for i := range experiments.Items {
+		resources = append(resources, &experiments.Items[i])
+	}
+
+	owned := map[string]bool{}
+	for _, resource := range resources {
+		status := resource.GetStatus()
+		if status.Provider.Id != "" {
+			owned[Orphan{Provider: status.Provider.Name, Kind: resource.GetKind(), Id: status.Provider.Id}.key()] = true
+		}
+	}
+
+	return owned, nil
+}
+
+func (or *OrphanReaper) providerOrphans(
+	ctx context.Context,
+	provider *pipelineshub.Provider,
+	owned map[string]bool,
+) ([]Orphan, error) {
+	providerSvc, err := or.ServiceManager.Get(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		providerNameLabel:      provider.Name,
+		providerNamespaceLabel: provider.Namespace,
+	}
+
+	retained, err := retainedResources(ctx, or.Client.NonCached, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	listedKinds := map[string]bool{}
+	listed := map[string]bool{}
+	for _, kind := range orphanReaperKinds {
+		outputs, err := or.ResourceLister.List(ctx, *providerSvc, kind, labels)
+		if errors.Is(err, ErrProviderResourceUnimplemented) {
+			log.FromContext(ctx).V(2).Info("skipping orphan reaping", "kind", kind, "reason", err.Error())
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		listedKinds[kind] = true
+		for _, output := range outputs {
+			listed[retainedResourceKey(kind, output.Id)] = true
+			orphan := Orphan{Provider: provider.GetCommonNamespacedName(), Kind: kind, Id: output.Id}
+			if !owned[orphan.key()] && !retained[retainedResourceKey(kind, output.Id)] {
+				orphans = append(orphans, orphan)
+			}
+		}
+	}
+
+	if len(retained) > 0 {
+		// Records of retained resources that no longer exist on the provider
+		// or have been adopted again are no longer needed.
+		if err := forgetRetainedResources(ctx, or.Client, provider, func(kind string, id string) bool {
+			orphan := Orphan{Provider: provider.GetCommonNamespacedName(), Kind: kind, Id: id}
+			return listedKinds[kind] && (!listed[retainedResourceKey(kind, id)] || owned[orphan.key()])
+		}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to forget retained provider resources")
+		}
+	}
+
+	return orphans, nil
+}
+
+func (or *OrphanReaper) handleOrphan(ctx context.Context, provider *pipelineshub.Provider, orphan Orphan) {
+	logger := log.FromContext(ctx).WithValues("kind", orphan.Kind, "id", orphan.Id)
+
+	action := OrphanActions.Reported
+	message := fmt.Sprintf(`%s "%s" on provider is not owned by any resource`, orphan.Kind, orphan.Id)
+
+	if or.Config.Delete && or.suspects[orphan.key()] {
+		providerSvc, err := or.ServiceManager.Get(ctx, provider)
+		if err == nil {
+			err = or.ResourceLister.Delete(ctx, *providerSvc, orphan.Kind, orphan.Id)
+		}
+		if err != nil {
+			logger.Error(err, "failed to delete orphaned resource")
+		} else {
+			action = OrphanActions.Deleted
+			message = fmt.Sprintf(`deleted orphaned %s "%s" from provider`, orphan.Kind, orphan.Id)
+		}
+	}
+
+	logger.Info("orphaned resource", "action", action)
+	or.orphanCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("kind", orphan.Kind),
+		attribute.String("action", action),
+	))
+	or.Recorder.Event(provider, EventTypes.Warning, EventReasons.Orphaned, message)
+}
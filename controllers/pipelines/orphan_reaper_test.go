@@ -0,0 +1,249 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type StubbedProviderResourceLister struct {
+	Outputs map[string][]providers.Output
+	Errors  map[string]error
+	Deleted []string
+	Labels  map[string]string
+}
+
+func (sprl *StubbedProviderResourceLister) List(_ context.Context, _ corev1.Service, kind string, labels map[string]string) ([]providers.Output, error) {
+	sprl.Labels = labels
+	return sprl.Outputs[kind], sprl.Errors[kind]
+}
+
+func (sprl *StubbedProviderResourceLister) Delete(_ context.Context, _ corev1.Service, _ string, id string) error {
+	sprl.Deleted = append(sprl.Deleted, id)
+	return nil
+}
+
+var _ = Describe("OrphanReaper", func() {
+	ctx := context.Background()
+
+	var (
+		provider  *pipelineshub.Provider
+		k8sClient client.Client
+		lister    *StubbedProviderResourceLister
+		reaperCfg config.OrphanReaping
+		newReaper func(objects ...client.Object) *OrphanReaper
+	)
+
+	ownedRunSchedule := func(id string) *pipelineshub.RunSchedule {
+		runSchedule := pipelineshub.RandomRunSchedule(provider.GetCommonNamespacedName())
+		runSchedule.Status.Provider = pipelineshub.ProviderAndId{Name: provider.GetCommonNamespacedName(), Id: id}
+		return runSchedule
+	}
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		provider.UID = "provider-uid"
+		lister = &StubbedProviderResourceLister{Outputs: map[string][]providers.Output{}, Errors: map[string]error{}}
+		reaperCfg = config.OrphanReaping{Enabled: true}
+
+		newReaper = func(objects ...client.Object) *OrphanReaper {
+			scheme := runtime.NewScheme()
+			Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+			Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "provider-service",
+					Namespace: provider.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: pipelineshub.GroupVersion.String(),
+						Kind:       "Provider",
+						Name:       provider.Name,
+						UID:        provider.UID,
+						Controller: &[]bool{true}[0],
+					}},
+				},
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(objects, provider, service)...).
+				Build()
+
+			optInClient := controllers.OptInClient{Writer: k8sClient, StatusClient: k8sClient, Cached: k8sClient, NonCached: k8sClient}
+			reaper, err := NewOrphanReaper(K8sExecutionContext{
+				Client:   optInClient,
+				Recorder: record.NewFakeRecorder(10),
+				Scheme:   scheme,
+			}, config.ConfigSpec{OrphanReaping: reaperCfg})
+			Expect(err).NotTo(HaveOccurred())
+			reaper.ResourceLister = lister
+			return reaper
+		}
+	})
+
+	It("lists provider resources by the labels of the provider", func() {
+		reaper := newReaper()
+
+		_, err := reaper.Reap(ctx)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lister.Labels).To(Equal(map[string]string{
+			"provider-name":      provider.Name,
+			"provider-namespace": provider.Namespace,
+		}))
+	})
+
+	It("reports provider resources that no resource owns", func() {
+		lister.Outputs["runschedule"] = []providers.Output{{Id: "owned"}, {Id: "orphaned"}}
+		reaper := newReaper(ownedRunSchedule("owned"))
+
+		orphans, err := reaper.Reap(ctx)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(ConsistOf(Orphan{Provider: provider.GetCommonNamespacedName(), Kind: "runschedule", Id: "orphaned"}))
+		Expect(lister.Deleted).To(BeEmpty())
+	})
+
+	It("skips kinds the provider cannot list", func() {
+		lister.Errors["pipeline"] = ErrProviderResourceUnimplemented
+		lister.Outputs["runschedule"] = []providers.Output{{Id: "orphaned"}}
+		reaper := newReaper()
+
+		orphans, err := reaper.Reap(ctx)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(HaveLen(1))
+	})
+
+	It("does not delete orphans when deletion is disabled", func() {
+		lister.Outputs["runschedule"] = []providers.Output{{Id: "orphaned"}}
+		reaper := newReaper()
+
+		_, err := reaper.Reap(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reaper.Reap(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(lister.Deleted).To(BeEmpty())
+	})
+
+	When("deletion is enabled", func() {
+		BeforeEach(func() {
+			reaperCfg.Delete = true
+		})
+
+		It("deletes orphans found by two consecutive reapings", func() {
+			lister.Outputs["runschedule"] = []providers.Output{{Id: "orphaned"}}
+			reaper := newReaper()
+
+			_, err := reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.Deleted).To(BeEmpty())
+
+			_, err = reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.Deleted).To(ConsistOf("orphaned"))
+		})
+
+		It("spares resources that have been claimed since the previous reaping", func() {
+			lister.Outputs["runschedule"] = []providers.Output{{Id: "claimed"}}
+			reaper := newReaper()
+
+			_, err := reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Create(ctx, ownedRunSchedule("claimed"))).To(Succeed())
+			_, err = reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lister.Deleted).To(BeEmpty())
+		})
+
+		It("spares resources retained by the deletion policy of their owner", func() {
+			lister.Outputs["runschedule"] = []providers.Output{{Id: "retained"}, {Id: "orphaned"}}
+			reaper := newReaper()
+
+			retained := ownedRunSchedule("retained")
+			retained.Spec.DeletionPolicy = pipelineshub.DeletionPolicyRetain
+			ec := K8sExecutionContext{Client: reaper.Client, Scheme: k8sClient.Scheme()}
+			Expect(RecordRetainedResource{Provider: *provider}.execute(ctx, ec, retained)).To(Succeed())
+
+			orphans, err := reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphans).To(ConsistOf(Orphan{Provider: provider.GetCommonNamespacedName(), Kind: "runschedule", Id: "orphaned"}))
+
+			_, err = reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.Deleted).To(ConsistOf("orphaned"))
+		})
+
+		It("reaps retained resources once they have been adopted and orphaned again", func() {
+			lister.Outputs["runschedule"] = []providers.Output{{Id: "retained"}}
+			reaper := newReaper()
+
+			retained := ownedRunSchedule("retained")
+			retained.Spec.DeletionPolicy = pipelineshub.DeletionPolicyRetain
+			ec := K8sExecutionContext{Client: reaper.Client, Scheme: k8sClient.Scheme()}
+			Expect(RecordRetainedResource{Provider: *provider}.execute(ctx, ec, retained)).To(Succeed())
+			Expect(ForgetRetainedResource{Provider: *provider, Id: "retained"}.execute(ctx, ec, retained)).To(Succeed())
+
+			_, err := reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reaper.Reap(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.Deleted).To(ConsistOf("retained"))
+		})
+	})
+
+	It("forgets retained resources that no longer exist on the provider", func() {
+		lister.Outputs["runschedule"] = []providers.Output{{Id: "retained"}}
+		reaper := newReaper()
+
+		ec := K8sExecutionContext{Client: reaper.Client, Scheme: k8sClient.Scheme()}
+		for _, id := range []string{"retained", "deleted"} {
+			runSchedule := ownedRunSchedule(id)
+			runSchedule.Spec.DeletionPolicy = pipelineshub.DeletionPolicyRetain
+			Expect(RecordRetainedResource{Provider: *provider}.execute(ctx, ec, runSchedule)).To(Succeed())
+		}
+
+		_, err := reaper.Reap(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		retained, err := retainedResources(ctx, k8sClient, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retained).To(HaveLen(1))
+		Expect(retained).To(HaveKey(retainedResourceKey("runschedule", "retained")))
+	})
+
+	It("keeps retained resources of kinds the provider cannot list", func() {
+		lister.Errors["runschedule"] = ErrProviderResourceUnimplemented
+		reaper := newReaper()
+
+		runSchedule := ownedRunSchedule("retained")
+		runSchedule.Spec.DeletionPolicy = pipelineshub.DeletionPolicyRetain
+		ec := K8sExecutionContext{Client: reaper.Client, Scheme: k8sClient.Scheme()}
+		Expect(RecordRetainedResource{Provider: *provider}.execute(ctx, ec, runSchedule)).To(Succeed())
+
+		_, err := reaper.Reap(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		retained, err := retainedResources(ctx, k8sClient, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retained).To(HaveKey(retainedResourceKey("runschedule", "retained")))
+	})
+})
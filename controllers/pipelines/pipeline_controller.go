@@ -9,6 +9,7 @@ import (
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
 	"github.com/sky-uk/kfp-operator/internal/config"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -32,6 +33,11 @@ func NewPipelineReconciler(
 		StateHandler: StateHandler[*pipelineshub.Pipeline]{
 			WorkflowRepository: workflowRepository,
 			WorkflowFactory:    workflowfactory.PipelineWorkflowFactory(config),
+			Namespaces:         ec.Client.Cached,
+			DirectOperations:   NewDirectOperations(NewHttpDirectExecutor()),
+			ResourceGetter:     NewHttpProviderResourceClient(config.DefaultProviderValues.ServicePort),
+			Throttle:           NewWorkflowLimitThrottle(ec.Client.Cached),
+			Retry:              config.Retry,
 		},
 		ResourceReconciler: ResourceReconciler[*pipelineshub.Pipeline]{
 			EC:     ec,
@@ -62,6 +68,11 @@ func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !r.EC.Scope.Contains(ctx, pipeline) {
+		logger.V(2).Info("ignoring resource outside of the scope")
+		return ctrl.Result{}, nil
+	}
+
 	logger.V(3).Info("found pipeline", "resource", pipeline)
 
 	provider, err := r.LoadProvider(ctx, pipeline.Spec.Provider)
@@ -86,15 +97,15 @@ func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	duration := time.Now().Sub(startTime)
 	logger.V(2).Info("reconciliation ended", logkeys.Duration, duration)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter(commands)}, nil
 }
 
 func (r *PipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	pipeline := &pipelineshub.Pipeline{}
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(pipeline)
+		For(pipeline, builder.WithPredicates(r.EC.Scope.Predicate()))
 
-	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, pipeline)
+	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, pipeline, &pipelineshub.PipelineList{})
 
 	return controllerBuilder.Complete(r)
 }
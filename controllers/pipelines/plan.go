@@ -0,0 +1,263 @@
+package pipelines
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Plan describes what a workflow would submit to the provider.
+type Plan = workflowfactory.Plan
+
+// RunConfigurationPlan describes what would be submitted to the provider for
+// the RunSchedules and the Run that a RunConfiguration creates. Names of
+// resources that are yet to be created are generated by Kubernetes and
+// therefore end in a placeholder.
+type RunConfigurationPlan struct {
+	// RunSchedules that would be created.
+	RunSchedules []Plan `json:"runSchedules,omitempty"`
+	// DeletedRunSchedules are the names of the RunSchedules that would be
+	// deleted.
+	DeletedRunSchedules []string `json:"deletedRunSchedules,omitempty"`
+	// Run that would be created when the RunConfiguration is triggered by a
+	// change.
+	Run *Plan `json:"run,omitempty"`
+}
+
+const generatedNamePlaceholder = "<generated>"
+
+// withGeneratedName names an object that is yet to be created after the name
+// Kubernetes would generate for it.
+func withGeneratedName[T client.Object](object T) T {
+	if object.GetName() == "" {
+		object.SetName(object.GetGenerateName() + generatedNamePlaceholder)
+	}
+
+	return object
+}
+
+var PlanConstants = struct {
+	PlanKey       string
+	ErrorKey      string
+	GenerationKey string
+}{
+	PlanKey:       "plan.json",
+	ErrorKey:      "error",
+	GenerationKey: "generation",
+}
+
+// PlanConfigMapName is the name of the ConfigMap that holds the plan of a
+// resource.
+func PlanConfigMapName(kind string, name string) string {
+	return fmt.Sprintf("%s-%s-plan", kind, name)
+}
+
+// MarshalPlan renders a plan the way it is stored and printed.
+func MarshalPlan(plan any) (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(plan); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// StorePlan stores the plan of a resource, or the reason why it could not be
+// planned, in a ConfigMap owned by the resource. The plan is marked with the
+// generation of the resource it was rendered for since it is kept once the
+// resource leaves dry-run.
+type StorePlan struct {
+	Plan  any
+	Error string
+}
+
+func (sp StorePlan) execute(ctx context.Context, ec K8sExecutionContext, resource pipelineshub.Resource) error {
+	return storePlan(ctx, ec, resource, resource.GetKind(), sp)
+}
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+func storePlan(ctx context.Context, ec K8sExecutionContext, owner client.Object, kind string, sp StorePlan) error {
+	logger := log.FromContext(ctx)
+
+	data := map[string]string{PlanConstants.GenerationKey: strconv.FormatInt(owner.GetGeneration(), 10)}
+	if sp.Error != "" {
+		data[PlanConstants.ErrorKey] = sp.Error
+	} else {
+		plan, err := MarshalPlan(sp.Plan)
+		if err != nil {
+			return err
+		}
+		data[PlanConstants.PlanKey] = plan
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := ec.Client.NonCached.Get(ctx, client.ObjectKey{Namespace: owner.GetNamespace(), Name: PlanConfigMapName(kind, owner.GetName())}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && maps.Equal(configMap.Data, data) {
+		return nil
+	}
+
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      PlanConfigMapName(kind, owner.GetName()),
+				Namespace: owner.GetNamespace(),
+			},
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(owner, configMap, ec.Scheme); err != nil {
+			return err
+		}
+
+		logger.V(1).Info("storing plan", "configMap", configMap.Name)
+		if err := ec.Client.Create(ctx, configMap); err != nil {
+			return err
+		}
+	} else {
+		configMap.Data = data
+
+		logger.V(1).Info("updating plan", "configMap", configMap.Name)
+		if err := ec.Client.Update(ctx, configMap); err != nil {
+			return err
+		}
+	}
+
+	if sp.Error != "" {
+		ec.Recorder.Eventf(owner, EventTypes.Warning, EventReasons.Planned, "unable to plan: %s", sp.Error)
+	} else {
+		ec.Recorder.Eventf(owner, EventTypes.Normal, EventReasons.Planned, "plan stored in ConfigMap %s", configMap.Name)
+	}
+
+	return nil
+}
+
+// Planner renders what the operator would submit to the provider for a
+// resource.
+type Planner struct {
+	Config config.ConfigSpec
+	Scheme *runtime.Scheme
+}
+
+// ProviderService constructs the service the operator would create for the
+// provider.
+func (p Planner) ProviderService(provider pipelineshub.Provider) corev1.Service {
+	return *withGeneratedName(ServiceManager{config: &p.Config}.Construct(&provider))
+}
+
+// ProviderOf returns the provider a resource is synchronised with.
+func ProviderOf(resource client.Object) (common.NamespacedName, error) {
+	switch r := resource.(type) {
+	case *pipelineshub.Pipeline:
+		return r.Spec.Provider, nil
+	case *pipelineshub.Run:
+		return r.Spec.Provider, nil
+	case *pipelineshub.RunSchedule:
+		return r.Spec.Provider, nil
+	case *pipelineshub.Experiment:
+		return r.Spec.Provider, nil
+	case *pipelineshub.RunConfiguration:
+		return r.Spec.Run.Provider, nil
+	default:
+		return common.NamespacedName{}, fmt.Errorf("resources of type %T cannot be planned", resource)
+	}
+}
+
+// Plan renders the plan of a resource. The pipeline version that a Run or a
+// RunConfiguration depends on must have been resolved into its status.
+func (p Planner) Plan(
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource client.Object,
+) (any, error) {
+	switch r := resource.(type) {
+	case *pipelineshub.Pipeline:
+		return workflowfactory.PipelineWorkflowFactory(p.Config).ConstructPlan(provider, providerSvc, r)
+	case *pipelineshub.Run:
+		return workflowfactory.RunWorkflowFactory(p.Config).ConstructPlan(provider, providerSvc, r)
+	case *pipelineshub.RunSchedule:
+		return workflowfactory.RunScheduleWorkflowFactory(p.Config).ConstructPlan(provider, providerSvc, r)
+	case *pipelineshub.Experiment:
+		return workflowfactory.ExperimentWorkflowFactory(p.Config).ConstructPlan(provider, providerSvc, r)
+	case *pipelineshub.RunConfiguration:
+		return p.PlanRunConfiguration(provider, providerSvc, r, nil)
+	default:
+		return nil, fmt.Errorf("resources of type %T cannot be planned", resource)
+	}
+}
+
+// PlanRunConfiguration renders the RunSchedules that would be created in
+// place of the existing ones and the Run that would be created when the
+// RunConfiguration is triggered by a change.
+func (p Planner) PlanRunConfiguration(
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	runConfiguration *pipelineshub.RunConfiguration,
+	existingSchedules []pipelineshub.RunSchedule,
+) (*RunConfigurationPlan, error) {
+	rcr := RunConfigurationReconciler{Scheme: p.Scheme, Config: p.Config}
+
+	resolvedParameters, _, err := runConfiguration.Spec.Run.ResolveParameters(runConfiguration.Status.Dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredSchedules, err := rcr.constructRunSchedulesForTriggers(runConfiguration, resolvedParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RunConfigurationPlan{}
+
+	runScheduleFactory := workflowfactory.RunScheduleWorkflowFactory(p.Config)
+	for _, runSchedule := range apis.SliceDiff(desiredSchedules, existingSchedules, compareRunSchedules) {
+		runSchedulePlan, err := runScheduleFactory.ConstructPlan(provider, providerSvc, withGeneratedName(&runSchedule))
+		if err != nil {
+			return nil, err
+		}
+		plan.RunSchedules = append(plan.RunSchedules, *runSchedulePlan)
+	}
+
+	for _, runSchedule := range apis.SliceDiff(existingSchedules, desiredSchedules, compareRunSchedules) {
+		plan.DeletedRunSchedules = append(plan.DeletedRunSchedules, runSchedule.Name)
+	}
+
+	if len(runConfiguration.Spec.Triggers.OnChange) > 0 || len(runConfiguration.Spec.Triggers.RunConfigurations) > 0 {
+		run, err := rcr.constructRunForRunConfiguration(runConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		run.Status.Dependencies = runConfiguration.Status.Dependencies
+
+		plan.Run, err = workflowfactory.RunWorkflowFactory(p.Config).ConstructPlan(provider, providerSvc, withGeneratedName(run))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,151 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Planner", func() {
+	var (
+		scheme   *runtime.Scheme
+		provider *pipelineshub.Provider
+		planner  Planner
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+		provider = pipelineshub.RandomProvider()
+		planner = Planner{Scheme: scheme}
+	})
+
+	runConfiguration := func() *pipelineshub.RunConfiguration {
+		rc := pipelineshub.RandomRunConfiguration(provider.GetCommonNamespacedName())
+		rc.Spec.Triggers = pipelineshub.Triggers{
+			Schedules: []pipelineshub.Schedule{pipelineshub.RandomSchedule(), pipelineshub.RandomSchedule()},
+			OnChange:  []pipelineshub.OnChangeType{pipelineshub.OnChangeTypes.Pipeline},
+		}
+		rc.Status.Dependencies.Pipeline.Version = "pipeline-version"
+		return rc
+	}
+
+	It("plans the run schedules and the run of a run configuration", func() {
+		rc := runConfiguration()
+
+		plan, err := planner.PlanRunConfiguration(*provider, *RandomProviderService(), rc, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.RunSchedules).To(HaveLen(2))
+		Expect(plan.DeletedRunSchedules).To(BeEmpty())
+		Expect(plan.Run).NotTo(BeNil())
+		Expect(plan.Run.ResourceDefinition).To(ContainSubstring(`"pipelineVersion":"pipeline-version"`))
+	})
+
+	It("only plans run schedules that change", func() {
+		rc := runConfiguration()
+		rc.Spec.Triggers.OnChange = nil
+
+		rcr := RunConfigurationReconciler{Scheme: scheme}
+		resolvedParameters, _, err := rc.Spec.Run.ResolveParameters(rc.Status.Dependencies)
+		Expect(err).NotTo(HaveOccurred())
+		existing, err := rcr.constructRunSchedulesForTriggers(rc, resolvedParameters)
+		Expect(err).NotTo(HaveOccurred())
+		existing[1].Name = "outdated"
+		existing[1].Spec.Schedule = pipelineshub.RandomSchedule()
+
+		plan, err := planner.PlanRunConfiguration(*provider, *RandomProviderService(), rc, existing)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.RunSchedules).To(HaveLen(1))
+		Expect(plan.DeletedRunSchedules).To(ConsistOf("outdated"))
+		Expect(plan.Run).To(BeNil())
+	})
+
+	It("rejects resources that cannot be planned", func() {
+		_, err := planner.Plan(*provider, *RandomProviderService(), provider)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("StorePlan", func() {
+	ctx := context.Background()
+
+	var (
+		k8sClient client.Client
+		ec        K8sExecutionContext
+		pipeline  *pipelineshub.Pipeline
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+		pipeline = pipelineshub.RandomPipeline(common.RandomNamespacedName())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).Build()
+		ec = K8sExecutionContext{
+			Client:   controllers.OptInClient{Writer: k8sClient, StatusClient: k8sClient, Cached: k8sClient, NonCached: k8sClient},
+			Recorder: record.NewFakeRecorder(10),
+			Scheme:   scheme,
+		}
+	})
+
+	storedConfigMap := func() *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{
+			Namespace: pipeline.Namespace,
+			Name:      PlanConfigMapName(pipeline.GetKind(), pipeline.Name),
+		}, configMap)).To(Succeed())
+		return configMap
+	}
+
+	It("stores the plan in a config map owned by the resource", func() {
+		plan := &Plan{WorkflowTemplate: "create-compiled", Parameters: map[string]string{"a": "b"}}
+
+		Expect(StorePlan{Plan: plan}.execute(ctx, ec, pipeline)).To(Succeed())
+
+		configMap := storedConfigMap()
+		expected, err := json.Marshal(plan)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data[PlanConstants.PlanKey]).To(MatchJSON(expected))
+		Expect(configMap.OwnerReferences).To(HaveLen(1))
+		Expect(configMap.OwnerReferences[0].Name).To(Equal(pipeline.Name))
+		Expect(ec.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(EventReasons.Planned)))
+	})
+
+	It("replaces the plan with the error when the resource cannot be planned", func() {
+		Expect(StorePlan{Plan: &Plan{}}.execute(ctx, ec, pipeline)).To(Succeed())
+		Expect(StorePlan{Error: "an error occurred"}.execute(ctx, ec, pipeline)).To(Succeed())
+
+		Expect(storedConfigMap().Data).To(Equal(map[string]string{
+			PlanConstants.ErrorKey:      "an error occurred",
+			PlanConstants.GenerationKey: strconv.FormatInt(pipeline.Generation, 10),
+		}))
+	})
+
+	It("marks the plan with the generation of the resource", func() {
+		Expect(StorePlan{Plan: &Plan{}}.execute(ctx, ec, pipeline)).To(Succeed())
+
+		pipeline.Generation++
+		Expect(StorePlan{Plan: &Plan{}}.execute(ctx, ec, pipeline)).To(Succeed())
+
+		Expect(storedConfigMap().Data).To(HaveKeyWithValue(PlanConstants.GenerationKey, strconv.FormatInt(pipeline.Generation, 10)))
+	})
+})
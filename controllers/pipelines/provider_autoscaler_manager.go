@@ -0,0 +1,168 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const defaultTargetCPUUtilizationPercentage = int32(80)
+
+type AutoscalerResourceManager interface {
+	Create(ctx context.Context, new *autoscalingv2.HorizontalPodAutoscaler, owner *pipelineshub.Provider) error
+	Update(ctx context.Context, old, new *autoscalingv2.HorizontalPodAutoscaler, owner *pipelineshub.Provider) error
+	Delete(ctx context.Context, old *autoscalingv2.HorizontalPodAutoscaler) error
+	Get(ctx context.Context, owner *pipelineshub.Provider) (*autoscalingv2.HorizontalPodAutoscaler, error)
+	Equal(a, b *autoscalingv2.HorizontalPodAutoscaler) bool
+	// Construct returns nil when the provider is not autoscaled.
+	Construct(provider *pipelineshub.Provider, deployment *appsv1.Deployment) *autoscalingv2.HorizontalPodAutoscaler
+}
+
+type AutoscalerManager struct {
+	client *controllers.OptInClient
+	scheme *runtime.Scheme
+	config *config.ConfigSpec
+}
+
+// providerAutoscaling returns the autoscaling of a provider, falling back to
+// the operator's defaults.
+func providerAutoscaling(provider *pipelineshub.Provider, config *config.ConfigSpec) *pipelineshub.ProviderAutoscaling {
+	if provider.Spec.Autoscaling != nil {
+		return provider.Spec.Autoscaling
+	}
+
+	return config.DefaultProviderValues.Autoscaling
+}
+
+func (am AutoscalerManager) Create(ctx context.Context, new *autoscalingv2.HorizontalPodAutoscaler, owner *pipelineshub.Provider) error {
+	logger := log.FromContext(ctx)
+
+	if err := ctrl.SetControllerReference(owner, new, am.scheme); err != nil {
+		logger.Error(err, "unable to set controller reference on autoscaler", "autoscaler", new.Name)
+		return err
+	}
+
+	if err := am.client.Create(ctx, new); err != nil {
+		logger.Error(err, "unable to create provider autoscaler")
+		return err
+	}
+	return nil
+}
+
+func (am AutoscalerManager) Update(ctx context.Context, old, new *autoscalingv2.HorizontalPodAutoscaler, owner *pipelineshub.Provider) error {
+	logger := log.FromContext(ctx)
+
+	old.Spec = new.Spec
+	old.SetLabels(new.Labels)
+
+	if err := ctrl.SetControllerReference(owner, old, am.scheme); err != nil {
+		logger.Error(err, "unable to set controller reference on autoscaler", "autoscaler", old.Name)
+		return err
+	}
+
+	if err := am.client.Update(ctx, old); err != nil {
+		logger.Error(err, "unable to update provider autoscaler", "autoscaler", old.Name)
+		return err
+	}
+	return nil
+}
+
+func (am AutoscalerManager) Delete(ctx context.Context, old *autoscalingv2.HorizontalPodAutoscaler) error {
+	logger := log.FromContext(ctx)
+
+	if err := am.client.Delete(ctx, old); err != nil {
+		logger.Error(err, "unable to delete provider autoscaler", "autoscaler", old.Name)
+		return err
+	}
+	return nil
+}
+
+func (am AutoscalerManager) Get(ctx context.Context, owner *pipelineshub.Provider) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hl := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := am.client.NonCached.List(ctx, hl, &client.ListOptions{
+		Namespace: owner.Namespace,
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, hpa := range hl.Items {
+		if metav1.IsControlledBy(&hpa, owner) {
+			return &hpa, nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+}
+
+// Equal compares the fields the operator sets, as the API server defaults
+// the scaling behaviour.
+func (am AutoscalerManager) Equal(a, b *autoscalingv2.HorizontalPodAutoscaler) bool {
+	return a.GenerateName == b.GenerateName &&
+		a.Namespace == b.Namespace &&
+		a.Spec.ScaleTargetRef == b.Spec.ScaleTargetRef &&
+		equality.Semantic.DeepEqual(a.Spec.MinReplicas, b.Spec.MinReplicas) &&
+		a.Spec.MaxReplicas == b.Spec.MaxReplicas &&
+		equality.Semantic.DeepEqual(a.Spec.Metrics, b.Spec.Metrics)
+}
+
+func (am AutoscalerManager) Construct(provider *pipelineshub.Provider, deployment *appsv1.Deployment) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := providerAutoscaling(provider, am.config)
+	if autoscaling == nil {
+		return nil
+	}
+
+	prefixedProviderName := fmt.Sprintf("provider-%s", provider.Name)
+	labels := lo.Assign(am.config.DefaultProviderValues.Labels, map[string]string{AppLabel: prefixedProviderName})
+
+	minReplicas := int32(1)
+	if autoscaling.MinReplicas != nil {
+		minReplicas = *autoscaling.MinReplicas
+	}
+
+	targetCPUUtilization := defaultTargetCPUUtilizationPercentage
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		targetCPUUtilization = *autoscaling.TargetCPUUtilizationPercentage
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-", prefixedProviderName),
+			Namespace:    provider.Namespace,
+			Labels:       labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       deployment.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: &targetCPUUtilization,
+					},
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/testutil"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8Scheme "k8s.io/client-go/kubernetes/scheme"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Context("Provider Autoscaler Manager", func() {
+
+	var (
+		ctx               = context.Background()
+		provider          *pipelineshub.Provider
+		deployment        *appsv1.Deployment
+		client            k8sClient.Client
+		autoscalerManager AutoscalerManager
+	)
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "provider-deployment", Namespace: provider.Namespace}}
+
+		client = fake.NewClientBuilder().
+			WithScheme(testutil.SchemeWithCrds()).
+			WithScheme(k8Scheme.Scheme).
+			Build()
+
+		autoscalerManager = AutoscalerManager{
+			client: &controllers.OptInClient{
+				Writer:       client,
+				StatusClient: client,
+				Cached:       client,
+				NonCached:    client,
+			},
+			scheme: client.Scheme(),
+			config: &config.ConfigSpec{},
+		}
+	})
+
+	var _ = Describe("Construct", func() {
+
+		Specify("Should target the provider deployment", func() {
+			provider.Spec.Autoscaling = &pipelineshub.ProviderAutoscaling{
+				MinReplicas:                    &[]int32{2}[0],
+				MaxReplicas:                    5,
+				TargetCPUUtilizationPercentage: &[]int32{60}[0],
+			}
+
+			hpa := autoscalerManager.Construct(provider, deployment)
+
+			Expect(hpa.GenerateName).To(Equal(fmt.Sprintf("provider-%s-", provider.Name)))
+			Expect(hpa.Namespace).To(Equal(provider.Namespace))
+			Expect(hpa.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment.Name,
+			}))
+			Expect(*hpa.Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(hpa.Spec.MaxReplicas).To(Equal(int32(5)))
+			Expect(*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(int32(60)))
+		})
+
+		Specify("Should fall back to the operator defaults", func() {
+			provider.Spec.Autoscaling = nil
+			autoscalerManager.config.DefaultProviderValues.Autoscaling = &pipelineshub.ProviderAutoscaling{MaxReplicas: 3}
+
+			hpa := autoscalerManager.Construct(provider, deployment)
+
+			Expect(*hpa.Spec.MinReplicas).To(Equal(int32(1)))
+			Expect(hpa.Spec.MaxReplicas).To(Equal(int32(3)))
+			Expect(*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(defaultTargetCPUUtilizationPercentage))
+		})
+
+		Specify("Should return nil if the provider is not autoscaled", func() {
+			provider.Spec.Autoscaling = nil
+
+			Expect(autoscalerManager.Construct(provider, deployment)).To(BeNil())
+		})
+	})
+
+	var _ = Describe("Lifecycle", func() {
+
+		Specify("Should create, get, update and delete the autoscaler owned by the provider", func() {
+			_, err := autoscalerManager.Get(ctx, provider)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			hpa := autoscalerManager.Construct(provider, deployment)
+			hpa.Name = "provider-hpa"
+			Expect(autoscalerManager.Create(ctx, hpa, provider)).To(Succeed())
+
+			existing, err := autoscalerManager.Get(ctx, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(autoscalerManager.Equal(existing, hpa)).To(BeTrue())
+
+			provider.Spec.Autoscaling.MaxReplicas += 1
+			desired := autoscalerManager.Construct(provider, deployment)
+			Expect(autoscalerManager.Equal(existing, desired)).To(BeFalse())
+			Expect(autoscalerManager.Update(ctx, existing, desired, provider)).To(Succeed())
+
+			existing, err = autoscalerManager.Get(ctx, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(existing.Spec.MaxReplicas).To(Equal(provider.Spec.Autoscaling.MaxReplicas))
+
+			Expect(autoscalerManager.Delete(ctx, existing)).To(Succeed())
+			_, err = autoscalerManager.Get(ctx, provider)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
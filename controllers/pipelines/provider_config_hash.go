@@ -0,0 +1,198 @@
+package pipelines
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ConfigHashAnnotation holds a hash of the contents of the Secrets and
+// ConfigMaps that the provider-service pods reference, so that their
+// deployment rolls out when any of them change.
+const ConfigHashAnnotation = apis.Group + "/config-hash"
+
+// configReferences are the names of the Secrets and ConfigMaps a pod
+// template references.
+type configReferences struct {
+	Secrets    []string
+	ConfigMaps []string
+}
+
+func (cr configReferences) Empty() bool {
+	return len(cr.Secrets) == 0 && len(cr.ConfigMaps) == 0
+}
+
+// podTemplateReferences collects the Secrets and ConfigMaps that the volumes
+// and the environment of a pod template reference.
+func podTemplateReferences(podTemplate corev1.PodTemplateSpec) configReferences {
+	secrets := map[string]struct{}{}
+	configMaps := map[string]struct{}{}
+
+	for _, volume := range podTemplate.Spec.Volumes {
+		if volume.Secret != nil {
+			secrets[volume.Secret.SecretName] = struct{}{}
+		}
+		if volume.ConfigMap != nil {
+			configMaps[volume.ConfigMap.Name] = struct{}{}
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					secrets[source.Secret.Name] = struct{}{}
+				}
+				if source.ConfigMap != nil {
+					configMaps[source.ConfigMap.Name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	for _, container := range slices.Concat(podTemplate.Spec.InitContainers, podTemplate.Spec.Containers) {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				secrets[env.ValueFrom.SecretKeyRef.Name] = struct{}{}
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps[env.ValueFrom.ConfigMapKeyRef.Name] = struct{}{}
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				secrets[envFrom.SecretRef.Name] = struct{}{}
+			}
+			if envFrom.ConfigMapRef != nil {
+				configMaps[envFrom.ConfigMapRef.Name] = struct{}{}
+			}
+		}
+	}
+
+	return configReferences{
+		Secrets:    sortedKeys(secrets),
+		ConfigMaps: sortedKeys(configMaps),
+	}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeData hashes data in the order of its keys.
+func writeData(hash func(...string), data map[string][]byte) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		hash(key, string(data[key]))
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// configHash hashes the contents of the referenced Secrets and ConfigMaps.
+// Objects that do not exist are hashed as such, so that their creation rolls
+// out the deployment as well. Contents are read from the API server to avoid
+// caching Secrets.
+func configHash(ctx context.Context, reader client.Reader, namespace string, references configReferences) (string, error) {
+	hasher := sha256.New()
+	hash := func(values ...string) {
+		for _, value := range values {
+			hasher.Write([]byte(value))
+			hasher.Write([]byte{0})
+		}
+	}
+
+	for _, name := range references.Secrets {
+		hash("secret", name)
+
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", err
+			}
+			hash("missing")
+			continue
+		}
+
+		writeData(hash, secret.Data)
+	}
+
+	for _, name := range references.ConfigMaps {
+		hash("configmap", name)
+
+		configMap := &corev1.ConfigMap{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", err
+			}
+			hash("missing")
+			continue
+		}
+
+		data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+		for key, value := range configMap.Data {
+			data[key] = []byte(value)
+		}
+		for key, value := range configMap.BinaryData {
+			data["binary/"+key] = value
+		}
+		writeData(hash, data)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// providersReferencing maps Secrets or ConfigMaps, as selected by
+// referenced, to the providers in the same namespace whose provider-service
+// pods reference them. Only the metadata of the objects is watched.
+func providersReferencing(
+	reader client.Reader,
+	config *config.ConfigSpec,
+	referenced func(configReferences) []string,
+) func(ctx context.Context, object client.Object) []reconcile.Request {
+	return func(ctx context.Context, object client.Object) []reconcile.Request {
+		logger := log.FromContext(ctx)
+
+		providers := &pipelineshub.ProviderList{}
+		if err := reader.List(ctx, providers, client.InNamespace(object.GetNamespace())); err != nil {
+			logger.Error(err, "unable to list providers", "namespace", object.GetNamespace())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, provider := range providers.Items {
+			podTemplate, err := providerPodTemplate(config, &provider)
+			if err != nil {
+				continue
+			}
+
+			if slices.Contains(referenced(podTemplateReferences(podTemplate)), object.GetName()) {
+				requests = append(requests, reconcile.Request{NamespacedName: provider.GetNamespacedName()})
+			}
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,151 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Provider config hash", func() {
+	ctx := context.Background()
+
+	var (
+		scheme    *runtime.Scheme
+		k8sClient client.Client
+		provider  *pipelineshub.Provider
+		secret    *corev1.Secret
+		cfg       *config.ConfigSpec
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+		provider = pipelineshub.RandomProvider()
+		provider.Spec.PodTemplateVolumes = []corev1.Volume{{
+			Name: "credentials",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "credentials"},
+			},
+		}}
+		provider.Spec.PodTemplateEnv = []corev1.EnvVar{{
+			Name: "SETTING",
+			ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+					Key:                  "setting",
+				},
+			},
+		}}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: provider.Namespace},
+			Data:       map[string][]byte{"token": []byte("a")},
+		}
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(provider, secret).Build()
+
+		cfg = &config.ConfigSpec{
+			DefaultProviderValues: config.DefaultProviderValues{
+				ServiceContainerName: "provider-service",
+				PodTemplateSpec: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name: "provider-service",
+							EnvFrom: []corev1.EnvFromSource{{
+								SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "defaults"}},
+							}},
+						}},
+					},
+				},
+			},
+		}
+	})
+
+	deploymentManager := func() DeploymentManager {
+		return DeploymentManager{
+			client: &controllers.OptInClient{Writer: k8sClient, StatusClient: k8sClient, Cached: k8sClient, NonCached: k8sClient},
+			scheme: scheme,
+			config: cfg,
+		}
+	}
+
+	It("collects the Secrets and ConfigMaps referenced by the operator defaults and the provider", func() {
+		podTemplate, err := providerPodTemplate(cfg, provider)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(podTemplateReferences(podTemplate)).To(Equal(configReferences{
+			Secrets:    []string{"credentials", "defaults"},
+			ConfigMaps: []string{"settings"},
+		}))
+	})
+
+	It("changes the config hash of the deployment when a referenced Secret changes", func() {
+		deployment, err := deploymentManager().Construct(ctx, provider)
+		Expect(err).NotTo(HaveOccurred())
+		hash := deployment.Spec.Template.Annotations[ConfigHashAnnotation]
+		Expect(hash).NotTo(BeEmpty())
+
+		unchanged, err := deploymentManager().Construct(ctx, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deploymentManager().Equal(deployment, unchanged)).To(BeTrue())
+
+		secret.Data["token"] = []byte("b")
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+		changed, err := deploymentManager().Construct(ctx, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed.Spec.Template.Annotations[ConfigHashAnnotation]).NotTo(Equal(hash))
+		Expect(deploymentManager().Equal(deployment, changed)).To(BeFalse())
+	})
+
+	It("changes the config hash when a referenced ConfigMap is created", func() {
+		deployment, err := deploymentManager().Construct(ctx, provider)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: provider.Namespace},
+			Data:       map[string]string{"setting": "value"},
+		})).To(Succeed())
+
+		changed, err := deploymentManager().Construct(ctx, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed.Spec.Template.Annotations[ConfigHashAnnotation]).NotTo(Equal(deployment.Spec.Template.Annotations[ConfigHashAnnotation]))
+	})
+
+	It("does not annotate deployments without references", func() {
+		provider.Spec.PodTemplateVolumes = nil
+		provider.Spec.PodTemplateEnv = nil
+		cfg.DefaultProviderValues.PodTemplateSpec.Spec.Containers[0].EnvFrom = nil
+
+		deployment, err := deploymentManager().Construct(ctx, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deployment.Spec.Template.Annotations).NotTo(HaveKey(ConfigHashAnnotation))
+	})
+
+	It("maps Secrets and ConfigMaps to the providers that reference them", func() {
+		request := reconcile.Request{NamespacedName: provider.GetNamespacedName()}
+
+		Expect(providersReferencing(k8sClient, cfg, func(cr configReferences) []string { return cr.Secrets })(ctx, secret)).To(ConsistOf(request))
+		Expect(providersReferencing(k8sClient, cfg, func(cr configReferences) []string { return cr.ConfigMaps })(ctx, &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: provider.Namespace},
+		})).To(ConsistOf(request))
+		Expect(providersReferencing(k8sClient, cfg, func(cr configReferences) []string { return cr.ConfigMaps })(ctx, &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: provider.Namespace},
+		})).To(BeEmpty())
+	})
+})
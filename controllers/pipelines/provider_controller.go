@@ -13,10 +13,13 @@ import (
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/logkeys"
 	"github.com/sky-uk/kfp-operator/internal/config"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -29,7 +32,13 @@ type ProviderReconciler struct {
 	ProviderLoader    ProviderLoader
 	DeploymentManager DeploymentResourceManager
 	ServiceManager    ServiceResourceManager
-	StatusManager     ProviderStatusManager
+	AutoscalerManager AutoscalerResourceManager
+	// DisruptionBudgetManager manages the PodDisruptionBudget of the
+	// provider-service pods.
+	DisruptionBudgetManager DisruptionBudgetResourceManager
+	StatusManager           ProviderStatusManager
+	Scope                   Scope
+	Config                  config.ConfigSpec
 }
 
 func NewProviderReconciler(ec K8sExecutionContext, config config.ConfigSpec) *ProviderReconciler {
@@ -48,9 +57,21 @@ func NewProviderReconciler(ec K8sExecutionContext, config config.ConfigSpec) *Pr
 			client: &ec.Client,
 			config: &config,
 		},
+		AutoscalerManager: AutoscalerManager{
+			scheme: ec.Scheme,
+			client: &ec.Client,
+			config: &config,
+		},
+		DisruptionBudgetManager: DisruptionBudgetManager{
+			scheme: ec.Scheme,
+			client: &ec.Client,
+			config: &config,
+		},
 		StatusManager: StatusManager{
 			client: &ec.Client,
 		},
+		Scope:  ec.Scope,
+		Config: config,
 	}
 }
 
@@ -59,12 +80,34 @@ func (r *ProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(provider, builder.WithPredicates(
 			predicate.GenerationChangedPredicate{},
+			r.Scope.Predicate(),
 		)).
 		Owns(&appsv1.Deployment{}, builder.WithPredicates(
 			predicate.GenerationChangedPredicate{},
 			predicate.ResourceVersionChangedPredicate{},
+			r.Scope.NamespacedPredicate(),
+		)).
+		Owns(&v1.Service{}, builder.WithPredicates(r.Scope.NamespacedPredicate())).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			r.Scope.NamespacedPredicate(),
 		)).
-		Owns(&v1.Service{}).
+		Owns(&policyv1.PodDisruptionBudget{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			r.Scope.NamespacedPredicate(),
+		)).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(providersReferencing(mgr.GetClient(), &r.Config, func(cr configReferences) []string { return cr.Secrets })),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, r.Scope.NamespacedPredicate()),
+		).
+		Watches(
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(providersReferencing(mgr.GetClient(), &r.Config, func(cr configReferences) []string { return cr.ConfigMaps })),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, r.Scope.NamespacedPredicate()),
+		).
 		Complete(r)
 }
 
@@ -87,6 +130,11 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !r.Scope.Contains(ctx, &provider) {
+		logger.V(2).Info("ignoring provider outside of the scope")
+		return ctrl.Result{}, nil
+	}
+
 	providerOutOfSync := provider.Status.ObservedGeneration != provider.Generation
 
 	existingDeployment, err := r.DeploymentManager.Get(ctx, &provider)
@@ -95,14 +143,17 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
-	desiredDeployment, err := r.DeploymentManager.Construct(&provider)
+	desiredDeployment, err := r.DeploymentManager.Construct(ctx, &provider)
 	if err != nil {
 		logger.Error(err, "unable to construct provider deployment", "provider", provider.GetNamespacedName())
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, err
 	}
 
+	deployment := existingDeployment
 	if providerOutOfSync || existingDeployment == nil || !r.DeploymentManager.Equal(existingDeployment, desiredDeployment) {
 		if existingDeployment == nil {
+			deployment = desiredDeployment
+
 			r.StatusManager.UpdateProviderStatus(ctx, &provider, apis.Creating, "")
 
 			if err := r.DeploymentManager.Create(ctx, desiredDeployment, &provider); err != nil {
@@ -158,6 +209,20 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
+	if err := r.reconcileAutoscaler(ctx, &provider, deployment, providerOutOfSync); err != nil {
+		if statusError := r.StatusManager.UpdateProviderStatus(ctx, &provider, apis.Failed, "Failed to reconcile subresource autoscaler"); statusError != nil {
+			err = errors.Join(err, statusError)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileDisruptionBudget(ctx, &provider, providerOutOfSync); err != nil {
+		if statusError := r.StatusManager.UpdateProviderStatus(ctx, &provider, apis.Failed, "Failed to reconcile subresource disruption budget"); statusError != nil {
+			err = errors.Join(err, statusError)
+		}
+		return ctrl.Result{}, err
+	}
+
 	if err := r.StatusManager.UpdateProviderStatus(ctx, &provider, apis.Succeeded, ""); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -167,3 +232,57 @@ func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	return ctrl.Result{}, nil
 }
+
+// reconcileAutoscaler creates, updates or deletes the autoscaler of the
+// provider deployment depending on whether the provider is autoscaled.
+func (r *ProviderReconciler) reconcileAutoscaler(ctx context.Context, provider *pipelineshub.Provider, deployment *appsv1.Deployment, providerOutOfSync bool) error {
+	existing, err := r.AutoscalerManager.Get(ctx, provider)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	desired := r.AutoscalerManager.Construct(provider, deployment)
+
+	switch {
+	case desired == nil && existing == nil:
+		return nil
+	case desired == nil:
+		r.StatusManager.UpdateProviderStatus(ctx, provider, apis.Updating, "")
+		return r.AutoscalerManager.Delete(ctx, existing)
+	case existing == nil:
+		r.StatusManager.UpdateProviderStatus(ctx, provider, apis.Creating, "")
+		return r.AutoscalerManager.Create(ctx, desired, provider)
+	case providerOutOfSync || !r.AutoscalerManager.Equal(existing, desired):
+		r.StatusManager.UpdateProviderStatus(ctx, provider, apis.Updating, "")
+		return r.AutoscalerManager.Update(ctx, existing, desired, provider)
+	default:
+		return nil
+	}
+}
+
+// reconcileDisruptionBudget creates, updates or deletes the disruption budget
+// of the provider pods depending on whether the provider declares one.
+func (r *ProviderReconciler) reconcileDisruptionBudget(ctx context.Context, provider *pipelineshub.Provider, providerOutOfSync bool) error {
+	existing, err := r.DisruptionBudgetManager.Get(ctx, provider)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	desired := r.DisruptionBudgetManager.Construct(provider)
+
+	switch {
+	case desired == nil && existing == nil:
+		return nil
+	case desired == nil:
+		r.StatusManager.UpdateProviderStatus(ctx, provider, apis.Updating, "")
+		return r.DisruptionBudgetManager.Delete(ctx, existing)
+	case existing == nil:
+		r.StatusManager.UpdateProviderStatus(ctx, provider, apis.Creating, "")
+		return r.DisruptionBudgetManager.Create(ctx, desired, provider)
+	case providerOutOfSync || !r.DisruptionBudgetManager.Equal(existing, desired):
+		r.StatusManager.UpdateProviderStatus(ctx, provider, apis.Updating, "")
+		return r.DisruptionBudgetManager.Update(ctx, existing, desired, provider)
+	default:
+		return nil
+	}
+}
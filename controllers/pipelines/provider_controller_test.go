@@ -12,7 +12,9 @@ import (
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/mocks"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -32,6 +34,8 @@ var _ = Context("Provider Controller", func() {
 		mockProviderLoader *mocks.MockProviderLoader
 		mockDeploymentMan  *mocks.MockDeploymentManager
 		mockServiceMan     *mocks.MockServiceManager
+		mockAutoscalerMan  *mocks.MockAutoscalerManager
+		mockPdbMan         *mocks.MockDisruptionBudgetManager
 		mockStatusMan      *mocks.MockStatusManager
 	)
 
@@ -48,16 +52,71 @@ var _ = Context("Provider Controller", func() {
 		mockServiceMan = &mocks.MockServiceManager{}
 		mockStatusMan = &mocks.MockStatusManager{}
 
+		mockAutoscalerMan = &mocks.MockAutoscalerManager{}
+		mockAutoscalerMan.
+			On("Get", provider).Return(nil, errors.NewNotFound(schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"}, "hpa")).
+			On("Construct", provider, mock.Anything).Return(nil)
+
+		mockPdbMan = &mocks.MockDisruptionBudgetManager{}
+		mockPdbMan.
+			On("Get", provider).Return(nil, errors.NewNotFound(schema.GroupResource{Group: "policy", Resource: "poddisruptionbudgets"}, "pdb")).
+			On("Construct", provider).Return(nil)
+
 		providerReconciler = ProviderReconciler{
-			ProviderLoader:    mockProviderLoader,
-			DeploymentManager: mockDeploymentMan,
-			ServiceManager:    mockServiceMan,
-			StatusManager:     mockStatusMan,
+			ProviderLoader:          mockProviderLoader,
+			DeploymentManager:       mockDeploymentMan,
+			ServiceManager:          mockServiceMan,
+			AutoscalerManager:       mockAutoscalerMan,
+			DisruptionBudgetManager: mockPdbMan,
+			StatusManager:           mockStatusMan,
 		}
 	})
 
+	reconcileWithDeploymentAndService := func() error {
+		mockProviderLoader.On("LoadProvider", mock.Anything, mock.Anything).Return(*provider, nil)
+
+		mockDeploymentMan.
+			On("Get", provider).Return(deployment, nil).
+			On("Construct", provider).Return(deployment, nil).
+			On("Equal", deployment, deployment).Return(true)
+
+		mockServiceMan.
+			On("Get", provider).Return(service, nil).
+			On("Construct", provider).Return(service).
+			On("Equal", service, service).Return(true)
+
+		mockStatusMan.On("UpdateProviderStatus", provider, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := providerReconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: provider.Namespace,
+				Name:      provider.Name,
+			},
+		})
+		return err
+	}
+
 	var _ = Describe("Reconcile", func() {
 
+		Specify("Should return the error when the Deployment cannot be constructed", func() {
+			mockProviderLoader.On("LoadProvider", mock.Anything, mock.Anything).Return(*provider, nil)
+
+			constructionError := errors.NewBadRequest("invalid pod template")
+			mockDeploymentMan.
+				On("Get", provider).Return(deployment, nil).
+				On("Construct", provider).Return(nil, constructionError)
+
+			_, err := providerReconciler.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: provider.Namespace,
+					Name:      provider.Name,
+				},
+			})
+
+			Expect(err).To(MatchError(constructionError))
+			mockDeploymentMan.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+
 		Specify("Should Create a Deployment and Service if they do not currently exist", func() {
 			mockProviderLoader.On("LoadProvider", mock.Anything, mock.Anything).Return(*provider, nil)
 
@@ -245,4 +304,96 @@ var _ = Context("Provider Controller", func() {
 			mockStatusMan.AssertCalled(GinkgoT(), "UpdateProviderStatus", provider, apis.Failed, "Failed to reconcile subresource deployment")
 		})
 	})
+
+	var _ = Describe("Reconcile autoscaler and disruption budget", func() {
+		var (
+			hpa = &autoscalingv2.HorizontalPodAutoscaler{}
+			pdb = &policyv1.PodDisruptionBudget{}
+		)
+
+		BeforeEach(func() {
+			mockAutoscalerMan = &mocks.MockAutoscalerManager{}
+			mockPdbMan = &mocks.MockDisruptionBudgetManager{}
+			providerReconciler.AutoscalerManager = mockAutoscalerMan
+			providerReconciler.DisruptionBudgetManager = mockPdbMan
+		})
+
+		Specify("Should create an autoscaler and a disruption budget if they are declared", func() {
+			mockAutoscalerMan.
+				On("Get", provider).Return(nil, errors.NewNotFound(schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"}, "hpa")).
+				On("Construct", provider, deployment).Return(hpa).
+				On("Create", hpa, provider).Return(nil)
+			mockPdbMan.
+				On("Get", provider).Return(nil, errors.NewNotFound(schema.GroupResource{Group: "policy", Resource: "poddisruptionbudgets"}, "pdb")).
+				On("Construct", provider).Return(pdb).
+				On("Create", pdb, provider).Return(nil)
+
+			Expect(reconcileWithDeploymentAndService()).To(Succeed())
+
+			mockAutoscalerMan.AssertCalled(GinkgoT(), "Create", hpa, provider)
+			mockPdbMan.AssertCalled(GinkgoT(), "Create", pdb, provider)
+			mockStatusMan.AssertCalled(GinkgoT(), "UpdateProviderStatus", provider, apis.Succeeded, "")
+		})
+
+		Specify("Should update an autoscaler and a disruption budget if they are out of sync", func() {
+			mockAutoscalerMan.
+				On("Get", provider).Return(hpa, nil).
+				On("Construct", provider, deployment).Return(hpa).
+				On("Equal", hpa, hpa).Return(false).
+				On("Update", hpa, hpa, provider).Return(nil)
+			mockPdbMan.
+				On("Get", provider).Return(pdb, nil).
+				On("Construct", provider).Return(pdb).
+				On("Equal", pdb, pdb).Return(false).
+				On("Update", pdb, pdb, provider).Return(nil)
+
+			Expect(reconcileWithDeploymentAndService()).To(Succeed())
+
+			mockAutoscalerMan.AssertCalled(GinkgoT(), "Update", hpa, hpa, provider)
+			mockPdbMan.AssertCalled(GinkgoT(), "Update", pdb, pdb, provider)
+		})
+
+		Specify("Should not update an autoscaler and a disruption budget that are in sync", func() {
+			mockAutoscalerMan.
+				On("Get", provider).Return(hpa, nil).
+				On("Construct", provider, deployment).Return(hpa).
+				On("Equal", hpa, hpa).Return(true)
+			mockPdbMan.
+				On("Get", provider).Return(pdb, nil).
+				On("Construct", provider).Return(pdb).
+				On("Equal", pdb, pdb).Return(true)
+
+			Expect(reconcileWithDeploymentAndService()).To(Succeed())
+
+			mockAutoscalerMan.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything, mock.Anything)
+			mockPdbMan.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		Specify("Should delete an autoscaler and a disruption budget that are no longer declared", func() {
+			mockAutoscalerMan.
+				On("Get", provider).Return(hpa, nil).
+				On("Construct", provider, deployment).Return(nil).
+				On("Delete", hpa).Return(nil)
+			mockPdbMan.
+				On("Get", provider).Return(pdb, nil).
+				On("Construct", provider).Return(nil).
+				On("Delete", pdb).Return(nil)
+
+			Expect(reconcileWithDeploymentAndService()).To(Succeed())
+
+			mockAutoscalerMan.AssertCalled(GinkgoT(), "Delete", hpa)
+			mockPdbMan.AssertCalled(GinkgoT(), "Delete", pdb)
+		})
+
+		Specify("Should set state as failed when the autoscaler cannot be reconciled", func() {
+			mockAutoscalerMan.
+				On("Get", provider).Return(nil, errors.NewNotFound(schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"}, "hpa")).
+				On("Construct", provider, deployment).Return(hpa).
+				On("Create", hpa, provider).Return(errors.NewBadRequest("error"))
+
+			Expect(reconcileWithDeploymentAndService()).NotTo(Succeed())
+
+			mockStatusMan.AssertCalled(GinkgoT(), "UpdateProviderStatus", provider, apis.Failed, "Failed to reconcile subresource autoscaler")
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"slices"
 	"sort"
 	"strings"
@@ -31,7 +32,7 @@ type DeploymentResourceManager interface {
 	Update(ctx context.Context, old, new *appsv1.Deployment, owner *pipelineshub.Provider) error
 	Get(ctx context.Context, owner *pipelineshub.Provider) (*appsv1.Deployment, error)
 	Equal(a, b *appsv1.Deployment) bool
-	Construct(provider *pipelineshub.Provider) (*appsv1.Deployment, error)
+	Construct(ctx context.Context, provider *pipelineshub.Provider) (*appsv1.Deployment, error)
 }
 
 type DeploymentManager struct {
@@ -58,8 +59,13 @@ func (dm DeploymentManager) Create(ctx context.Context, new *appsv1.Deployment,
 func (dm DeploymentManager) Update(ctx context.Context, old *appsv1.Deployment, new *appsv1.Deployment, owner *pipelineshub.Provider) error {
 	logger := log.FromContext(ctx)
 
+	replicas := old.Spec.Replicas
 	old.Spec = new.Spec
 	old.SetLabels(new.Labels)
+	if new.Spec.Replicas == nil {
+		// the replicas of autoscaled deployments are owned by the autoscaler
+		old.Spec.Replicas = replicas
+	}
 
 	if err := ctrl.SetControllerReference(owner, old, dm.scheme); err != nil {
 		logger.Error(err, "unable to set controller reference on deployment", "deployment", new.Name)
@@ -90,27 +96,44 @@ func (dm DeploymentManager) Get(ctx context.Context, owner *pipelineshub.Provide
 	return nil, apierrors.NewNotFound(schema.GroupResource{}, "")
 }
 
+// Equal ignores the replicas when either deployment leaves them to an
+// autoscaler.
 func (dm DeploymentManager) Equal(a, b *appsv1.Deployment) bool {
+	aSpec, bSpec := a.Spec, b.Spec
+	if aSpec.Replicas == nil || bSpec.Replicas == nil {
+		aSpec.Replicas, bSpec.Replicas = nil, nil
+	}
+
 	return a.GenerateName == b.GenerateName &&
 		a.Namespace == b.Namespace &&
-		equality.Semantic.DeepEqual(a.Spec, b.Spec)
+		equality.Semantic.DeepEqual(aSpec, bSpec)
 }
 
-func (dm DeploymentManager) Construct(provider *pipelineshub.Provider) (*appsv1.Deployment, error) {
+// Construct stamps a hash of the referenced Secrets and ConfigMaps into the
+// pod template so that changes to them roll out the deployment.
+func (dm DeploymentManager) Construct(ctx context.Context, provider *pipelineshub.Provider) (*appsv1.Deployment, error) {
 	prefixedProviderName := fmt.Sprintf("provider-%s", provider.Name)
 
 	matchLabels := map[string]string{AppLabel: prefixedProviderName}
 	deploymentLabels := lo.Assign(dm.config.DefaultProviderValues.Labels, matchLabels)
-	replicas := int32(dm.config.DefaultProviderValues.Replicas)
 
-	podTemplate := dm.config.DefaultProviderValues.PodTemplateSpec
-	populatedPodTemplate, err := populateServiceContainer(dm.config.DefaultProviderValues.ServiceContainerName, *podTemplate.DeepCopy(), provider)
+	var replicas *int32
+	if providerAutoscaling(provider, dm.config) == nil {
+		replicas = lo.ToPtr(int32(dm.config.DefaultProviderValues.Replicas))
+	}
+
+	podTemplate, err := providerPodTemplate(dm.config, provider)
 	if err != nil {
 		return nil, err
 	}
-	podTemplate = *populatedPodTemplate
-	podTemplate.Spec.ServiceAccountName = provider.Spec.ServiceAccount
-	podTemplate.ObjectMeta.Labels = lo.Assign(podTemplate.ObjectMeta.Labels, matchLabels)
+
+	if references := podTemplateReferences(podTemplate); !references.Empty() {
+		hash, err := configHash(ctx, dm.client.NonCached, provider.Namespace, references)
+		if err != nil {
+			return nil, err
+		}
+		podTemplate.ObjectMeta.Annotations = lo.Assign(podTemplate.ObjectMeta.Annotations, map[string]string{ConfigHashAnnotation: hash})
+	}
 
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -126,7 +149,7 @@ func (dm DeploymentManager) Construct(provider *pipelineshub.Provider) (*appsv1.
 			Selector: &metav1.LabelSelector{
 				MatchLabels: matchLabels,
 			},
-			Replicas: &replicas,
+			Replicas: replicas,
 			Template: podTemplate,
 		},
 	}
@@ -134,6 +157,64 @@ func (dm DeploymentManager) Construct(provider *pipelineshub.Provider) (*appsv1.
 	return deployment, nil
 }
 
+// providerPodTemplate is the template of the provider-service pods, populated
+// from the operator defaults and the provider.
+func providerPodTemplate(config *config.ConfigSpec, provider *pipelineshub.Provider) (corev1.PodTemplateSpec, error) {
+	populatedPodTemplate, err := populateServiceContainer(config.DefaultProviderValues.ServiceContainerName, *config.DefaultProviderValues.PodTemplateSpec.DeepCopy(), provider)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	podTemplate := *populatedPodTemplate
+	if config.RunCompletionFeed.Authentication != nil {
+		podTemplate = withRunCompletionToken(podTemplate, config.DefaultProviderValues.ServiceContainerName, config.RunCompletionFeed.Authentication.TokenAudience())
+	}
+	podTemplate.Spec.ServiceAccountName = provider.Spec.ServiceAccount
+	podTemplate.ObjectMeta.Labels = lo.Assign(podTemplate.ObjectMeta.Labels, map[string]string{AppLabel: fmt.Sprintf("provider-%s", provider.Name)})
+
+	return podTemplate, nil
+}
+
+const (
+	runCompletionTokenVolumeName        = "kfp-operator-token"
+	runCompletionTokenExpirationSeconds = int64(3600)
+)
+
+// withRunCompletionToken projects a token for the audience of the run
+// completion feed into the service container, which the provider service
+// authenticates run completion events with.
+func withRunCompletionToken(podTemplate corev1.PodTemplateSpec, serviceContainerName string, audience string) corev1.PodTemplateSpec {
+	tokenDir, tokenFile := path.Split(common.OperatorWebhookTokenPath)
+
+	podTemplate.Spec.Volumes = mergeVolumesByName(podTemplate.Spec.Volumes, []corev1.Volume{{
+		Name: runCompletionTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          audience,
+						ExpirationSeconds: lo.ToPtr(runCompletionTokenExpirationSeconds),
+						Path:              tokenFile,
+					},
+				}},
+			},
+		},
+	}})
+
+	podTemplate.Spec.Containers = lo.Map(podTemplate.Spec.Containers, func(c corev1.Container, _ int) corev1.Container {
+		if c.Name == serviceContainerName {
+			c.VolumeMounts = mergeVolumeMountsByPath(c.VolumeMounts, []corev1.VolumeMount{{
+				Name:      runCompletionTokenVolumeName,
+				MountPath: path.Clean(tokenDir),
+				ReadOnly:  true,
+			}})
+		}
+		return c
+	})
+
+	return podTemplate
+}
+
 func populateServiceContainer(serviceContainerName string, podTemplate corev1.PodTemplateSpec, provider *pipelineshub.Provider) (*corev1.PodTemplateSpec, error) {
 	if !slices.ContainsFunc(podTemplate.Spec.Containers, func(c corev1.Container) bool {
 		return c.Name == serviceContainerName
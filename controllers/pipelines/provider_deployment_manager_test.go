@@ -8,6 +8,7 @@ import (
 	"fmt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/controllers"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/testutil"
@@ -186,7 +187,7 @@ var _ = Context("Provider Deployment Manager", func() {
 			}
 			provider.Spec.PodTemplateEnv = nil
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			providerSuffixedName := fmt.Sprintf("provider-%s", provider.Name)
@@ -237,7 +238,7 @@ var _ = Context("Provider Deployment Manager", func() {
 				{Name: "CUSTOM", Value: "x"},
 			}
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			providerNamespacedName, err := common.NamespacedName{
@@ -285,7 +286,7 @@ var _ = Context("Provider Deployment Manager", func() {
 				},
 			}
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			envByName := map[string]corev1.EnvVar{}
@@ -333,7 +334,7 @@ var _ = Context("Provider Deployment Manager", func() {
 				ReadOnly:  true,
 			}}
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(deployment.Spec.Template.Spec.Volumes).To(Equal(provider.Spec.PodTemplateVolumes))
@@ -354,7 +355,7 @@ var _ = Context("Provider Deployment Manager", func() {
 				{Name: "custom", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 			}
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(deployment.Spec.Template.Spec.Volumes).To(Equal([]corev1.Volume{
@@ -378,7 +379,7 @@ var _ = Context("Provider Deployment Manager", func() {
 				{Name: "custom", MountPath: "/custom"},
 			}
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
@@ -394,22 +395,97 @@ var _ = Context("Provider Deployment Manager", func() {
 			provider.Spec.PodTemplateVolumes = nil
 			provider.Spec.PodTemplateVolumeMounts = nil
 
-			deployment, err := deploymentManager.Construct(provider)
+			deployment, err := deploymentManager.Construct(ctx, provider)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(deployment.Spec.Template.Spec.Volumes).To(BeEmpty())
 			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(BeEmpty())
 		})
 
+		Specify("Should project a token for the run completion feed audience when authentication is configured", func() {
+			provider.Spec.Parameters = nil
+			provider.Spec.PodTemplateEnv = nil
+			provider.Spec.PodTemplateVolumes = nil
+			provider.Spec.PodTemplateVolumeMounts = nil
+			deploymentManager.config.RunCompletionFeed.Authentication = &config.FeedAuthentication{}
+
+			deployment, err := deploymentManager.Construct(ctx, provider)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deployment.Spec.Template.Spec.Volumes).To(Equal([]corev1.Volume{{
+				Name: "kfp-operator-token",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          config.DefaultFeedAudience,
+								ExpirationSeconds: lo.ToPtr(int64(3600)),
+								Path:              "token",
+							},
+						}},
+					},
+				},
+			}}))
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: "kfp-operator-token", MountPath: "/var/run/secrets/kfp-operator", ReadOnly: true},
+			}))
+		})
+
 		Specify("Should return an error if the no container with matching ServiceContainerName exists", func() {
 			deploymentManager.config.DefaultProviderValues.PodTemplateSpec.Spec.Containers = []corev1.Container{}
-			_, err := deploymentManager.Construct(provider)
+			_, err := deploymentManager.Construct(ctx, provider)
 
 			Expect(err).To(HaveOccurred())
 		})
 
 	})
 
+	var _ = Describe("Replicas", func() {
+
+		Specify("Should set the default replicas of providers that are not autoscaled", func() {
+			deploymentManager.config.DefaultProviderValues.Replicas = 2
+			unscaledProvider := pipelineshub.RandomProvider()
+			unscaledProvider.Spec.Autoscaling = nil
+
+			deployment, err := deploymentManager.Construct(ctx, unscaledProvider)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deployment.Spec.Replicas).To(Equal(&[]int32{2}[0]))
+		})
+
+		Specify("Should leave the replicas of autoscaled providers to the autoscaler", func() {
+			autoscaledProvider := pipelineshub.RandomProvider()
+			autoscaledProvider.Spec.Autoscaling = &pipelineshub.ProviderAutoscaling{MaxReplicas: 3}
+
+			deployment, err := deploymentManager.Construct(ctx, autoscaledProvider)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deployment.Spec.Replicas).To(BeNil())
+		})
+
+		Specify("Should keep the replicas of autoscaled deployments on update", func() {
+			replicas := int32(3)
+			existing := appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+			}
+			Expect(client.Create(ctx, &existing)).To(Succeed())
+
+			desired := existing.DeepCopy()
+			desired.Spec.Replicas = nil
+			Expect(deploymentManager.Equal(&existing, desired)).To(BeTrue())
+
+			Expect(deploymentManager.Update(ctx, &existing, desired, provider)).To(Succeed())
+
+			result := &appsv1.Deployment{}
+			Expect(client.Get(ctx, k8sClient.ObjectKey{Name: existing.Name, Namespace: existing.Namespace}, result)).To(Succeed())
+			Expect(result.Spec.Replicas).To(Equal(&replicas))
+		})
+	})
+
 	var _ = Describe("Equal", func() {
 
 		Specify("Should return true if the deployments are equal", func() {
@@ -0,0 +1,139 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type DisruptionBudgetResourceManager interface {
+	Create(ctx context.Context, new *policyv1.PodDisruptionBudget, owner *pipelineshub.Provider) error
+	Update(ctx context.Context, old, new *policyv1.PodDisruptionBudget, owner *pipelineshub.Provider) error
+	Delete(ctx context.Context, old *policyv1.PodDisruptionBudget) error
+	Get(ctx context.Context, owner *pipelineshub.Provider) (*policyv1.PodDisruptionBudget, error)
+	Equal(a, b *policyv1.PodDisruptionBudget) bool
+	// Construct returns nil when the provider has no disruption budget.
+	Construct(provider *pipelineshub.Provider) *policyv1.PodDisruptionBudget
+}
+
+type DisruptionBudgetManager struct {
+	client *controllers.OptInClient
+	scheme *runtime.Scheme
+	config *config.ConfigSpec
+}
+
+// providerDisruptionBudget returns the disruption budget of a provider,
+// falling back to the operator's defaults.
+func providerDisruptionBudget(provider *pipelineshub.Provider, config *config.ConfigSpec) *pipelineshub.ProviderDisruptionBudget {
+	if provider.Spec.DisruptionBudget != nil {
+		return provider.Spec.DisruptionBudget
+	}
+
+	return config.DefaultProviderValues.DisruptionBudget
+}
+
+func (dbm DisruptionBudgetManager) Create(ctx context.Context, new *policyv1.PodDisruptionBudget, owner *pipelineshub.Provider) error {
+	logger := log.FromContext(ctx)
+
+	if err := ctrl.SetControllerReference(owner, new, dbm.scheme); err != nil {
+		logger.Error(err, "unable to set controller reference on disruption budget", "disruptionBudget", new.Name)
+		return err
+	}
+
+	if err := dbm.client.Create(ctx, new); err != nil {
+		logger.Error(err, "unable to create provider disruption budget")
+		return err
+	}
+	return nil
+}
+
+func (dbm DisruptionBudgetManager) Update(ctx context.Context, old, new *policyv1.PodDisruptionBudget, owner *pipelineshub.Provider) error {
+	logger := log.FromContext(ctx)
+
+	old.Spec = new.Spec
+	old.SetLabels(new.Labels)
+
+	if err := ctrl.SetControllerReference(owner, old, dbm.scheme); err != nil {
+		logger.Error(err, "unable to set controller reference on disruption budget", "disruptionBudget", old.Name)
+		return err
+	}
+
+	if err := dbm.client.Update(ctx, old); err != nil {
+		logger.Error(err, "unable to update provider disruption budget", "disruptionBudget", old.Name)
+		return err
+	}
+	return nil
+}
+
+func (dbm DisruptionBudgetManager) Delete(ctx context.Context, old *policyv1.PodDisruptionBudget) error {
+	logger := log.FromContext(ctx)
+
+	if err := dbm.client.Delete(ctx, old); err != nil {
+		logger.Error(err, "unable to delete provider disruption budget", "disruptionBudget", old.Name)
+		return err
+	}
+	return nil
+}
+
+func (dbm DisruptionBudgetManager) Get(ctx context.Context, owner *pipelineshub.Provider) (*policyv1.PodDisruptionBudget, error) {
+	pl := &policyv1.PodDisruptionBudgetList{}
+	if err := dbm.client.NonCached.List(ctx, pl, &client.ListOptions{
+		Namespace: owner.Namespace,
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, pdb := range pl.Items {
+		if metav1.IsControlledBy(&pdb, owner) {
+			return &pdb, nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+}
+
+func (dbm DisruptionBudgetManager) Equal(a, b *policyv1.PodDisruptionBudget) bool {
+	return a.GenerateName == b.GenerateName &&
+		a.Namespace == b.Namespace &&
+		equality.Semantic.DeepEqual(a.Spec.Selector, b.Spec.Selector) &&
+		equality.Semantic.DeepEqual(a.Spec.MinAvailable, b.Spec.MinAvailable) &&
+		equality.Semantic.DeepEqual(a.Spec.MaxUnavailable, b.Spec.MaxUnavailable)
+}
+
+func (dbm DisruptionBudgetManager) Construct(provider *pipelineshub.Provider) *policyv1.PodDisruptionBudget {
+	disruptionBudget := providerDisruptionBudget(provider, dbm.config)
+	if disruptionBudget == nil {
+		return nil
+	}
+
+	prefixedProviderName := fmt.Sprintf("provider-%s", provider.Name)
+	matchLabels := map[string]string{AppLabel: prefixedProviderName}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-", prefixedProviderName),
+			Namespace:    provider.Namespace,
+			Labels:       lo.Assign(dbm.config.DefaultProviderValues.Labels, matchLabels),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: matchLabels,
+			},
+			MinAvailable:   disruptionBudget.MinAvailable,
+			MaxUnavailable: disruptionBudget.MaxUnavailable,
+		},
+	}
+}
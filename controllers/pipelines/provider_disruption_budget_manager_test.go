@@ -0,0 +1,111 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/testutil"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8Scheme "k8s.io/client-go/kubernetes/scheme"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Context("Provider Disruption Budget Manager", func() {
+
+	var (
+		ctx                     = context.Background()
+		provider                *pipelineshub.Provider
+		client                  k8sClient.Client
+		disruptionBudgetManager DisruptionBudgetManager
+	)
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+
+		client = fake.NewClientBuilder().
+			WithScheme(testutil.SchemeWithCrds()).
+			WithScheme(k8Scheme.Scheme).
+			Build()
+
+		disruptionBudgetManager = DisruptionBudgetManager{
+			client: &controllers.OptInClient{
+				Writer:       client,
+				StatusClient: client,
+				Cached:       client,
+				NonCached:    client,
+			},
+			scheme: client.Scheme(),
+			config: &config.ConfigSpec{},
+		}
+	})
+
+	var _ = Describe("Construct", func() {
+
+		Specify("Should select the provider pods", func() {
+			maxUnavailable := intstr.FromString("50%")
+			provider.Spec.DisruptionBudget = &pipelineshub.ProviderDisruptionBudget{MaxUnavailable: &maxUnavailable}
+
+			pdb := disruptionBudgetManager.Construct(provider)
+
+			providerSuffixedName := fmt.Sprintf("provider-%s", provider.Name)
+			Expect(pdb.GenerateName).To(Equal(providerSuffixedName + "-"))
+			Expect(pdb.Namespace).To(Equal(provider.Namespace))
+			Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{AppLabel: providerSuffixedName}))
+			Expect(pdb.Spec.MaxUnavailable).To(Equal(&maxUnavailable))
+			Expect(pdb.Spec.MinAvailable).To(BeNil())
+		})
+
+		Specify("Should fall back to the operator defaults", func() {
+			minAvailable := intstr.FromInt32(2)
+			provider.Spec.DisruptionBudget = nil
+			disruptionBudgetManager.config.DefaultProviderValues.DisruptionBudget = &pipelineshub.ProviderDisruptionBudget{MinAvailable: &minAvailable}
+
+			Expect(disruptionBudgetManager.Construct(provider).Spec.MinAvailable).To(Equal(&minAvailable))
+		})
+
+		Specify("Should return nil if the provider has no disruption budget", func() {
+			provider.Spec.DisruptionBudget = nil
+
+			Expect(disruptionBudgetManager.Construct(provider)).To(BeNil())
+		})
+	})
+
+	var _ = Describe("Lifecycle", func() {
+
+		Specify("Should create, get, update and delete the disruption budget owned by the provider", func() {
+			_, err := disruptionBudgetManager.Get(ctx, provider)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			pdb := disruptionBudgetManager.Construct(provider)
+			pdb.Name = "provider-pdb"
+			Expect(disruptionBudgetManager.Create(ctx, pdb, provider)).To(Succeed())
+
+			existing, err := disruptionBudgetManager.Get(ctx, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(disruptionBudgetManager.Equal(existing, pdb)).To(BeTrue())
+
+			minAvailable := intstr.FromString("25%")
+			provider.Spec.DisruptionBudget.MinAvailable = &minAvailable
+			desired := disruptionBudgetManager.Construct(provider)
+			Expect(disruptionBudgetManager.Equal(existing, desired)).To(BeFalse())
+			Expect(disruptionBudgetManager.Update(ctx, existing, desired, provider)).To(Succeed())
+
+			existing, err = disruptionBudgetManager.Get(ctx, provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(existing.Spec.MinAvailable).To(Equal(&minAvailable))
+
+			Expect(disruptionBudgetManager.Delete(ctx, existing)).To(Succeed())
+			_, err = disruptionBudgetManager.Get(ctx, provider)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
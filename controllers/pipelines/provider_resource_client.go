@@ -0,0 +1,135 @@
+package pipelines
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	ErrProviderResourceNotFound      = errors.New("provider resource not found")
+	ErrProviderResourceUnimplemented = errors.New("provider does not implement the operation for the resource")
+)
+
+// ProviderResourceGetter reads a resource from the provider-service.
+type ProviderResourceGetter interface {
+	Get(ctx context.Context, providerSvc corev1.Service, kind string, id string) (providers.Output, error)
+}
+
+// ProviderResourceLister lists and deletes resources on the provider-service
+// independently of the resources managed by the operator.
+type ProviderResourceLister interface {
+	List(ctx context.Context, providerSvc corev1.Service, kind string, labels map[string]string) ([]providers.Output, error)
+	Delete(ctx context.Context, providerSvc corev1.Service, kind string, id string) error
+}
+
+// HttpProviderResourceClient calls the resource endpoints of the
+// provider-service. It returns ErrProviderResourceNotFound when the
+// provider-service does not know a resource and
+// ErrProviderResourceUnimplemented when it does not implement an operation
+// for the kind.
+type HttpProviderResourceClient struct {
+	Client      *http.Client
+	ServicePort int
+}
+
+func NewHttpProviderResourceClient(servicePort int) HttpProviderResourceClient {
+	return HttpProviderResourceClient{
+		Client:      &http.Client{Timeout: DirectExecutionTimeout},
+		ServicePort: servicePort,
+	}
+}
+
+func (hprc HttpProviderResourceClient) Get(
+	ctx context.Context,
+	providerSvc corev1.Service,
+	kind string,
+	id string,
+) (providers.Output, error) {
+	output := providers.Output{}
+
+	body, err := hprc.do(ctx, http.MethodGet, hprc.resourceUrl(providerSvc, kind, id))
+	if err != nil {
+		return output, err
+	}
+
+	err = json.Unmarshal(body, &output)
+	return output, err
+}
+
+func (hprc HttpProviderResourceClient) List(
+	ctx context.Context,
+	providerSvc corev1.Service,
+	kind string,
+	labels map[string]string,
+) ([]providers.Output, error) {
+	query := url.Values{}
+	for key, value := range labels {
+		query.Set(key, value)
+	}
+
+	body, err := hprc.do(ctx, http.MethodGet, hprc.resourceUrl(providerSvc, kind, "")+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []providers.Output
+	err = json.Unmarshal(body, &outputs)
+	return outputs, err
+}
+
+func (hprc HttpProviderResourceClient) Delete(
+	ctx context.Context,
+	providerSvc corev1.Service,
+	kind string,
+	id string,
+) error {
+	_, err := hprc.do(ctx, http.MethodDelete, hprc.resourceUrl(providerSvc, kind, id))
+	return err
+}
+
+func (hprc HttpProviderResourceClient) resourceUrl(providerSvc corev1.Service, kind string, id string) string {
+	return fmt.Sprintf(
+		"http://%s/resource/%s/%s",
+		workflowfactory.ProviderServiceUrl(providerSvc, hprc.ServicePort),
+		kind,
+		url.PathEscape(id),
+	)
+}
+
+func (hprc HttpProviderResourceClient) do(ctx context.Context, method string, resourceUrl string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, resourceUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := hprc.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, ErrProviderResourceNotFound
+	case http.StatusNotImplemented:
+		return nil, ErrProviderResourceUnimplemented
+	default:
+		return nil, fmt.Errorf("provider-service failed to %s %s with status %d: %s", method, resourceUrl, response.StatusCode, body)
+	}
+}
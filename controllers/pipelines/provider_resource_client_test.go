@@ -0,0 +1,115 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HttpProviderResourceClient", func() {
+	var (
+		server        *httptest.Server
+		requestMethod string
+		requestPath   string
+		requestQuery  string
+		statusCode    int
+		responseBody  string
+	)
+
+	providerSvc := corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "default"}}
+
+	BeforeEach(func() {
+		statusCode = http.StatusOK
+		responseBody = `{"id":"projects/p/schedules/s","version":"v1"}`
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestMethod = r.Method
+			requestPath = r.URL.EscapedPath()
+			requestQuery = r.URL.RawQuery
+			w.WriteHeader(statusCode)
+			w.Write([]byte(responseBody))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func() HttpProviderResourceClient {
+		client := NewHttpProviderResourceClient(8080)
+		// Route the in-cluster service address to the test server.
+		client.Client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+			},
+		}
+		return client
+	}
+
+	get := func() (providers.Output, error) {
+		return newClient().Get(context.Background(), providerSvc, "runschedule", "projects/p/schedules/s")
+	}
+
+	It("gets resources with an encoded id", func() {
+		output, err := get()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(Equal(providers.Output{Id: "projects/p/schedules/s", Version: "v1"}))
+		Expect(requestMethod).To(Equal(http.MethodGet))
+		Expect(requestPath).To(Equal("/resource/runschedule/projects%2Fp%2Fschedules%2Fs"))
+	})
+
+	It("reports missing resources", func() {
+		statusCode = http.StatusNotFound
+		_, err := get()
+
+		Expect(err).To(MatchError(ErrProviderResourceNotFound))
+	})
+
+	It("reports unimplemented operations", func() {
+		statusCode = http.StatusNotImplemented
+		_, err := get()
+
+		Expect(err).To(MatchError(ErrProviderResourceUnimplemented))
+	})
+
+	It("fails on other errors", func() {
+		statusCode = http.StatusInternalServerError
+		_, err := get()
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("lists resources by label", func() {
+		responseBody = `[{"id":"a"},{"id":"b"}]`
+
+		outputs, err := newClient().List(context.Background(), providerSvc, "runschedule", map[string]string{
+			"provider-name":      "provider",
+			"provider-namespace": "default",
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(Equal([]providers.Output{{Id: "a"}, {Id: "b"}}))
+		Expect(requestMethod).To(Equal(http.MethodGet))
+		Expect(requestPath).To(Equal("/resource/runschedule/"))
+		Expect(requestQuery).To(Equal("provider-name=provider&provider-namespace=default"))
+	})
+
+	It("deletes resources with an encoded id", func() {
+		responseBody = `{}`
+
+		err := newClient().Delete(context.Background(), providerSvc, "runschedule", "projects/p/schedules/s")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requestMethod).To(Equal(http.MethodDelete))
+		Expect(requestPath).To(Equal("/resource/runschedule/projects%2Fp%2Fschedules%2Fs"))
+	})
+})
@@ -4,10 +4,14 @@ import (
 	"context"
 
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/samber/lo"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
 	"github.com/sky-uk/kfp-operator/internal/config"
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -65,13 +69,51 @@ func (br ResourceReconciler[R]) reconciliationRequestsForWorkflow(
 	}
 }
 
+// reconciliationRequestsForNamespace requests the reconciliation of all
+// resources in a namespace so that changes to its labels are re-evaluated
+// against the allowedNamespaceSelector of providers.
+func (br ResourceReconciler[R]) reconciliationRequestsForNamespace(
+	list client.ObjectList,
+) handler.MapFunc {
+	return func(ctx context.Context, namespace client.Object) []reconcile.Request {
+		logger := log.FromContext(ctx)
+
+		resources := list.DeepCopyObject().(client.ObjectList)
+		if err := br.EC.Client.Cached.List(ctx, resources, client.InNamespace(namespace.GetName())); err != nil {
+			logger.Error(err, "unable to list resources in namespace", "namespace", namespace.GetName())
+			return nil
+		}
+
+		items, err := meta.ExtractList(resources)
+		if err != nil {
+			logger.Error(err, "unable to extract resources", "namespace", namespace.GetName())
+			return nil
+		}
+
+		return lo.FilterMap(items, func(item runtime.Object, _ int) (reconcile.Request, bool) {
+			object, ok := item.(client.Object)
+			if !ok {
+				return reconcile.Request{}, false
+			}
+			return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)}, true
+		})
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
 func (br ResourceReconciler[R]) setupWithManager(
 	controllerBuilder *builder.Builder,
 	resource R,
+	list client.ObjectList,
 ) *builder.Builder {
 	return controllerBuilder.Watches(
 		&argo.Workflow{},
 		handler.EnqueueRequestsFromMapFunc(br.reconciliationRequestsForWorkflow(resource)),
-		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, br.EC.Scope.WorkflowPredicate()),
+	).Watches(
+		&corev1.Namespace{},
+		handler.EnqueueRequestsFromMapFunc(br.reconciliationRequestsForNamespace(list)),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}, br.EC.Scope.NamespacePredicate()),
 	)
 }
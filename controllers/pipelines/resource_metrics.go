@@ -0,0 +1,280 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResourceMetrics records the synchronisation of resources and the workflows
+// created for them. All methods are safe to call on a nil receiver so that
+// execution contexts without metrics do not need to guard against it.
+type ResourceMetrics struct {
+	stateTransitions            metric.Int64Counter
+	syncDurations               metric.Float64Histogram
+	workflowsCreated            metric.Int64Counter
+	workflowsFailed             metric.Int64Counter
+	parameterResolutionFailures metric.Int64Counter
+	mutex                       sync.Mutex
+	syncStarts                  map[resourceName]syncStart
+	currentTimeFunc             func() time.Time
+}
+
+type resourceName struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// syncStart is when a generation of a resource was first observed.
+type syncStart struct {
+	generation int64
+	observedAt time.Time
+}
+
+func NewResourceMetrics(reader client.Reader) (*ResourceMetrics, error) {
+	meter := otel.Meter("resources")
+
+	if _, err := meter.Int64ObservableGauge(
+		"resources",
+		metric.WithDescription("Number of resources by kind, namespace and synchronization state"),
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			return observeResources(ctx, reader, observer)
+		}),
+	); err != nil {
+		return nil, fmt.Errorf("failed to create resources gauge: %w", err)
+	}
+
+	stateTransitions, err := meter.Int64Counter(
+		"resource_state_transitions",
+		metric.WithDescription("Total number of synchronization state transitions of resources"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state transitions counter: %w", err)
+	}
+
+	syncDurations, err := meter.Float64Histogram(
+		"resource_sync_duration",
+		metric.WithDescription("Time from a new generation of a resource being observed until it has succeeded"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync duration histogram: %w", err)
+	}
+
+	workflowsCreated, err := meter.Int64Counter(
+		"resource_workflows_created",
+		metric.WithDescription("Total number of workflows created for resources"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflows created counter: %w", err)
+	}
+
+	workflowsFailed, err := meter.Int64Counter(
+		"resource_workflows_failed",
+		metric.WithDescription("Total number of workflows for resources that have failed"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflows failed counter: %w", err)
+	}
+
+	parameterResolutionFailures, err := meter.Int64Counter(
+		"resource_parameter_resolution_failures",
+		metric.WithDescription("Total number of failures to resolve the runtime parameters of resources"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parameter resolution failures counter: %w", err)
+	}
+
+	return &ResourceMetrics{
+		stateTransitions:            stateTransitions,
+		syncDurations:               syncDurations,
+		workflowsCreated:            workflowsCreated,
+		workflowsFailed:             workflowsFailed,
+		parameterResolutionFailures: parameterResolutionFailures,
+		syncStarts:                  map[resourceName]syncStart{},
+		currentTimeFunc:             time.Now,
+	}, nil
+}
+
+type resourceKey struct {
+	kind      string
+	namespace string
+	state     apis.SynchronizationState
+}
+
+func observeResources(ctx context.Context, reader client.Reader, observer metric.Int64Observer) error {
+	counts := map[resourceKey]int64{}
+	count := func(kind, namespace string, conditions apis.Conditions) {
+		counts[resourceKey{kind: kind, namespace: namespace, state: conditions.GetSyncStateFromReason()}]++
+	}
+
+	var pipelines pipelineshub.PipelineList
+	if err := reader.List(ctx, &pipelines); err != nil {
+		return err
+	}
+	for _, pipeline := range pipelines.Items {
+		count(pipeline.GetKind(), pipeline.Namespace, pipeline.Status.Conditions)
+	}
+
+	var runSchedules pipelineshub.RunScheduleList
+	if err := reader.List(ctx, &runSchedules); err != nil {
+		return err
+	}
+	for i := range runSchedules.Items {
+		count(runSchedules.Items[i].GetKind(), runSchedules.Items[i].Namespace, runSchedules.Items[i].Status.Conditions)
+	}
+
+	var experiments pipelineshub.ExperimentList
+	if err := reader.List(ctx, &experiments); err != nil {
+		return err
+	}
+	for _, experiment := range experiments.Items {
+		count(experiment.GetKind(), experiment.Namespace, experiment.Status.Conditions)
+	}
+
+	var runs pipelineshub.RunList
+	if err := reader.List(ctx, &runs); err != nil {
+		return err
+	}
+	for i := range runs.Items {
+		count(runs.Items[i].GetKind(), runs.Items[i].Namespace, runs.Items[i].Status.Conditions)
+	}
+
+	var runConfigurations pipelineshub.RunConfigurationList
+	if err := reader.List(ctx, &runConfigurations); err != nil {
+		return err
+	}
+	for i := range runConfigurations.Items {
+		count(runConfigurations.Items[i].GetKind(), runConfigurations.Items[i].Namespace, runConfigurations.Items[i].Status.Conditions)
+	}
+
+	for key, value := range counts {
+		observer.Observe(value, metric.WithAttributes(
+			attribute.String("kind", key.kind),
+			attribute.String("namespace", key.namespace),
+			attribute.String("state", string(key.state)),
+		))
+	}
+
+	return nil
+}
+
+// RecordTransition records a change of the synchronization state of a
+// resource. A status update whose previous status had not observed the
+// current generation of the resource starts the synchronization of that
+// generation, and the time until it has succeeded is recorded as the
+// synchronization duration.
+func (rm *ResourceMetrics) RecordTransition(
+	ctx context.Context,
+	kind string,
+	resource metav1.Object,
+	oldObservedGeneration int64,
+	oldConditions apis.Conditions,
+	newConditions apis.Conditions,
+) {
+	if rm == nil {
+		return
+	}
+
+	from := oldConditions.GetSyncStateFromReason()
+	to := newConditions.GetSyncStateFromReason()
+
+	if from != to {
+		rm.stateTransitions.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("kind", kind),
+			attribute.String("namespace", resource.GetNamespace()),
+			attribute.String("from", string(from)),
+			attribute.String("to", string(to)),
+		))
+	}
+
+	if duration, synced := rm.syncDuration(kind, resource, oldObservedGeneration, to); synced {
+		rm.syncDurations.Record(ctx, duration.Seconds(), metric.WithAttributes(
+			attribute.String("kind", kind),
+			attribute.String("namespace", resource.GetNamespace()),
+		))
+	}
+}
+
+// syncDuration tracks when the current generation of the resource was first
+// observed and returns the time since then once it has succeeded.
+func (rm *ResourceMetrics) syncDuration(
+	kind string,
+	resource metav1.Object,
+	oldObservedGeneration int64,
+	state apis.SynchronizationState,
+) (time.Duration, bool) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	name := resourceName{kind: kind, namespace: resource.GetNamespace(), name: resource.GetName()}
+	generation := resource.GetGeneration()
+	now := rm.currentTimeFunc()
+
+	start, started := rm.syncStarts[name]
+	if oldObservedGeneration != generation && (!started || start.generation != generation) {
+		start = syncStart{generation: generation, observedAt: now}
+		rm.syncStarts[name] = start
+		started = true
+	}
+
+	if !started {
+		return 0, false
+	}
+
+	switch state {
+	case apis.Succeeded:
+		delete(rm.syncStarts, name)
+		return now.Sub(start.observedAt), start.generation == generation
+	case apis.Deleting, apis.Deleted:
+		delete(rm.syncStarts, name)
+	}
+
+	return 0, false
+}
+
+func (rm *ResourceMetrics) RecordWorkflowCreated(ctx context.Context, workflow *argo.Workflow) {
+	if rm == nil {
+		return
+	}
+
+	rm.workflowsCreated.Add(ctx, 1, metric.WithAttributes(attribute.String("template", workflowTemplate(workflow))))
+}
+
+func (rm *ResourceMetrics) RecordWorkflowFailed(ctx context.Context, workflow *argo.Workflow) {
+	if rm == nil {
+		return
+	}
+
+	rm.workflowsFailed.Add(ctx, 1, metric.WithAttributes(attribute.String("template", workflowTemplate(workflow))))
+}
+
+func (rm *ResourceMetrics) RecordParameterResolutionFailure(ctx context.Context, kind string, namespace string) {
+	if rm == nil {
+		return
+	}
+
+	rm.parameterResolutionFailures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("namespace", namespace),
+	))
+}
+
+func workflowTemplate(workflow *argo.Workflow) string {
+	if workflow.Spec.WorkflowTemplateRef == nil {
+		return ""
+	}
+
+	return workflow.Spec.WorkflowTemplateRef.Name
+}
@@ -0,0 +1,253 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func syncConditions(state apis.SynchronizationState, transitionTime time.Time) apis.Conditions {
+	return apis.Conditions{{
+		Type:               apis.ConditionTypes.SynchronizationSucceeded,
+		Status:             apis.ConditionStatusForSynchronizationState(state),
+		Reason:             string(state),
+		LastTransitionTime: metav1.NewTime(transitionTime),
+	}}
+}
+
+var _ = Describe("ResourceMetrics", func() {
+	ctx := context.Background()
+	originalMeterProvider := otel.GetMeterProvider()
+	provider := common.NamespacedName{Name: "provider", Namespace: "default"}
+
+	var (
+		reader    *sdkmetric.ManualReader
+		k8sClient client.Client
+		metrics   *ResourceMetrics
+	)
+
+	collect := func(name string) metricdata.Aggregation {
+		var rm metricdata.ResourceMetrics
+		Expect(reader.Collect(ctx, &rm)).To(Succeed())
+		for _, scope := range rm.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if m.Name == name {
+					return m.Data
+				}
+			}
+		}
+		return nil
+	}
+
+	int64Points := func(name string) []metricdata.DataPoint[int64] {
+		switch data := collect(name).(type) {
+		case metricdata.Sum[int64]:
+			return data.DataPoints
+		case metricdata.Gauge[int64]:
+			return data.DataPoints
+		default:
+			return nil
+		}
+	}
+
+	BeforeEach(func() {
+		reader = sdkmetric.NewManualReader()
+		otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+		scheme := runtime.NewScheme()
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+		pipeline := pipelineshub.RandomPipeline(provider)
+		pipeline.Status.Conditions = syncConditions(apis.Succeeded, time.Now())
+		otherPipeline := pipelineshub.RandomPipeline(provider)
+		otherPipeline.Status.Conditions = syncConditions(apis.Succeeded, time.Now())
+		runSchedule := pipelineshub.RandomRunSchedule(provider)
+		runSchedule.Status.Conditions = syncConditions(apis.Failed, time.Now())
+
+		k8sClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(pipeline, otherPipeline, runSchedule).
+			WithStatusSubresource(&pipelineshub.Pipeline{}).
+			Build()
+
+		var err error
+		metrics, err = NewResourceMetrics(k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		otel.SetMeterProvider(originalMeterProvider)
+	})
+
+	It("counts resources by kind, namespace and synchronization state", func() {
+		points := int64Points("resources")
+
+		Expect(points).To(HaveLen(2))
+		for _, point := range points {
+			kind, _ := point.Attributes.Value("kind")
+			state, _ := point.Attributes.Value("state")
+			namespace, _ := point.Attributes.Value("namespace")
+			Expect(namespace.AsString()).To(Equal("default"))
+
+			switch kind.AsString() {
+			case "pipeline":
+				Expect(state.AsString()).To(Equal(string(apis.Succeeded)))
+				Expect(point.Value).To(Equal(int64(2)))
+			case "runschedule":
+				Expect(state.AsString()).To(Equal(string(apis.Failed)))
+				Expect(point.Value).To(Equal(int64(1)))
+			default:
+				Fail("unexpected kind " + kind.AsString())
+			}
+		}
+	})
+
+	Describe("RecordTransition", func() {
+		var (
+			pipeline *pipelineshub.Pipeline
+			now      time.Time
+		)
+
+		BeforeEach(func() {
+			pipeline = pipelineshub.RandomPipeline(provider)
+			pipeline.Generation = 2
+			now = time.Now()
+			metrics.currentTimeFunc = func() time.Time { return now }
+		})
+
+		syncDurations := func() []metricdata.HistogramDataPoint[float64] {
+			histogram, ok := collect("resource_sync_duration").(metricdata.Histogram[float64])
+			if !ok {
+				return nil
+			}
+			return histogram.DataPoints
+		}
+
+		It("counts changes of the synchronization state", func() {
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Creating, now), syncConditions(apis.Failed, now))
+
+			points := int64Points("resource_state_transitions")
+			Expect(points).To(HaveLen(1))
+			Expect(points[0].Value).To(Equal(int64(1)))
+			Expect(points[0].Attributes.ToSlice()).To(ConsistOf(
+				attribute.String("kind", "pipeline"),
+				attribute.String("namespace", "default"),
+				attribute.String("from", string(apis.Creating)),
+				attribute.String("to", string(apis.Failed)),
+			))
+			Expect(syncDurations()).To(BeEmpty())
+		})
+
+		It("ignores updates that keep the synchronization state", func() {
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Updating, now), syncConditions(apis.Updating, now))
+
+			Expect(int64Points("resource_state_transitions")).To(BeEmpty())
+		})
+
+		It("records the time from observing a new generation until it has succeeded", func() {
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 1, syncConditions(apis.Succeeded, now), syncConditions(apis.Updating, now))
+			now = now.Add(time.Minute)
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Updating, now), syncConditions(apis.Failed, now))
+			now = now.Add(time.Minute)
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Failed, now), syncConditions(apis.Succeeded, now))
+
+			points := syncDurations()
+			Expect(points).To(HaveLen(1))
+			Expect(points[0].Count).To(Equal(uint64(1)))
+			Expect(points[0].Sum).To(Equal((2 * time.Minute).Seconds()))
+		})
+
+		It("records the duration of generations that succeed within a single update", func() {
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 1, syncConditions(apis.Succeeded, now), syncConditions(apis.Succeeded, now))
+
+			points := syncDurations()
+			Expect(points).To(HaveLen(1))
+			Expect(points[0].Count).To(Equal(uint64(1)))
+		})
+
+		It("does not record the duration of generations that were observed before", func() {
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Updating, now), syncConditions(apis.Succeeded, now))
+
+			Expect(syncDurations()).To(BeEmpty())
+		})
+
+		It("records the duration of each generation once", func() {
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 1, syncConditions(apis.Succeeded, now), syncConditions(apis.Updating, now))
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Updating, now), syncConditions(apis.Succeeded, now))
+			metrics.RecordTransition(ctx, "pipeline", pipeline, 2, syncConditions(apis.Succeeded, now), syncConditions(apis.Succeeded, now))
+
+			points := syncDurations()
+			Expect(points).To(HaveLen(1))
+			Expect(points[0].Count).To(Equal(uint64(1)))
+		})
+	})
+
+	It("counts created and failed workflows by template", func() {
+		workflow := &argo.Workflow{Spec: argo.WorkflowSpec{WorkflowTemplateRef: &argo.WorkflowTemplateRef{Name: "create-pipeline"}}}
+
+		metrics.RecordWorkflowCreated(ctx, workflow)
+		metrics.RecordWorkflowFailed(ctx, workflow)
+
+		for _, name := range []string{"resource_workflows_created", "resource_workflows_failed"} {
+			points := int64Points(name)
+			Expect(points).To(HaveLen(1))
+			Expect(points[0].Attributes.ToSlice()).To(ConsistOf(attribute.String("template", "create-pipeline")))
+		}
+	})
+
+	It("counts parameter resolution failures", func() {
+		metrics.RecordParameterResolutionFailure(ctx, "run", "default")
+
+		points := int64Points("resource_parameter_resolution_failures")
+		Expect(points).To(HaveLen(1))
+		Expect(points[0].Value).To(Equal(int64(1)))
+	})
+
+	It("records transitions when the status of a resource is set", func() {
+		var pipelines pipelineshub.PipelineList
+		Expect(k8sClient.List(ctx, &pipelines)).To(Succeed())
+		pipeline := &pipelines.Items[0]
+
+		ec := K8sExecutionContext{
+			Client:   controllers.OptInClient{Writer: k8sClient, StatusClient: k8sClient, Cached: k8sClient, NonCached: k8sClient},
+			Recorder: record.NewFakeRecorder(10),
+			Metrics:  metrics,
+		}
+		setStatus := From(pipeline.Status).WithSyncStateCondition(apis.Updating, metav1.Now(), "")
+
+		Expect(setStatus.execute(ctx, ec, pipeline)).To(Succeed())
+
+		points := int64Points("resource_state_transitions")
+		Expect(points).To(HaveLen(1))
+		Expect(points[0].Attributes.ToSlice()).To(ContainElement(attribute.String("to", string(apis.Updating))))
+	})
+
+	It("is a no-op without metrics", func() {
+		var nilMetrics *ResourceMetrics
+
+		Expect(func() {
+			nilMetrics.RecordTransition(ctx, "pipeline", pipelineshub.RandomPipeline(provider), 0, nil, syncConditions(apis.Succeeded, time.Now()))
+			nilMetrics.RecordWorkflowCreated(ctx, &argo.Workflow{})
+			nilMetrics.RecordWorkflowFailed(ctx, &argo.Workflow{})
+			nilMetrics.RecordParameterResolutionFailure(ctx, "run", "default")
+		}).NotTo(Panic())
+	})
+})
@@ -0,0 +1,152 @@
+package pipelines
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RetainedResourcesConfigMapName is the name of the ConfigMap that records
+// the provider resources retained by the deletion policy of the resources
+// that owned them.
+func RetainedResourcesConfigMapName(providerName string) string {
+	return fmt.Sprintf("%s-retained-resources", providerName)
+}
+
+// retainedResourceKey is a valid ConfigMap key for the provider resource as
+// provider ids may contain characters that keys may not.
+func retainedResourceKey(kind string, id string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(retainedResourceValue(kind, id))))
+}
+
+func retainedResourceValue(kind string, id string) string {
+	return fmt.Sprintf("%s/%s", kind, id)
+}
+
+// RecordRetainedResource records the provider resource of a resource that is
+// deleted with the Retain deletion policy so that the orphan reaper does not
+// delete it. The record is kept in a ConfigMap owned by the provider.
+type RecordRetainedResource struct {
+	Provider pipelineshub.Provider
+}
+
+func (rrr RecordRetainedResource) execute(ctx context.Context, ec K8sExecutionContext, resource pipelineshub.Resource) error {
+	logger := log.FromContext(ctx)
+	id := resource.GetStatus().Provider.Id
+	key := retainedResourceKey(resource.GetKind(), id)
+	name := RetainedResourcesConfigMapName(rrr.Provider.Name)
+
+	configMap := &corev1.ConfigMap{}
+	err := ec.Client.NonCached.Get(ctx, client.ObjectKey{Namespace: rrr.Provider.Namespace, Name: name}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: rrr.Provider.Namespace,
+			},
+			Data: map[string]string{key: retainedResourceValue(resource.GetKind(), id)},
+		}
+		if err := controllerutil.SetOwnerReference(&rrr.Provider, configMap, ec.Scheme); err != nil {
+			return err
+		}
+
+		logger.V(1).Info("recording retained provider resource", "configMap", name, "providerId", id)
+		return ec.Client.Create(ctx, configMap)
+	}
+
+	if _, ok := configMap.Data[key]; ok {
+		return nil
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[key] = retainedResourceValue(resource.GetKind(), id)
+
+	logger.V(1).Info("recording retained provider resource", "configMap", name, "providerId", id)
+	return ec.Client.Update(ctx, configMap)
+}
+
+// ForgetRetainedResource removes the record of a retained provider resource
+// when a resource adopts it again, so that it is reaped once it is orphaned
+// again.
+type ForgetRetainedResource struct {
+	Provider pipelineshub.Provider
+	Id       string
+}
+
+func (frr ForgetRetainedResource) execute(ctx context.Context, ec K8sExecutionContext, resource pipelineshub.Resource) error {
+	return forgetRetainedResources(ctx, ec.Client, &frr.Provider, func(kind string, id string) bool {
+		return kind == resource.GetKind() && id == frr.Id
+	})
+}
+
+// forgetRetainedResources removes the records of the retained provider
+// resources of the provider for which forget returns true.
+func forgetRetainedResources(
+	ctx context.Context,
+	optInClient controllers.OptInClient,
+	provider *pipelineshub.Provider,
+	forget func(kind string, id string) bool,
+) error {
+	name := RetainedResourcesConfigMapName(provider.Name)
+
+	configMap := &corev1.ConfigMap{}
+	err := optInClient.NonCached.Get(ctx, client.ObjectKey{Namespace: provider.Namespace, Name: name}, configMap)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var forgotten []string
+	for key, value := range configMap.Data {
+		kind, id, _ := strings.Cut(value, "/")
+		if forget(kind, id) {
+			delete(configMap.Data, key)
+			forgotten = append(forgotten, value)
+		}
+	}
+
+	if len(forgotten) == 0 {
+		return nil
+	}
+
+	log.FromContext(ctx).V(1).Info("forgetting retained provider resources", "configMap", name, "resources", forgotten)
+	return optInClient.Update(ctx, configMap)
+}
+
+// retainedResources returns the keys of the provider resources recorded as
+// retained for the provider.
+func retainedResources(ctx context.Context, reader client.Reader, provider *pipelineshub.Provider) (map[string]bool, error) {
+	configMap := &corev1.ConfigMap{}
+	err := reader.Get(ctx, client.ObjectKey{Namespace: provider.Namespace, Name: RetainedResourcesConfigMapName(provider.Name)}, configMap)
+	if apierrors.IsNotFound(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	retained := map[string]bool{}
+	for key := range configMap.Data {
+		retained[key] = true
+	}
+
+	return retained, nil
+}
@@ -0,0 +1,135 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	DefaultRetryInitialBackoff = 30 * time.Second
+	DefaultRetryMaxBackoff     = time.Hour
+)
+
+// maxRetryAttempts returns the number of retries of the resource, which may
+// be overridden with the RetryMaxAttemptsAnnotation.
+func (st StateHandler[R]) maxRetryAttempts(ctx context.Context, resource R) int32 {
+	if annotation, ok := resource.GetAnnotations()[pipelineshub.RetryMaxAttemptsAnnotation]; ok {
+		maxAttempts, err := strconv.ParseInt(annotation, 10, 32)
+		if err == nil && maxAttempts >= 0 {
+			return int32(maxAttempts)
+		}
+
+		log.FromContext(ctx).Error(err, "ignoring invalid retry max attempts annotation", "annotation", annotation)
+	}
+
+	return st.Retry.MaxAttempts
+}
+
+// retryBackoff returns the time to wait before the given attempt, doubling
+// the initial backoff with every attempt up to the maximum backoff.
+func retryBackoff(policy config.RetryPolicy, attempt int32) time.Duration {
+	backoff, maxBackoff := DefaultRetryInitialBackoff, DefaultRetryMaxBackoff
+	if policy.InitialBackoff != nil {
+		backoff = policy.InitialBackoff.Duration
+	}
+	if policy.MaxBackoff != nil {
+		maxBackoff = policy.MaxBackoff.Duration
+	}
+
+	for i := int32(1); i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+// scheduleRetry records failed creations and updates in the retry status of
+// the resource and requeues it for when the next retry is due. The retry
+// status is reset once the resource succeeds or its version changes.
+// Creations whose outcome is unknown are not retried as the provider
+// resource may already exist.
+func (st StateHandler[R]) scheduleRetry(
+	ctx context.Context,
+	resource R,
+	commands []Command,
+	now metav1.Time,
+) []Command {
+	previous := resource.GetStatus()
+	previousState := previous.Conditions.GetSyncStateFromReason()
+
+	for i, command := range commands {
+		setStatus, ok := command.(SetStatus)
+		if !ok {
+			continue
+		}
+
+		condition := setStatus.Status.Conditions.SynchronizationSucceeded()
+		state := apis.SynchronisationState(condition.Reason)
+
+		switch {
+		case state == apis.Succeeded || setStatus.Status.Version != previous.Version:
+			setStatus.Status.Retry = nil
+		case state == apis.Failed &&
+			(previousState == apis.Creating || previousState == apis.Updating) &&
+			condition.Message != StateHandlerConstants.ProviderChangedError:
+			var attempts int32
+			if setStatus.Status.Retry != nil {
+				attempts = setStatus.Status.Retry.Attempts
+			}
+
+			retry := &pipelineshub.RetryStatus{Attempts: attempts}
+			if attempts < st.maxRetryAttempts(ctx, resource) {
+				backoff := retryBackoff(st.Retry, attempts+1)
+				retry.NextRetryTime = &metav1.Time{Time: now.Add(backoff)}
+				commands = append(commands, Requeue{After: backoff})
+			}
+			setStatus.Status.Retry = retry
+		}
+
+		commands[i] = setStatus
+	}
+
+	return commands
+}
+
+// retry resubmits the workflow of a failed resource once its next retry is
+// due. It returns false if no retry is scheduled.
+func (st StateHandler[R]) retry(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource R,
+	transitionTime metav1.Time,
+) ([]Command, bool) {
+	status := resource.GetStatus()
+	if status.Retry == nil || status.Retry.NextRetryTime == nil || status.Conditions.GetSyncStateFromReason() != apis.Failed {
+		return nil, false
+	}
+
+	if wait := status.Retry.NextRetryTime.Sub(transitionTime.Time); wait > 0 {
+		return []Command{Requeue{After: wait}}, true
+	}
+
+	attempt := status.Retry.Attempts + 1
+	log.FromContext(ctx).Info("retrying failed synchronisation", "attempt", attempt)
+
+	message := fmt.Sprintf("retry %d of %d: %s", attempt, st.maxRetryAttempts(ctx, resource), status.Conditions.SynchronizationSucceeded().Message)
+	commands := st.synchronise(ctx, provider, providerSvc, resource, status.Version, transitionTime, message)
+	for i, command := range commands {
+		if setStatus, ok := command.(SetStatus); ok {
+			setStatus.Status.Retry = &pipelineshub.RetryStatus{Attempts: attempt}
+			commands[i] = setStatus
+		}
+	}
+
+	return commands, true
+}
@@ -0,0 +1,206 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Retry", func() {
+	ctx := context.Background()
+	now := metav1.Now()
+
+	var provider *pipelineshub.Provider
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = nil
+		provider.Spec.AllowedNamespaceSelector = nil
+	})
+
+	stateHandler := func(maxAttempts int32) StateHandler[*pipelineshub.TestResource] {
+		return StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			Retry: config.RetryPolicy{
+				MaxAttempts:    maxAttempts,
+				InitialBackoff: &metav1.Duration{Duration: time.Minute},
+				MaxBackoff:     &metav1.Duration{Duration: time.Hour},
+			},
+		}
+	}
+
+	resourceIn := func(state apis.SynchronizationState, message string) *pipelineshub.TestResource {
+		resource := pipelineshub.RandomResource()
+		resource.Status.Provider.Name = provider.GetCommonNamespacedName()
+		resource.SetComputedVersion(apis.RandomShortHash())
+		resource.Status.Version = resource.ComputeVersion()
+		resource.Status.Retry = nil
+		resource.Status = From(resource.Status).WithSyncStateCondition(state, now, message).Status
+		return resource
+	}
+
+	failed := func(resource *pipelineshub.TestResource, message string) []Command {
+		return []Command{*From(resource.Status).WithSyncStateCondition(apis.Failed, now, message)}
+	}
+
+	Describe("retryBackoff", func() {
+		policy := config.RetryPolicy{
+			InitialBackoff: &metav1.Duration{Duration: time.Minute},
+			MaxBackoff:     &metav1.Duration{Duration: 10 * time.Minute},
+		}
+
+		It("doubles the backoff with every attempt", func() {
+			Expect(retryBackoff(policy, 1)).To(Equal(time.Minute))
+			Expect(retryBackoff(policy, 2)).To(Equal(2 * time.Minute))
+			Expect(retryBackoff(policy, 3)).To(Equal(4 * time.Minute))
+		})
+
+		It("caps the backoff", func() {
+			Expect(retryBackoff(policy, 5)).To(Equal(10 * time.Minute))
+			Expect(retryBackoff(policy, 100)).To(Equal(10 * time.Minute))
+		})
+
+		It("uses the defaults", func() {
+			Expect(retryBackoff(config.RetryPolicy{}, 1)).To(Equal(DefaultRetryInitialBackoff))
+			Expect(retryBackoff(config.RetryPolicy{}, 100)).To(Equal(DefaultRetryMaxBackoff))
+		})
+	})
+
+	Describe("scheduleRetry", func() {
+		It("schedules a retry when an update fails", func() {
+			resource := resourceIn(apis.Updating, "")
+
+			commands := stateHandler(3).scheduleRetry(ctx, resource, failed(resource, "operation failed"), now)
+
+			Expect(commands).To(HaveLen(2))
+			Expect(commands[0].(SetStatus).Status.Retry).To(Equal(&pipelineshub.RetryStatus{
+				NextRetryTime: &metav1.Time{Time: now.Add(time.Minute)},
+			}))
+			Expect(commands[1]).To(Equal(Requeue{After: time.Minute}))
+		})
+
+		It("backs off further with every attempt", func() {
+			resource := resourceIn(apis.Creating, "")
+			resource.Status.Retry = &pipelineshub.RetryStatus{Attempts: 2}
+
+			commands := stateHandler(3).scheduleRetry(ctx, resource, failed(resource, "operation failed"), now)
+
+			Expect(commands[0].(SetStatus).Status.Retry.NextRetryTime).To(Equal(&metav1.Time{Time: now.Add(4 * time.Minute)}))
+			Expect(commands[1]).To(Equal(Requeue{After: 4 * time.Minute}))
+		})
+
+		It("stops retrying once the attempts are exhausted", func() {
+			resource := resourceIn(apis.Creating, "")
+			resource.Status.Retry = &pipelineshub.RetryStatus{Attempts: 3}
+
+			commands := stateHandler(3).scheduleRetry(ctx, resource, failed(resource, "operation failed"), now)
+
+			Expect(commands).To(HaveLen(1))
+			Expect(commands[0].(SetStatus).Status.Retry).To(Equal(&pipelineshub.RetryStatus{Attempts: 3}))
+		})
+
+		It("does not retry when retries are disabled", func() {
+			resource := resourceIn(apis.Creating, "")
+
+			commands := stateHandler(0).scheduleRetry(ctx, resource, failed(resource, "operation failed"), now)
+
+			Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(Requeue{})))
+		})
+
+		It("uses the attempts of the annotation", func() {
+			resource := resourceIn(apis.Creating, "")
+			resource.SetAnnotations(map[string]string{pipelineshub.RetryMaxAttemptsAnnotation: "1"})
+
+			commands := stateHandler(0).scheduleRetry(ctx, resource, failed(resource, "operation failed"), now)
+
+			Expect(commands).To(ContainElement(Requeue{After: time.Minute}))
+		})
+
+		It("ignores invalid annotations", func() {
+			resource := resourceIn(apis.Creating, "")
+			resource.SetAnnotations(map[string]string{pipelineshub.RetryMaxAttemptsAnnotation: "invalid"})
+
+			commands := stateHandler(0).scheduleRetry(ctx, resource, failed(resource, "operation failed"), now)
+
+			Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(Requeue{})))
+		})
+
+		It("does not retry when the provider has changed", func() {
+			resource := resourceIn(apis.Creating, "")
+
+			commands := stateHandler(3).scheduleRetry(ctx, resource, failed(resource, StateHandlerConstants.ProviderChangedError), now)
+
+			Expect(commands).To(HaveLen(1))
+		})
+
+		It("resets the retry status once the resource succeeds", func() {
+			resource := resourceIn(apis.Updating, "")
+			resource.Status.Retry = &pipelineshub.RetryStatus{Attempts: 2}
+
+			commands := stateHandler(3).scheduleRetry(ctx, resource, []Command{
+				*From(resource.Status).WithSyncStateCondition(apis.Succeeded, now, ""),
+			}, now)
+
+			Expect(commands[0].(SetStatus).Status.Retry).To(BeNil())
+		})
+
+		It("resets the retry status when the version changes", func() {
+			resource := resourceIn(apis.Failed, "operation failed")
+			resource.Status.Retry = &pipelineshub.RetryStatus{Attempts: 3}
+
+			commands := stateHandler(3).scheduleRetry(ctx, resource, []Command{
+				*From(resource.Status).WithSyncStateCondition(apis.Updating, now, "").WithVersion(apis.RandomShortHash()),
+			}, now)
+
+			Expect(commands[0].(SetStatus).Status.Retry).To(BeNil())
+		})
+	})
+
+	Describe("onSucceededOrFailed", func() {
+		It("waits until the next retry is due", func() {
+			resource := resourceIn(apis.Failed, "operation failed")
+			resource.Status.Retry = &pipelineshub.RetryStatus{
+				Attempts:      1,
+				NextRetryTime: &metav1.Time{Time: now.Add(time.Minute)},
+			}
+
+			commands := stateHandler(3).onSucceededOrFailed(ctx, *provider, *RandomProviderService(), resource, now)
+
+			Expect(commands).To(Equal([]Command{Requeue{After: time.Minute}}))
+		})
+
+		It("resubmits the workflow once the next retry is due", func() {
+			resource := resourceIn(apis.Failed, "operation failed")
+			resource.Status.Provider.Id = "provider-id"
+			resource.Status.Retry = &pipelineshub.RetryStatus{
+				Attempts:      1,
+				NextRetryTime: &metav1.Time{Time: now.Add(-time.Second)},
+			}
+
+			commands := stateHandler(3).onSucceededOrFailed(ctx, *provider, *RandomProviderService(), resource, now)
+
+			expectedStatus := From(resource.Status).WithSyncStateCondition(apis.Updating, now, "retry 2 of 3: operation failed")
+			expectedStatus.Status.Retry = &pipelineshub.RetryStatus{Attempts: 2}
+			Expect(commands).To(ContainElement(*expectedStatus))
+			Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+		})
+
+		It("does nothing when no retry is scheduled", func() {
+			resource := resourceIn(apis.Failed, "operation failed")
+			resource.Status.Retry = &pipelineshub.RetryStatus{Attempts: 3}
+
+			commands := stateHandler(3).onSucceededOrFailed(ctx, *provider, *RandomProviderService(), resource, now)
+
+			Expect(commands).To(BeEmpty())
+		})
+	})
+})
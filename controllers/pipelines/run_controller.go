@@ -3,8 +3,10 @@ package pipelines
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/sky-uk/kfp-operator/apis"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/logkeys"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
@@ -18,6 +20,7 @@ import (
 
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -41,6 +44,10 @@ func NewRunReconciler(
 		StateHandler: StateHandler[*pipelineshub.Run]{
 			WorkflowRepository: workflowRepository,
 			WorkflowFactory:    workflowfactory.RunWorkflowFactory(config),
+			Namespaces:         ec.Client.Cached,
+			DirectOperations:   NewDirectOperations(NewHttpDirectExecutor()),
+			Throttle:           NewWorkflowLimitThrottle(ec.Client.Cached),
+			Retry:              config.Retry,
 		},
 		EC: ec,
 		DependingOnPipelineReconciler: DependingOnPipelineReconciler[*pipelineshub.Run]{
@@ -78,6 +85,11 @@ func (r *RunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !r.EC.Scope.Contains(ctx, run) {
+		logger.V(2).Info("ignoring resource outside of the scope")
+		return ctrl.Result{}, nil
+	}
+
 	logger.V(3).Info("found run", "resource", run)
 
 	result, err := r.handleCompletion(ctx, run)
@@ -93,6 +105,7 @@ func (r *RunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	_, unresolvedOptParams, err := run.Spec.ResolveParameters(run.Status.Dependencies)
 
 	if err != nil {
+		r.EC.Metrics.RecordParameterResolutionFailure(ctx, run.GetKind(), run.Namespace)
 		message := fmt.Sprintf("Unable to resolve parameters: %v", err)
 		updateStatus := From(run.Status.Status).WithSyncStateCondition(apis.Succeeded, metav1.Now(), message)
 		if err := updateStatus.execute(ctx, r.EC, run); err != nil {
@@ -128,6 +141,10 @@ func (r *RunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		}
 	}
 
+	if after := requeueAfter(commands); after > 0 && (result.RequeueAfter == 0 || after < result.RequeueAfter) {
+		result.RequeueAfter = after
+	}
+
 	duration := time.Since(startTime)
 	logger.V(2).Info("reconciliation ended", logkeys.Duration, duration)
 
@@ -139,11 +156,20 @@ func (r *RunReconciler) handleCompletion(ctx context.Context, run *pipelineshub.
 		return ctrl.Result{}, err
 	}
 
-	if r.Config.RunCompletionTTL == nil || run.Status.MarkedCompletedAt == nil || run.DeletionTimestamp != nil {
+	if run.Status.MarkedCompletedAt == nil || run.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.pruneRunHistory(ctx, run); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ttl := r.completionTTL(ctx, run)
+	if ttl == nil {
 		return ctrl.Result{}, nil
 	}
 
-	ttlExpiry := run.Status.MarkedCompletedAt.Time.Add(r.Config.RunCompletionTTL.Duration)
+	ttlExpiry := run.Status.MarkedCompletedAt.Time.Add(ttl.Duration)
 
 	if time.Now().After(ttlExpiry) {
 		err := r.EC.Client.Delete(ctx, run)
@@ -153,6 +179,81 @@ func (r *RunReconciler) handleCompletion(ctx context.Context, run *pipelineshub.
 	return ctrl.Result{RequeueAfter: time.Until(ttlExpiry)}, nil
 }
 
+// completionTTL returns how long the run is kept after its completion. The
+// RunCompletionTTLAnnotation takes precedence over the TTL configured for the
+// completion state, which takes precedence over RunCompletionTTL.
+func (r *RunReconciler) completionTTL(ctx context.Context, run *pipelineshub.Run) *metav1.Duration {
+	if annotation, ok := run.GetAnnotations()[pipelineshub.RunCompletionTTLAnnotation]; ok {
+		ttl, err := time.ParseDuration(annotation)
+		if err == nil {
+			return &metav1.Duration{Duration: ttl}
+		}
+
+		log.FromContext(ctx).Error(err, "ignoring invalid completion TTL annotation", "annotation", annotation)
+	}
+
+	switch {
+	case run.Status.CompletionState == pipelineshub.CompletionStates.Succeeded && r.Config.SucceededRunCompletionTTL != nil:
+		return r.Config.SucceededRunCompletionTTL
+	case run.Status.CompletionState == pipelineshub.CompletionStates.Failed && r.Config.FailedRunCompletionTTL != nil:
+		return r.Config.FailedRunCompletionTTL
+	default:
+		return r.Config.RunCompletionTTL
+	}
+}
+
+// pruneRunHistory deletes the oldest completed runs of the RunConfiguration
+// that owns the run once their number exceeds its history limit for the
+// completion state of the run. It is called on every reconciliation of a
+// completed run and reads from the cache, so that runs are still pruned after
+// a failed deletion or when the limit is lowered.
+func (r *RunReconciler) pruneRunHistory(ctx context.Context, run *pipelineshub.Run) error {
+	owner := metav1.GetControllerOf(run)
+	if owner == nil || owner.Kind != "RunConfiguration" {
+		return nil
+	}
+
+	runConfiguration := &pipelineshub.RunConfiguration{}
+	if err := r.EC.Client.Cached.Get(ctx, types.NamespacedName{Namespace: run.Namespace, Name: owner.Name}, runConfiguration); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	limit, limited := runConfiguration.Spec.RunsHistoryLimit(run.Status.CompletionState)
+	if !limited {
+		return nil
+	}
+
+	ownedRuns, err := findOwnedRuns(ctx, r.EC.Client.Cached, runConfiguration)
+	if err != nil {
+		return err
+	}
+
+	completedRuns := lo.Filter(ownedRuns, func(ownedRun pipelineshub.Run, _ int) bool {
+		return ownedRun.Status.CompletionState == run.Status.CompletionState &&
+			ownedRun.Status.MarkedCompletedAt != nil &&
+			ownedRun.DeletionTimestamp == nil
+	})
+
+	if len(completedRuns) <= limit {
+		return nil
+	}
+
+	slices.SortFunc(completedRuns, func(a, b pipelineshub.Run) int {
+		return b.Status.MarkedCompletedAt.Compare(a.Status.MarkedCompletedAt.Time)
+	})
+
+	logger := log.FromContext(ctx)
+	for i := range completedRuns[limit:] {
+		prunedRun := &completedRuns[limit+i]
+		logger.Info("pruning run beyond the history limit", "run", prunedRun.Name, "limit", limit)
+		if err := r.EC.Client.Delete(ctx, prunedRun); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *RunReconciler) markCompletedIfCompleted(ctx context.Context, run *pipelineshub.Run) error {
 	if run.Status.CompletionState != "" && run.Status.MarkedCompletedAt == nil {
 		now := metav1.Now()
@@ -227,9 +328,9 @@ func (r *RunReconciler) reconciliationRequestsForRunconfigurations(
 func (r *RunReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	run := &pipelineshub.Run{}
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(run)
+		For(run, builder.WithPredicates(r.EC.Scope.Predicate()))
 
-	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, run)
+	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, run, &pipelineshub.RunList{})
 	controllerBuilder, err := r.DependingOnPipelineReconciler.setupWithManager(
 		mgr,
 		controllerBuilder,
@@ -0,0 +1,190 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("RunReconciler", func() {
+	ctx := context.Background()
+
+	completedRun := func(state pipelineshub.CompletionState, completedAt time.Time) *pipelineshub.Run {
+		run := pipelineshub.RandomRun(common.RandomNamespacedName())
+		run.Status.CompletionState = state
+		run.Status.MarkedCompletedAt = &metav1.Time{Time: completedAt}
+		return run
+	}
+
+	Describe("completionTTL", func() {
+		ttl := func(duration time.Duration) *metav1.Duration {
+			return &metav1.Duration{Duration: duration}
+		}
+
+		reconciler := RunReconciler{
+			ResourceReconciler: ResourceReconciler[*pipelineshub.Run]{
+				Config: config.ConfigSpec{
+					RunCompletionTTL:       ttl(time.Hour),
+					FailedRunCompletionTTL: ttl(time.Hour * 24),
+				},
+			},
+		}
+
+		It("uses the TTL of the completion state", func() {
+			run := completedRun(pipelineshub.CompletionStates.Failed, time.Now())
+
+			Expect(reconciler.completionTTL(ctx, run)).To(Equal(ttl(time.Hour * 24)))
+		})
+
+		It("falls back to the global TTL", func() {
+			run := completedRun(pipelineshub.CompletionStates.Succeeded, time.Now())
+
+			Expect(reconciler.completionTTL(ctx, run)).To(Equal(ttl(time.Hour)))
+		})
+
+		It("is overridden by the annotation", func() {
+			run := completedRun(pipelineshub.CompletionStates.Failed, time.Now())
+			run.SetAnnotations(map[string]string{pipelineshub.RunCompletionTTLAnnotation: "72h"})
+
+			Expect(reconciler.completionTTL(ctx, run)).To(Equal(ttl(time.Hour * 72)))
+		})
+
+		It("ignores invalid annotations", func() {
+			run := completedRun(pipelineshub.CompletionStates.Succeeded, time.Now())
+			run.SetAnnotations(map[string]string{pipelineshub.RunCompletionTTLAnnotation: "invalid"})
+
+			Expect(reconciler.completionTTL(ctx, run)).To(Equal(ttl(time.Hour)))
+		})
+	})
+
+	Describe("pruneRunHistory", func() {
+		var (
+			scheme           *runtime.Scheme
+			runConfiguration *pipelineshub.RunConfiguration
+			now              time.Time
+		)
+
+		BeforeEach(func() {
+			scheme = runtime.NewScheme()
+			Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+			Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+			runConfiguration = pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
+			runConfiguration.UID = "rc-uid"
+			runConfiguration.Spec.SuccessfulRunsHistoryLimit = &[]int32{2}[0]
+			runConfiguration.Spec.FailedRunsHistoryLimit = nil
+			now = time.Now().Truncate(time.Second)
+		})
+
+		ownedRun := func(name string, state pipelineshub.CompletionState, completedAt time.Time) *pipelineshub.Run {
+			run := completedRun(state, completedAt)
+			run.Name = name
+			run.Namespace = runConfiguration.Namespace
+			Expect(controllerutil.SetControllerReference(runConfiguration, run, scheme)).To(Succeed())
+			return run
+		}
+
+		reconcilerWith := func(runs ...client.Object) (RunReconciler, client.Client) {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(runs, runConfiguration)...).
+				WithStatusSubresource(&pipelineshub.Run{}).
+				Build()
+			return RunReconciler{
+				EC: K8sExecutionContext{
+					Client: controllers.OptInClient{Writer: k8sClient, StatusClient: k8sClient, Cached: k8sClient, NonCached: k8sClient},
+				},
+			}, k8sClient
+		}
+
+		remainingRuns := func(k8sClient client.Client) []string {
+			remaining := &pipelineshub.RunList{}
+			Expect(k8sClient.List(ctx, remaining)).To(Succeed())
+
+			names := make([]string, len(remaining.Items))
+			for i, remainingRun := range remaining.Items {
+				names[i] = remainingRun.Name
+			}
+			return names
+		}
+
+		prune := func(run *pipelineshub.Run, runs ...client.Object) []string {
+			reconciler, k8sClient := reconcilerWith(runs...)
+
+			Expect(reconciler.pruneRunHistory(ctx, run)).To(Succeed())
+
+			return remainingRuns(k8sClient)
+		}
+
+		It("deletes the oldest runs beyond the limit of their completion state", func() {
+			newest := ownedRun("newest", pipelineshub.CompletionStates.Succeeded, now)
+
+			Expect(prune(newest,
+				newest,
+				ownedRun("newer", pipelineshub.CompletionStates.Succeeded, now.Add(-time.Minute)),
+				ownedRun("oldest", pipelineshub.CompletionStates.Succeeded, now.Add(-time.Hour)),
+				ownedRun("failed", pipelineshub.CompletionStates.Failed, now.Add(-time.Hour)),
+			)).To(ConsistOf("newest", "newer", "failed"))
+		})
+
+		It("keeps all runs of unlimited completion states", func() {
+			newest := ownedRun("newest", pipelineshub.CompletionStates.Failed, now)
+
+			Expect(prune(newest,
+				newest,
+				ownedRun("newer", pipelineshub.CompletionStates.Failed, now.Add(-time.Minute)),
+				ownedRun("oldest", pipelineshub.CompletionStates.Failed, now.Add(-time.Hour)),
+			)).To(ConsistOf("newest", "newer", "oldest"))
+		})
+
+		It("keeps runs that are not owned by a run configuration", func() {
+			run := completedRun(pipelineshub.CompletionStates.Succeeded, now)
+
+			Expect(prune(run, run)).To(ConsistOf(run.Name))
+		})
+
+		It("prunes when the run is first marked completed", func() {
+			newest := ownedRun("newest", pipelineshub.CompletionStates.Succeeded, now)
+			newest.Status.MarkedCompletedAt = nil
+			reconciler, k8sClient := reconcilerWith(
+				newest,
+				ownedRun("newer", pipelineshub.CompletionStates.Succeeded, now.Add(-time.Minute)),
+				ownedRun("oldest", pipelineshub.CompletionStates.Succeeded, now.Add(-time.Hour)),
+			)
+
+			_, err := reconciler.handleCompletion(ctx, newest)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remainingRuns(k8sClient)).To(ConsistOf("newest", "newer"))
+		})
+
+		It("prunes when the run has already been marked completed", func() {
+			newest := ownedRun("newest", pipelineshub.CompletionStates.Succeeded, now)
+			runConfiguration.Spec.SuccessfulRunsHistoryLimit = &[]int32{1}[0]
+			reconciler, k8sClient := reconcilerWith(
+				newest,
+				ownedRun("newer", pipelineshub.CompletionStates.Succeeded, now.Add(-time.Minute)),
+				ownedRun("oldest", pipelineshub.CompletionStates.Succeeded, now.Add(-time.Hour)),
+			)
+
+			_, err := reconciler.handleCompletion(ctx, newest)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remainingRuns(k8sClient)).To(ConsistOf("newest"))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package pipelines
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"time"
@@ -23,6 +24,7 @@ import (
 
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -31,9 +33,10 @@ import (
 type RunConfigurationReconciler struct {
 	DependingOnPipelineReconciler[*pipelineshub.RunConfiguration]
 	DependingOnRunConfigurationReconciler[*pipelineshub.RunConfiguration]
-	EC     K8sExecutionContext
-	Scheme *runtime.Scheme
-	Config config.ConfigSpec
+	EC             K8sExecutionContext
+	Scheme         *runtime.Scheme
+	Config         config.ConfigSpec
+	ServiceManager ServiceResourceManager
 }
 
 func NewRunConfigurationReconciler(
@@ -50,6 +53,11 @@ func NewRunConfigurationReconciler(
 		ec,
 		ec.Scheme,
 		config,
+		ServiceManager{
+			client: &ec.Client,
+			scheme: ec.Scheme,
+			config: &config,
+		},
 	}
 }
 
@@ -72,6 +80,11 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !r.EC.Scope.Contains(ctx, runConfiguration) {
+		logger.V(2).Info("ignoring resource outside of the scope")
+		return ctrl.Result{}, nil
+	}
+
 	logger.V(3).Info("found run configuration", "resource", runConfiguration)
 
 	if runConfiguration.DeletionTimestamp != nil {
@@ -81,6 +94,7 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	provider := runConfiguration.Spec.Run.Provider
 	if !runConfiguration.Status.Provider.Empty() && provider != runConfiguration.Status.Provider {
 		//TODO: refactor to use Commands and introduce a StateHandler
+		oldStatus := runConfiguration.Status
 		runConfiguration.Status.SetSynchronizationState(apis.Failed, StateHandlerConstants.ProviderChangedError)
 
 		message := fmt.Sprintf(
@@ -90,7 +104,27 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		)
 		r.EC.Recorder.Event(runConfiguration, EventTypes.Warning, EventReasons.SyncFailed, message)
 
-		return ctrl.Result{}, r.EC.Client.Status().Update(ctx, runConfiguration)
+		return ctrl.Result{}, r.updateStatus(ctx, runConfiguration, oldStatus)
+	}
+
+	cycle, err := r.findDependencyCycle(ctx, runConfiguration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cycle != nil {
+		message := fmt.Sprintf("%s: %s", StateHandlerConstants.DependencyCycleError, cycle)
+		logger.Info("refusing to trigger runs", "cycle", cycle.String())
+
+		if runConfiguration.Status.Conditions.SynchronizationSucceeded().Message != message {
+			oldStatus := runConfiguration.Status
+			runConfiguration.Status.ObservedGeneration = runConfiguration.GetGeneration()
+			runConfiguration.Status.SetSynchronizationState(apis.Failed, message)
+			r.EC.Recorder.Event(runConfiguration, EventTypes.Warning, EventReasons.SyncFailed, message)
+
+			return ctrl.Result{}, r.updateStatus(ctx, runConfiguration, oldStatus)
+		}
+
+		return ctrl.Result{}, nil
 	}
 
 	if changed, err := r.handleObservedPipelineVersion(
@@ -105,6 +139,10 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	if pipelineshub.IsDryRun(runConfiguration) {
+		return ctrl.Result{}, r.plan(ctx, runConfiguration)
+	}
+
 	var newStatus pipelineshub.RunConfigurationStatus
 	state := apis.Succeeded
 	message := ""
@@ -122,6 +160,7 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return ctrl.Result{}, err
 		}
 	} else {
+		r.EC.Metrics.RecordParameterResolutionFailure(ctx, runConfiguration.GetKind(), runConfiguration.Namespace)
 		r.EC.Recorder.Eventf(
 			runConfiguration,
 			EventTypes.Normal,
@@ -138,8 +177,9 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	newStatus.SetSynchronizationState(state, message)
 
 	if !reflect.DeepEqual(newStatus, runConfiguration.Status) {
+		oldStatus := runConfiguration.Status
 		runConfiguration.Status = newStatus
-		if err := r.EC.Client.Status().Update(ctx, runConfiguration); err != nil {
+		if err := r.updateStatus(ctx, runConfiguration, oldStatus); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
@@ -150,6 +190,89 @@ func (r *RunConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{}, nil
 }
 
+func (r *RunConfigurationReconciler) updateStatus(
+	ctx context.Context,
+	runConfiguration *pipelineshub.RunConfiguration,
+	oldStatus pipelineshub.RunConfigurationStatus,
+) error {
+	if err := r.EC.Client.Status().Update(ctx, runConfiguration); err != nil {
+		return err
+	}
+
+	r.EC.Metrics.RecordTransition(
+		ctx,
+		runConfiguration.GetKind(),
+		runConfiguration,
+		oldStatus.ObservedGeneration,
+		oldStatus.Conditions,
+		runConfiguration.Status.Conditions,
+	)
+
+	return nil
+}
+
+// findDependencyCycle guards against cycles that predate admission validation
+// or were created while it was unavailable, which would otherwise trigger runs
+// indefinitely.
+func (r *RunConfigurationReconciler) findDependencyCycle(
+	ctx context.Context,
+	runConfiguration *pipelineshub.RunConfiguration,
+) (pipelineshub.DependencyCycle, error) {
+	if len(runConfiguration.GetReferencedRCs()) == 0 {
+		return nil, nil
+	}
+
+	runConfigurations := &pipelineshub.RunConfigurationList{}
+	if err := r.EC.Client.Cached.List(ctx, runConfigurations); err != nil {
+		return nil, err
+	}
+
+	return pipelineshub.FindDependencyCycle(runConfiguration, runConfigurations.Items), nil
+}
+
+// plan stores what would be submitted to the provider for the RunSchedules
+// and Runs of the RunConfiguration instead of creating them.
+func (r *RunConfigurationReconciler) plan(
+	ctx context.Context,
+	runConfiguration *pipelineshub.RunConfiguration,
+) error {
+	logger := log.FromContext(ctx)
+
+	provider, err := r.loadProvider(ctx, runConfiguration.Spec.Run.Provider)
+	if err != nil {
+		return err
+	}
+
+	providerSvc, err := r.ServiceManager.Get(ctx, &provider)
+	if err != nil {
+		return err
+	}
+
+	existingSchedules, err := findOwnedRunSchedules(ctx, r.EC.Client.NonCached, runConfiguration)
+	if err != nil {
+		return err
+	}
+
+	planner := Planner{Config: r.Config, Scheme: r.Scheme}
+	plan, err := planner.PlanRunConfiguration(provider, *providerSvc, runConfiguration, existingSchedules)
+	if err != nil {
+		logger.Error(err, "unable to plan run configuration")
+		return storePlan(ctx, r.EC, runConfiguration, runConfiguration.GetKind(), StorePlan{Error: err.Error()})
+	}
+
+	return storePlan(ctx, r.EC, runConfiguration, runConfiguration.GetKind(), StorePlan{Plan: plan})
+}
+
+func (r *RunConfigurationReconciler) loadProvider(
+	ctx context.Context,
+	providerName common.NamespacedName,
+) (pipelineshub.Provider, error) {
+	provider := pipelineshub.Provider{}
+	err := r.EC.Client.NonCached.Get(ctx, types.NamespacedName{Name: providerName.Name, Namespace: providerName.Namespace}, &provider)
+
+	return provider, err
+}
+
 func (r *RunConfigurationReconciler) triggerUntriggeredRuns(
 	ctx context.Context,
 	runConfiguration *pipelineshub.RunConfiguration,
@@ -396,7 +519,7 @@ func (r *RunConfigurationReconciler) reconciliationRequestsForRunConfiguration(
 func (r *RunConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	runConfiguration := &pipelineshub.RunConfiguration{}
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(runConfiguration)
+		For(runConfiguration, builder.WithPredicates(r.EC.Scope.Predicate()))
 
 	controllerBuilder, err := r.DependingOnPipelineReconciler.setupWithManager(
 		mgr,
@@ -417,7 +540,7 @@ func (r *RunConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	return controllerBuilder.Owns(&pipelineshub.RunSchedule{}).Complete(r)
+	return controllerBuilder.Owns(&pipelineshub.RunSchedule{}, builder.WithPredicates(r.EC.Scope.Predicate())).Complete(r)
 }
 
 func findOwnedRunSchedules(
@@ -474,9 +597,9 @@ func (r *RunConfigurationReconciler) constructRunForRunConfiguration(
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: runConfiguration.Name + "-",
 			Namespace:    runConfiguration.Namespace,
-			Labels: map[string]string{
+			Labels: lo.Assign(runConfiguration.GetLabels(), map[string]string{
 				workflowfactory.RunConfigurationConstants.RunConfigurationNameLabelKey: runConfiguration.GetName(),
-			},
+			}),
 		},
 		Spec: spec,
 	}
@@ -499,6 +622,7 @@ func (r *RunConfigurationReconciler) constructRunSchedulesForTriggers(
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: runConfiguration.Name + "-",
 				Namespace:    runConfiguration.Namespace,
+				Labels:       maps.Clone(runConfiguration.GetLabels()),
 			},
 			Spec: pipelineshub.RunScheduleSpec{
 				Provider: runConfiguration.Spec.Run.Provider,
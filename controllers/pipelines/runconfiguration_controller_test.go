@@ -62,6 +62,17 @@ var _ = Context("constructRunForRunConfiguration", PropertyBased, func() {
 
 		Expect(run.GetLabels()).To(HaveKeyWithValue(workflowfactory.RunConfigurationConstants.RunConfigurationNameLabelKey, runConfiguration.GetName()))
 	})
+
+	It("propagates the runconfiguration's labels", func() {
+		runConfiguration := pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
+		runConfiguration.SetLabels(map[string]string{"tenant": "a"})
+
+		run, err := rcr.constructRunForRunConfiguration(runConfiguration)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(run.GetLabels()).To(HaveKeyWithValue("tenant", "a"))
+		Expect(runConfiguration.GetLabels()).To(HaveLen(1))
+	})
 })
 
 var _ = Context("constructRunSchedulesForTriggers", PropertyBased, func() {
@@ -91,6 +102,18 @@ var _ = Context("constructRunSchedulesForTriggers", PropertyBased, func() {
 			Expect(schedule.Spec.Provider).To(Equal(runConfiguration.Spec.Run.Provider))
 		}
 	})
+
+	It("propagates the runconfiguration's labels", func() {
+		runConfiguration := pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
+		runConfiguration.SetLabels(map[string]string{"tenant": "a"})
+		runConfiguration.Spec.Triggers = pipelineshub.Triggers{Schedules: []pipelineshub.Schedule{pipelineshub.RandomSchedule()}}
+
+		runSchedules, err := rcr.constructRunSchedulesForTriggers(runConfiguration, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(runSchedules).To(HaveLen(1))
+		Expect(runSchedules[0].GetLabels()).To(Equal(map[string]string{"tenant": "a"}))
+	})
 })
 
 var _ = Context("updateRcTriggers", PropertyBased, func() {
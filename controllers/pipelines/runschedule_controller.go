@@ -9,6 +9,7 @@ import (
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
 	"github.com/sky-uk/kfp-operator/internal/config"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -29,6 +30,11 @@ func NewRunScheduleReconciler(
 		StateHandler: StateHandler[*pipelineshub.RunSchedule]{
 			WorkflowRepository: workflowRepository,
 			WorkflowFactory:    workflowfactory.RunScheduleWorkflowFactory(config),
+			Namespaces:         ec.Client.Cached,
+			DirectOperations:   NewDirectOperations(NewHttpDirectExecutor()),
+			ResourceGetter:     NewHttpProviderResourceClient(config.DefaultProviderValues.ServicePort),
+			Throttle:           NewWorkflowLimitThrottle(ec.Client.Cached),
+			Retry:              config.Retry,
 		},
 		ResourceReconciler: ResourceReconciler[*pipelineshub.RunSchedule]{
 			EC:     ec,
@@ -58,6 +64,11 @@ func (r *RunScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !r.EC.Scope.Contains(ctx, runSchedule) {
+		logger.V(2).Info("ignoring resource outside of the scope")
+		return ctrl.Result{}, nil
+	}
+
 	logger.V(3).Info("found run schedule", "resource", runSchedule)
 
 	provider, err := r.LoadProvider(ctx, runSchedule.Spec.Provider)
@@ -83,15 +94,15 @@ func (r *RunScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	duration := time.Now().Sub(startTime)
 	logger.V(2).Info("reconciliation ended", logkeys.Duration, duration)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter(commands)}, nil
 }
 
 func (r *RunScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	runSchedule := &pipelineshub.RunSchedule{}
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
-		For(runSchedule)
+		For(runSchedule, builder.WithPredicates(r.EC.Scope.Predicate()))
 
-	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, runSchedule)
+	controllerBuilder = r.ResourceReconciler.setupWithManager(controllerBuilder, runSchedule, &pipelineshub.RunScheduleList{})
 
 	return controllerBuilder.Complete(r)
 }
@@ -0,0 +1,162 @@
+package pipelines
+
+import (
+	"context"
+	"slices"
+
+	"github.com/samber/lo"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Scope decides which objects an instance of the operator manages. The zero
+// value manages all objects.
+type Scope struct {
+	namespaces        []string
+	namespaceSelector labels.Selector
+	labelSelector     labels.Selector
+	// Namespaces is used to look up namespace labels when namespaces are
+	// selected by their labels.
+	Namespaces client.Reader
+}
+
+func NewScope(scope config.Scope, namespaces client.Reader) (Scope, error) {
+	s := Scope{
+		namespaces: scope.Namespaces,
+		Namespaces: namespaces,
+	}
+
+	if scope.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(scope.NamespaceSelector)
+		if err != nil {
+			return Scope{}, err
+		}
+		s.namespaceSelector = selector
+	}
+
+	if scope.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(scope.LabelSelector)
+		if err != nil {
+			return Scope{}, err
+		}
+		s.labelSelector = selector
+	}
+
+	return s, nil
+}
+
+func (s Scope) restrictsNamespaces() bool {
+	return len(s.namespaces) > 0 || s.namespaceSelector != nil
+}
+
+// containsNamespaceWithLabels returns true if the namespace is listed or its
+// labels match the namespace selector.
+func (s Scope) containsNamespaceWithLabels(namespace string, namespaceLabels map[string]string) bool {
+	if !s.restrictsNamespaces() || lo.Contains(s.namespaces, namespace) {
+		return true
+	}
+
+	return s.namespaceSelector != nil && s.namespaceSelector.Matches(labels.Set(namespaceLabels))
+}
+
+func (s Scope) ContainsNamespace(ctx context.Context, namespace string) bool {
+	if !s.restrictsNamespaces() || lo.Contains(s.namespaces, namespace) {
+		return true
+	}
+
+	if s.namespaceSelector == nil || s.Namespaces == nil {
+		return false
+	}
+
+	ns := &corev1.Namespace{}
+	if err := s.Namespaces.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		log.FromContext(ctx).Error(err, "unable to look up namespace labels", "namespace", namespace)
+		return false
+	}
+
+	return s.containsNamespaceWithLabels(namespace, ns.Labels)
+}
+
+// Contains returns true if the resource is in a namespace of the scope and
+// its labels match the label selector.
+func (s Scope) Contains(ctx context.Context, resource client.Object) bool {
+	if s.labelSelector != nil && !s.labelSelector.Matches(labels.Set(resource.GetLabels())) {
+		return false
+	}
+
+	return s.ContainsNamespace(ctx, resource.GetNamespace())
+}
+
+// Predicate filters events of resources outside the scope.
+func (s Scope) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return s.Contains(context.Background(), object)
+	})
+}
+
+// NamespacedPredicate filters events of objects in namespaces outside the
+// scope regardless of their labels, e.g. for objects created by the operator.
+func (s Scope) NamespacedPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return s.ContainsNamespace(context.Background(), object.GetNamespace())
+	})
+}
+
+// NamespacePredicate filters events of namespaces outside the scope.
+func (s Scope) NamespacePredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(namespace client.Object) bool {
+		return s.containsNamespaceWithLabels(namespace.GetName(), namespace.GetLabels())
+	})
+}
+
+// WorkflowPredicate filters events of workflows whose owners are in
+// namespaces outside the scope.
+func (s Scope) WorkflowPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(workflow client.Object) bool {
+		return s.ContainsNamespace(context.Background(), workflow.GetLabels()[workflowconstants.OwnerNamespaceLabelKey])
+	})
+}
+
+// CacheOptions restricts the cache of the manager to the scope. The cache is
+// only restricted to namespaces that are listed explicitly since a selector
+// may match namespaces that are created or relabelled later; events of
+// resources in other namespaces are filtered by the predicates instead. The
+// label selector applies to the resources of the operator only because the
+// objects it creates, such as workflows, do not carry the labels of their
+// owners. The operator namespaces, such as the namespace of the providers
+// that legacy resources default to, are cached in addition to the listed
+// namespaces since resources in scope depend on objects in them.
+func (s Scope) CacheOptions(operatorNamespaces ...string) cache.Options {
+	options := cache.Options{}
+
+	if len(s.namespaces) > 0 && s.namespaceSelector == nil {
+		namespaces := lo.Uniq(lo.Compact(append(slices.Clone(s.namespaces), operatorNamespaces...)))
+		options.DefaultNamespaces = lo.SliceToMap(namespaces, func(namespace string) (string, cache.Config) {
+			return namespace, cache.Config{}
+		})
+	}
+
+	if s.labelSelector != nil {
+		options.ByObject = map[client.Object]cache.ByObject{}
+		for _, resource := range []client.Object{
+			&pipelineshub.Pipeline{},
+			&pipelineshub.Run{},
+			&pipelineshub.RunConfiguration{},
+			&pipelineshub.RunSchedule{},
+			&pipelineshub.Experiment{},
+			&pipelineshub.Provider{},
+		} {
+			options.ByObject[resource] = cache.ByObject{Label: s.labelSelector}
+		}
+	}
+
+	return options
+}
@@ -0,0 +1,176 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("Scope", func() {
+	ctx := context.Background()
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	pipelineIn := func(namespace string, labels map[string]string) *pipelineshub.Pipeline {
+		return &pipelineshub.Pipeline{ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: namespace, Labels: labels}}
+	}
+
+	newScope := func(scopeConfig config.Scope) Scope {
+		scheme := runtime.NewScheme()
+		Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+
+		namespaces := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				namespace("tenant-a", map[string]string{"tenant": "a"}),
+				namespace("tenant-b", map[string]string{"tenant": "b"}),
+			).
+			Build()
+
+		scope, err := NewScope(scopeConfig, namespaces)
+		Expect(err).NotTo(HaveOccurred())
+		return scope
+	}
+
+	It("contains everything when unrestricted", func() {
+		Expect(Scope{}.Contains(ctx, pipelineIn("any", nil))).To(BeTrue())
+	})
+
+	It("contains resources in listed namespaces", func() {
+		scope := newScope(config.Scope{Namespaces: []string{"listed"}})
+
+		Expect(scope.Contains(ctx, pipelineIn("listed", nil))).To(BeTrue())
+		Expect(scope.Contains(ctx, pipelineIn("tenant-a", nil))).To(BeFalse())
+	})
+
+	It("contains resources in namespaces matching the selector", func() {
+		scope := newScope(config.Scope{
+			Namespaces:        []string{"listed"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+		})
+
+		Expect(scope.Contains(ctx, pipelineIn("listed", nil))).To(BeTrue())
+		Expect(scope.Contains(ctx, pipelineIn("tenant-a", nil))).To(BeTrue())
+		Expect(scope.Contains(ctx, pipelineIn("tenant-b", nil))).To(BeFalse())
+		Expect(scope.Contains(ctx, pipelineIn("missing", nil))).To(BeFalse())
+	})
+
+	It("contains resources matching the label selector", func() {
+		scope := newScope(config.Scope{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"instance": "canary"}},
+		})
+
+		Expect(scope.Contains(ctx, pipelineIn("any", map[string]string{"instance": "canary"}))).To(BeTrue())
+		Expect(scope.Contains(ctx, pipelineIn("any", nil))).To(BeFalse())
+	})
+
+	It("rejects invalid selectors", func() {
+		_, err := NewScope(config.Scope{
+			LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "instance",
+				Operator: "invalid",
+			}}},
+		}, nil)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("predicates", func() {
+		scope := func() Scope {
+			return newScope(config.Scope{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"instance": "canary"}},
+			})
+		}
+
+		It("filters resources by namespace and labels", func() {
+			predicate := scope().Predicate()
+
+			Expect(predicate.Create(event.CreateEvent{Object: pipelineIn("tenant-a", map[string]string{"instance": "canary"})})).To(BeTrue())
+			Expect(predicate.Create(event.CreateEvent{Object: pipelineIn("tenant-a", nil)})).To(BeFalse())
+			Expect(predicate.Create(event.CreateEvent{Object: pipelineIn("tenant-b", map[string]string{"instance": "canary"})})).To(BeFalse())
+		})
+
+		It("filters objects created by the operator by namespace only", func() {
+			predicate := scope().NamespacedPredicate()
+
+			Expect(predicate.Create(event.CreateEvent{Object: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a"}}})).To(BeTrue())
+			Expect(predicate.Create(event.CreateEvent{Object: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-b"}}})).To(BeFalse())
+		})
+
+		It("filters namespaces by their labels", func() {
+			predicate := scope().NamespacePredicate()
+
+			Expect(predicate.Update(event.UpdateEvent{
+				ObjectOld: namespace("relabelled", nil),
+				ObjectNew: namespace("relabelled", map[string]string{"tenant": "a"}),
+			})).To(BeTrue())
+			Expect(predicate.Create(event.CreateEvent{Object: namespace("tenant-b", map[string]string{"tenant": "b"})})).To(BeFalse())
+		})
+
+		It("filters workflows by the namespace of their owner", func() {
+			predicate := scope().WorkflowPredicate()
+			workflow := func(ownerNamespace string) *argo.Workflow {
+				return &argo.Workflow{ObjectMeta: metav1.ObjectMeta{
+					Namespace: "provider-namespace",
+					Labels:    map[string]string{workflowconstants.OwnerNamespaceLabelKey: ownerNamespace},
+				}}
+			}
+
+			Expect(predicate.Create(event.CreateEvent{Object: workflow("tenant-a")})).To(BeTrue())
+			Expect(predicate.Create(event.CreateEvent{Object: workflow("tenant-b")})).To(BeFalse())
+		})
+	})
+
+	Describe("CacheOptions", func() {
+		It("restricts the cache to listed namespaces", func() {
+			options := newScope(config.Scope{Namespaces: []string{"a", "b"}}).CacheOptions()
+
+			Expect(options.DefaultNamespaces).To(HaveLen(2))
+			Expect(options.DefaultNamespaces).To(HaveKey("a"))
+			Expect(options.DefaultNamespaces).To(HaveKey("b"))
+		})
+
+		It("caches the operator namespaces in addition to listed namespaces", func() {
+			options := newScope(config.Scope{Namespaces: []string{"a", "b"}}).CacheOptions("operator", "a", "")
+
+			Expect(options.DefaultNamespaces).To(HaveLen(3))
+			Expect(options.DefaultNamespaces).To(HaveKey("operator"))
+		})
+
+		It("does not restrict the cache to namespaces when they are selected", func() {
+			options := newScope(config.Scope{
+				Namespaces:        []string{"a"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+			}).CacheOptions()
+
+			Expect(options.DefaultNamespaces).To(BeEmpty())
+		})
+
+		It("applies the label selector to the resources of the operator only", func() {
+			options := newScope(config.Scope{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"instance": "canary"}},
+			}).CacheOptions()
+
+			Expect(options.ByObject).To(HaveLen(6))
+			Expect(options.ByObject).To(HaveKey(&pipelineshub.Pipeline{}))
+			Expect(options.ByObject).NotTo(HaveKey(&argo.Workflow{}))
+			Expect(options.DefaultLabelSelector).To(BeNil())
+		})
+	})
+})
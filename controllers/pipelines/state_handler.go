@@ -4,28 +4,241 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/samber/lo"
 	"github.com/sky-uk/kfp-operator/apis"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowfactory"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowutil"
+	"github.com/sky-uk/kfp-operator/internal/config"
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type StateHandler[R pipelineshub.Resource] struct {
 	WorkflowFactory    workflowfactory.WorkflowFactory[R]
 	WorkflowRepository WorkflowRepository
+	// Namespaces is used to look up namespace labels when a provider restricts
+	// namespaces with a selector.
+	Namespaces client.Reader
+	// DirectOperations calls the provider-service for providers in the direct
+	// execution mode. Workflows are submitted to Argo when it is not set.
+	DirectOperations *DirectOperations
+	// ResourceGetter verifies provider resources that are adopted with the
+	// AdoptProviderIdAnnotation. Adoption is not supported when it is not set.
+	ResourceGetter ProviderResourceGetter
+	// Throttle holds resources in their current state while the workflow
+	// limits of their provider are reached. Workflows are not limited when
+	// it is not set.
+	Throttle WorkflowThrottle
+	// Retry resubmits the workflows of resources whose creation or update
+	// failed.
+	Retry config.RetryPolicy
 }
 
 var StateHandlerConstants = struct {
-	ProviderChangedError string
+	ProviderChangedError     string
+	DependencyCycleError     string
+	NamespaceNotAllowedError string
+	AdoptionFailedError      string
+	ProviderResourceRetained string
+	OperationFailedError     string
 }{
-	ProviderChangedError: "the provider has changed",
+	ProviderChangedError:     "the provider has changed",
+	DependencyCycleError:     "dependency cycle detected",
+	NamespaceNotAllowedError: "namespace is not allowed by the provider",
+	AdoptionFailedError:      "failed to adopt provider resource",
+	ProviderResourceRetained: "provider resource retained by the deletion policy",
+	OperationFailedError:     "operation failed",
+}
+
+func (st *StateHandler[R]) namespaceLabels(ctx context.Context, namespace string) func() (map[string]string, error) {
+	return func() (map[string]string, error) {
+		if st.Namespaces == nil {
+			return nil, errors.New("no reader configured to look up namespace labels")
+		}
+
+		ns := &corev1.Namespace{}
+		if err := st.Namespaces.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+			return nil, err
+		}
+
+		return ns.Labels, nil
+	}
+}
+
+// onNamespaceNotAllowed fails resources in namespaces the provider does not
+// allow and returns false for resources that may proceed. The version is
+// cleared so that the resource is synchronised again once its namespace is
+// allowed. Resources are left untouched and reconciled again when the
+// namespace cannot be looked up.
+func (st *StateHandler[R]) onNamespaceNotAllowed(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	resource R,
+	transitionTime metav1.Time,
+) ([]Command, bool) {
+	logger := log.FromContext(ctx)
+
+	allowed, err := provider.AllowsNamespace(resource.GetNamespace(), st.namespaceLabels(ctx, resource.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "unable to determine whether the namespace is allowed by the provider")
+		return []Command{Abort{Err: err}}, true
+	}
+
+	if allowed {
+		return nil, false
+	}
+
+	message := fmt.Sprintf("%s: %s", StateHandlerConstants.NamespaceNotAllowedError, resource.GetNamespace())
+	condition := resource.GetStatus().Conditions.SynchronizationSucceeded()
+	if condition.Reason == string(apis.Failed) && condition.Message == message {
+		return []Command{}, true
+	}
+
+	return []Command{
+		*From(resource.GetStatus()).
+			WithVersion("").
+			WithSyncStateCondition(apis.Failed, transitionTime, message),
+	}, true
+}
+
+// executesDirectly returns true if the workflow is executed by calling the
+// provider-service from the controller rather than by Argo. Workflows that
+// compile resources always run in Argo.
+func (st StateHandler[R]) executesDirectly(provider pipelineshub.Provider, workflow *argo.Workflow) bool {
+	if st.DirectOperations == nil || !provider.ExecutesDirectly() {
+		return false
+	}
+
+	templateRef := workflow.Spec.WorkflowTemplateRef
+	return templateRef == nil || !strings.HasSuffix(templateRef.Name, workflowfactory.CompiledSuffix)
+}
+
+// submit returns the commands that transition the resource into the
+// in-progress state and submit the workflow to Argo. Workflows that are
+// executed directly are recorded as the operation in progress with the
+// transition and run in the background once it has been persisted.
+func (st StateHandler[R]) submit(provider pipelineshub.Provider, inProgress SetStatus, workflow *argo.Workflow) []Command {
+	if st.executesDirectly(provider, workflow) {
+		operation := providerOperationFor(inProgress.Status.Conditions.GetSyncStateFromReason())
+		return st.startDirectOperation(inProgress, operation, workflow)
+	}
+
+	return []Command{inProgress, CreateWorkflow{Workflow: *workflow}}
+}
+
+func providerOperationFor(state apis.SynchronizationState) ProviderOperation {
+	switch state {
+	case apis.Creating:
+		return ProviderOperations.Create
+	case apis.Updating:
+		return ProviderOperations.Update
+	default:
+		return ProviderOperations.Delete
+	}
+}
+
+// startDirectOperation records a new operation with the status and starts it
+// once the status has been updated. The resource is reconciled until the
+// operation has finished.
+func (st StateHandler[R]) startDirectOperation(status SetStatus, operation ProviderOperation, workflow *argo.Workflow) []Command {
+	id := string(uuid.NewUUID())
+
+	return []Command{
+		*status.WithOperation(&pipelineshub.OperationStatus{Name: string(operation), Id: id}),
+		StartDirectOperation{Operations: st.DirectOperations, Id: id, Operation: operation, Workflow: *workflow},
+		Requeue{After: DirectOperationPollInterval},
+	}
+}
+
+// executeDirectly records the result of the operation of an in-progress
+// resource when the provider is in the direct execution mode. It returns
+// false if the operation is left to Argo, which includes workflows submitted
+// before the provider switched to the direct execution mode.
+//
+// Operations whose result is unknown, because they were started by a previous
+// instance of the manager, are repeated with the id they were recorded with.
+// The provider service responds to repeated calls with the response of the
+// first, so that the operation is not performed twice.
+func (st StateHandler[R]) executeDirectly(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource R,
+	workflows []argo.Workflow,
+	operation ProviderOperation,
+	construct func(pipelineshub.Provider, corev1.Service, R) (*argo.Workflow, error),
+	states IdVerifier,
+	transitionTime metav1.Time,
+) ([]Command, bool) {
+	if len(workflows) > 0 || st.DirectOperations == nil || !provider.ExecutesDirectly() {
+		return nil, false
+	}
+
+	logger := log.FromContext(ctx)
+
+	status := resource.GetStatus()
+	recorded := status.Operation
+	status.Operation = nil
+
+	var resumed *pipelineshub.OperationStatus
+	if recorded != nil && recorded.Name == string(operation) {
+		result, started := st.DirectOperations.Result(recorded.Id)
+
+		switch {
+		case started && result == nil:
+			return []Command{Requeue{After: DirectOperationPollInterval}}, true
+		case started && result.Err != nil:
+			failureMessage := fmt.Sprintf("%s: %s", StateHandlerConstants.OperationFailedError, result.Err)
+			logger.Error(result.Err, fmt.Sprintf("%s, failing resource", failureMessage))
+
+			return []Command{
+				*From(status).
+					WithSyncStateCondition(states.FailureState, transitionTime, failureMessage),
+			}, true
+		case started:
+			return []Command{
+				*st.statusFromProviderOutput(ctx, status, provider.GetCommonNamespacedName(), result.Output, states, transitionTime),
+			}, true
+		default:
+			logger.Info("outcome of the operation is unknown, repeating it", "operation", recorded.Name, "id", recorded.Id)
+			resumed = recorded
+		}
+	}
+
+	workflow, err := construct(provider, providerSvc, resource)
+	if err != nil {
+		failureMessage := workflowconstants.ConstructionFailedError
+		logger.Error(err, fmt.Sprintf("%s, failing resource", failureMessage))
+
+		return []Command{
+			*From(status).
+				WithSyncStateCondition(states.FailureState, transitionTime, failureMessage),
+		}, true
+	}
+
+	if !st.executesDirectly(provider, workflow) {
+		return nil, false
+	}
+
+	if resumed != nil {
+		return []Command{
+			StartDirectOperation{Operations: st.DirectOperations, Id: resumed.Id, Operation: operation, Workflow: *workflow},
+			Requeue{After: DirectOperationPollInterval},
+		}, true
+	}
+
+	return st.startDirectOperation(*From(status), operation, workflow), true
 }
 
 func (st *StateHandler[R]) stateTransition(
@@ -45,6 +258,8 @@ func (st *StateHandler[R]) stateTransition(
 		case apis.Creating:
 			commands = st.onCreating(
 				ctx,
+				provider,
+				providerSvc,
 				resource,
 				st.WorkflowRepository.GetByLabels(ctx, workflowconstants.CommonWorkflowLabels(resource), provider.Namespace),
 				transitionTime,
@@ -52,12 +267,16 @@ func (st *StateHandler[R]) stateTransition(
 		case apis.Succeeded, apis.Failed:
 			if !resource.GetDeletionTimestamp().IsZero() {
 				commands = st.onDelete(ctx, provider, providerSvc, resource, transitionTime)
+			} else if notAllowed, failed := st.onNamespaceNotAllowed(ctx, provider, resource, transitionTime); failed {
+				commands = notAllowed
 			} else {
 				commands = st.onSucceededOrFailed(ctx, provider, providerSvc, resource, transitionTime)
 			}
 		case apis.Updating:
 			commands = st.onUpdating(
 				ctx,
+				provider,
+				providerSvc,
 				resource,
 				st.WorkflowRepository.GetByLabels(ctx, workflowconstants.CommonWorkflowLabels(resource), provider.Namespace),
 				transitionTime,
@@ -65,13 +284,19 @@ func (st *StateHandler[R]) stateTransition(
 		case apis.Deleting:
 			commands = st.onDeleting(
 				ctx,
+				provider,
+				providerSvc,
 				resource,
 				st.WorkflowRepository.GetByLabels(ctx, workflowconstants.CommonWorkflowLabels(resource), provider.Namespace),
 				transitionTime,
 			)
 		case apis.Deleted:
 		default:
-			commands = st.onUnknown(ctx, provider, providerSvc, resource, transitionTime)
+			if notAllowed, failed := st.onNamespaceNotAllowed(ctx, provider, resource, transitionTime); failed {
+				commands = notAllowed
+			} else {
+				commands = st.onUnknown(ctx, provider, providerSvc, resource, transitionTime)
+			}
 		}
 	}
 
@@ -93,11 +318,114 @@ func (st *StateHandler[R]) StateTransition(
 	logger := log.FromContext(ctx)
 	logger.V(2).Info("state transition start")
 
+	if pipelineshub.IsDryRun(resource) && resource.GetDeletionTimestamp().IsZero() {
+		return st.plan(ctx, provider, providerSvc, resource)
+	}
+
 	time := metav1.Now()
-	stateTransitionCommands := st.stateTransition(ctx, provider, providerSvc, resource, time)
+	stateTransitionCommands := st.scheduleRetry(ctx, resource, st.stateTransition(ctx, provider, providerSvc, resource, time), time)
+	if throttledCommands, throttled := st.throttle(ctx, provider, resource, stateTransitionCommands, time); throttled {
+		return throttledCommands
+	}
+
 	return alwaysSetObservedGeneration(ctx, stateTransitionCommands, resource, time)
 }
 
+// plan renders the workflow that would synchronise the resource instead of
+// submitting it. The status of the resource is left untouched so that it is
+// synchronised as usual once the DryRunAnnotation is removed.
+func (st *StateHandler[R]) plan(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource R,
+) []Command {
+	plan, err := st.WorkflowFactory.ConstructPlan(provider, providerSvc, resource)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to plan resource")
+		return []Command{StorePlan{Error: err.Error()}}
+	}
+
+	return []Command{StorePlan{Plan: plan}}
+}
+
+// throttle replaces commands that create a workflow while the provider's
+// workflow limits are reached. The resource keeps its status, apart from the
+// Throttled condition, and is reconciled again once capacity may have freed
+// up. The Throttled condition is removed once workflows are admitted.
+func (st *StateHandler[R]) throttle(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	resource R,
+	commands []Command,
+	transitionTime metav1.Time,
+) ([]Command, bool) {
+	if st.Throttle == nil {
+		return commands, false
+	}
+
+	createsWorkflow := false
+	for i, command := range commands {
+		switch c := command.(type) {
+		case CreateWorkflow:
+			createsWorkflow = true
+		case SetStatus:
+			c.Status.Conditions = withoutCondition(c.Status.Conditions, apis.ConditionTypes.Throttled)
+			commands[i] = c
+		}
+	}
+
+	if !createsWorkflow {
+		return commands, false
+	}
+
+	logger := log.FromContext(ctx)
+
+	throttling, err := st.Throttle.Throttle(ctx, provider)
+	if err != nil {
+		logger.Error(err, "unable to determine whether workflows are throttled, submitting workflow")
+		return commands, false
+	}
+
+	if throttling == nil {
+		return commands, false
+	}
+
+	logger.Info("throttling workflow submission", "reason", throttling.Reason, "retryAfter", throttling.RetryAfter)
+
+	throttledCommands := []Command{AcquireResource{}}
+
+	existing, found := lo.Find(resource.GetStatus().Conditions, func(condition metav1.Condition) bool {
+		return condition.Type == apis.ConditionTypes.Throttled
+	})
+	if !found || existing.Reason != throttling.Reason || existing.Message != throttling.Message {
+		throttledCommands = append(throttledCommands, SetThrottled{
+			Condition: metav1.Condition{
+				Type:               apis.ConditionTypes.Throttled,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: resource.GetStatus().ObservedGeneration,
+				LastTransitionTime: transitionTime,
+				Reason:             throttling.Reason,
+				Message:            throttling.Message,
+			},
+		})
+	}
+
+	return append(throttledCommands, Requeue{After: throttling.RetryAfter}), true
+}
+
+func withoutCondition(conditions apis.Conditions, conditionType string) apis.Conditions {
+	if !lo.ContainsBy(conditions, func(condition metav1.Condition) bool {
+		return condition.Type == conditionType
+	}) {
+		return conditions
+	}
+
+	return lo.Filter(conditions, func(condition metav1.Condition, _ int) bool {
+		return condition.Type != conditionType
+	})
+}
+
 func (st *StateHandler[R]) onUnknown(
 	ctx context.Context,
 	provider pipelineshub.Provider,
@@ -124,12 +452,13 @@ func (st *StateHandler[R]) onUnknown(
 			}
 		}
 
-		return []Command{
-			*From(resource.GetStatus()).
-				WithSyncStateCondition(apis.Updating, transitionTime, "").
-				WithVersion(newVersion),
-			CreateWorkflow{Workflow: *workflow},
-		}
+		return st.submit(provider, *From(resource.GetStatus()).
+			WithSyncStateCondition(apis.Updating, transitionTime, "").
+			WithVersion(newVersion), workflow)
+	}
+
+	if providerId, adopt := st.adoptionId(resource); adopt {
+		return st.onAdopt(ctx, provider, providerSvc, resource, providerId, transitionTime)
 	}
 
 	logger.V(2).Info("empty state, creating resource")
@@ -157,10 +486,7 @@ func (st *StateHandler[R]) onUnknown(
 
 	status.WithSyncStateCondition(apis.Creating, transitionTime, "")
 
-	return []Command{
-		status,
-		CreateWorkflow{Workflow: *workflow},
-	}
+	return st.submit(provider, status, workflow)
 }
 
 func (st StateHandler[R]) onDelete(
@@ -180,6 +506,17 @@ func (st StateHandler[R]) onDelete(
 		}
 	}
 
+	if retainable, ok := any(resource).(pipelineshub.ResourceWithDeletionPolicy); ok &&
+		retainable.GetDeletionPolicy() == pipelineshub.DeletionPolicyRetain {
+		logger.Info("retaining provider resource", "providerId", resource.GetStatus().Provider.Id)
+
+		return []Command{
+			RecordRetainedResource{Provider: provider},
+			*From(resource.GetStatus()).
+				WithSyncStateCondition(apis.Deleted, transitionTime, StateHandlerConstants.ProviderResourceRetained),
+		}
+	}
+
 	workflow, err := st.WorkflowFactory.ConstructDeletionWorkflow(provider, providerSvc, resource)
 
 	if err != nil {
@@ -192,11 +529,8 @@ func (st StateHandler[R]) onDelete(
 		}
 	}
 
-	return []Command{
-		*From(resource.GetStatus()).
-			WithSyncStateCondition(apis.Deleting, transitionTime, ""),
-		CreateWorkflow{Workflow: *workflow},
-	}
+	return st.submit(provider, *From(resource.GetStatus()).
+		WithSyncStateCondition(apis.Deleting, transitionTime, ""), workflow)
 }
 
 func (st StateHandler[R]) onSucceededOrFailed(
@@ -210,14 +544,42 @@ func (st StateHandler[R]) onSucceededOrFailed(
 	newResourceVersion := resource.ComputeVersion()
 
 	if resource.GetStatus().Version == newResourceVersion {
+		if providerId, adopt := st.adoptionId(resource); adopt && adoptionFailed(resource) {
+			return st.onAdopt(ctx, provider, providerSvc, resource, providerId, transitionTime)
+		}
+
+		if commands, retrying := st.retry(ctx, provider, providerSvc, resource, transitionTime); retrying {
+			return commands
+		}
+
 		logger.V(2).Info("resource version has not changed")
 		return []Command{}
 	}
 
+	return st.synchronise(ctx, provider, providerSvc, resource, newResourceVersion, transitionTime, "")
+}
+
+// synchronise submits the workflow that creates or updates the resource on
+// the provider.
+func (st StateHandler[R]) synchronise(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource R,
+	newResourceVersion string,
+	transitionTime metav1.Time,
+	message string,
+) []Command {
+	logger := log.FromContext(ctx)
+
 	var workflow *argo.Workflow
 	var err error
 	var targetState apis.SynchronizationState
 
+	if adoptedId, adopt := st.adoptionId(resource); adopt {
+		return st.onAdopt(ctx, provider, providerSvc, resource, adoptedId, transitionTime)
+	}
+
 	providerId := resource.GetStatus().Provider.Id
 	if providerId == "" {
 		logger.V(2).Info("no providerId exists, creating")
@@ -261,12 +623,101 @@ func (st StateHandler[R]) onSucceededOrFailed(
 		targetState = apis.Updating
 	}
 
-	return []Command{
-		*From(resource.GetStatus()).
-			WithSyncStateCondition(targetState, transitionTime, "").
-			WithVersion(newResourceVersion),
-		CreateWorkflow{Workflow: *workflow},
+	return st.submit(provider, *From(resource.GetStatus()).
+		WithSyncStateCondition(targetState, transitionTime, message).
+		WithVersion(newResourceVersion), workflow)
+}
+
+// adoptionId returns the id of the provider resource to adopt for resources
+// that have not been created on the provider yet.
+func (st StateHandler[R]) adoptionId(resource R) (string, bool) {
+	if st.ResourceGetter == nil || resource.GetStatus().Provider.Id != "" {
+		return "", false
 	}
+
+	providerId := resource.GetAnnotations()[pipelineshub.AdoptProviderIdAnnotation]
+
+	return providerId, providerId != ""
+}
+
+// onAdopt verifies that the provider resource exists and updates it in place
+// of creating a new one.
+func (st StateHandler[R]) onAdopt(
+	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource R,
+	providerId string,
+	transitionTime metav1.Time,
+) []Command {
+	logger := log.FromContext(ctx)
+	newVersion := resource.ComputeVersion()
+
+	if resource.GetStatus().Version == newVersion && adoptionFailedFor(resource, providerId) {
+		logger.V(2).Info("adoption of provider resource already failed", "providerId", providerId)
+		return []Command{}
+	}
+
+	logger.Info("adopting provider resource", "providerId", providerId)
+
+	if _, err := st.ResourceGetter.Get(ctx, providerSvc, resource.GetKind(), providerId); err != nil {
+		logger.Error(err, fmt.Sprintf("%s, failing resource", StateHandlerConstants.AdoptionFailedError))
+
+		return []Command{
+			*From(resource.GetStatus()).
+				WithVersion(newVersion).
+				WithSyncStateCondition(
+					apis.Failed,
+					transitionTime,
+					fmt.Sprintf("%s %s: %s", StateHandlerConstants.AdoptionFailedError, providerId, err),
+				),
+		}
+	}
+
+	adopted := resource.DeepCopyObject().(R)
+	status := adopted.GetStatus()
+	status.Provider = pipelineshub.ProviderAndId{
+		Name: provider.GetCommonNamespacedName(),
+		Id:   providerId,
+	}
+	adopted.SetStatus(status)
+
+	workflow, err := st.WorkflowFactory.ConstructUpdateWorkflow(provider, providerSvc, adopted)
+	if err != nil {
+		failureMessage := workflowconstants.ConstructionFailedError
+		logger.Error(err, fmt.Sprintf("%s, failing resource", failureMessage))
+
+		return []Command{
+			*From(resource.GetStatus()).
+				WithVersion(newVersion).
+				WithSyncStateCondition(apis.Failed, transitionTime, failureMessage),
+		}
+	}
+
+	return append(
+		[]Command{ForgetRetainedResource{Provider: provider, Id: providerId}},
+		st.submit(provider, *From(status).
+			WithSyncStateCondition(apis.Updating, transitionTime, "").
+			WithVersion(newVersion), workflow)...,
+	)
+}
+
+// adoptionFailed returns true if the resource failed to adopt a provider
+// resource.
+func adoptionFailed(resource pipelineshub.Resource) bool {
+	condition := resource.GetStatus().Conditions.SynchronizationSucceeded()
+	return condition.Reason == string(apis.Failed) &&
+		strings.HasPrefix(condition.Message, StateHandlerConstants.AdoptionFailedError+" ")
+}
+
+// adoptionFailedFor returns true if the resource failed to adopt the given
+// provider resource. Adoption is only attempted again once the annotation or
+// the resource changes.
+func adoptionFailedFor(resource pipelineshub.Resource, providerId string) bool {
+	return adoptionFailed(resource) && strings.HasPrefix(
+		resource.GetStatus().Conditions.SynchronizationSucceeded().Message,
+		fmt.Sprintf("%s %s: ", StateHandlerConstants.AdoptionFailedError, providerId),
+	)
 }
 
 type IdVerifier struct {
@@ -299,6 +750,38 @@ var deletedForNonEmptyId = IdVerifier{
 	},
 }
 
+func (st StateHandler[R]) statusFromProviderOutput(
+	ctx context.Context,
+	status pipelineshub.Status,
+	provider common.NamespacedName,
+	result providers.Output,
+	states IdVerifier,
+	transitionTime metav1.Time,
+) *SetStatus {
+	logger := log.FromContext(ctx)
+	providerAndId := pipelineshub.ProviderAndId{Name: provider, Id: result.Id}
+
+	if result.ProviderError != "" {
+		logger.Error(errors.New(result.ProviderError), "provider error, failing resource")
+		return From(status).
+			WithProvider(providerAndId).
+			WithSyncStateCondition(states.FailureState, transitionTime, result.ProviderError)
+	}
+
+	err := states.VerifyId(result.Id)
+
+	if err != nil {
+		failureMessage := err.Error()
+		logger.Error(err, fmt.Sprintf("%s, failing resource", failureMessage))
+		return From(status).
+			WithSyncStateCondition(states.FailureState, transitionTime, failureMessage)
+	}
+
+	return From(status).
+		WithSyncStateCondition(states.SuccessState, transitionTime, "").
+		WithProvider(providerAndId)
+}
+
 func (st StateHandler[R]) setStateIfProviderFinished(
 	ctx context.Context,
 	status pipelineshub.Status,
@@ -326,27 +809,7 @@ func (st StateHandler[R]) setStateIfProviderFinished(
 			return handleWorkflowErr(err)
 		}
 
-		providerAndId := pipelineshub.ProviderAndId{Name: namespacedProvider, Id: result.Id}
-
-		if result.ProviderError != "" {
-			logger.Error(err, fmt.Sprintf("%s, failing resource", result.ProviderError))
-			return From(status).
-				WithProvider(providerAndId).
-				WithSyncStateCondition(states.FailureState, transitionTime, result.ProviderError)
-		}
-
-		err = states.VerifyId(result.Id)
-
-		if err != nil {
-			failureMessage := err.Error()
-			logger.Error(err, fmt.Sprintf("%s, failing resource", failureMessage))
-			return From(status).
-				WithSyncStateCondition(states.FailureState, transitionTime, failureMessage)
-		}
-
-		return From(status).
-			WithSyncStateCondition(states.SuccessState, transitionTime, "").
-			WithProvider(providerAndId)
+		return st.statusFromProviderOutput(ctx, status, namespacedProvider, result, states, transitionTime)
 	}
 
 	inProgress, succeeded, failed := workflowutil.LatestWorkflowByPhase(workflows)
@@ -365,7 +828,10 @@ func (st StateHandler[R]) setStateIfProviderFinished(
 		var failureMessage string
 
 		if failed != nil {
-			failureMessage = "operation failed"
+			failureMessage = StateHandlerConstants.OperationFailedError
+			if detail := workflowutil.GetFailureMessage(failed); detail != "" {
+				failureMessage = fmt.Sprintf("%s: %s", failureMessage, detail)
+			}
 		} else {
 			failureMessage = "operation progress unknown"
 		}
@@ -385,6 +851,8 @@ func (st StateHandler[R]) setStateIfProviderFinished(
 
 func (st StateHandler[R]) onCreating(
 	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
 	resource R,
 	creationWorkflows []argo.Workflow,
 	transitionTime metav1.Time,
@@ -401,11 +869,20 @@ func (st StateHandler[R]) onCreating(
 		}
 	}
 
+	if commands, executed := st.executeDirectly(
+		ctx, provider, providerSvc, resource, creationWorkflows,
+		ProviderOperations.Create, st.WorkflowFactory.ConstructCreationWorkflow, succeedForEmptyId, transitionTime,
+	); executed {
+		return commands
+	}
+
 	return st.setStateIfProviderFinished(ctx, resource.GetStatus(), creationWorkflows, succeedForEmptyId, transitionTime)
 }
 
 func (st StateHandler[R]) onUpdating(
 	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
 	resource R,
 	updateWorkflows []argo.Workflow,
 	transitionTime metav1.Time,
@@ -422,14 +899,30 @@ func (st StateHandler[R]) onUpdating(
 		}
 	}
 
+	if commands, executed := st.executeDirectly(
+		ctx, provider, providerSvc, resource, updateWorkflows,
+		ProviderOperations.Update, st.WorkflowFactory.ConstructUpdateWorkflow, succeedForEmptyId, transitionTime,
+	); executed {
+		return commands
+	}
+
 	return st.setStateIfProviderFinished(ctx, resource.GetStatus(), updateWorkflows, succeedForEmptyId, transitionTime)
 }
 
 func (st StateHandler[R]) onDeleting(
 	ctx context.Context,
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
 	resource R,
 	deletionWorkflows []argo.Workflow,
 	transitionTime metav1.Time,
 ) []Command {
+	if commands, executed := st.executeDirectly(
+		ctx, provider, providerSvc, resource, deletionWorkflows,
+		ProviderOperations.Delete, st.WorkflowFactory.ConstructDeletionWorkflow, deletedForNonEmptyId, transitionTime,
+	); executed {
+		return commands
+	}
+
 	return st.setStateIfProviderFinished(ctx, resource.GetStatus(), deletionWorkflows, deletedForNonEmptyId, transitionTime)
 }
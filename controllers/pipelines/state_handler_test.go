@@ -4,6 +4,7 @@ package pipelines
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
@@ -12,6 +13,7 @@ import (
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
 	"github.com/sky-uk/kfp-operator/apis"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowutil"
@@ -19,6 +21,7 @@ import (
 	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 type StateTransitionTestCase struct {
@@ -32,16 +35,18 @@ type StateTransitionTestCase struct {
 type TestWorkflowFactory struct {
 	CalledWithProvider    *pipelineshub.Provider
 	CalledWithProviderSvc *corev1.Service
+	CalledWithResource    *pipelineshub.TestResource
 	shouldFail            bool
 }
 
 func (f *TestWorkflowFactory) ConstructCreationWorkflow(
 	provider pipelineshub.Provider,
 	providerSvc corev1.Service,
-	_ *pipelineshub.TestResource,
+	resource *pipelineshub.TestResource,
 ) (*argo.Workflow, error) {
 	f.CalledWithProvider = &provider
 	f.CalledWithProviderSvc = &providerSvc
+	f.CalledWithResource = resource
 	if f.shouldFail {
 		return nil, fmt.Errorf("an error occurred")
 	}
@@ -51,10 +56,11 @@ func (f *TestWorkflowFactory) ConstructCreationWorkflow(
 func (f *TestWorkflowFactory) ConstructUpdateWorkflow(
 	provider pipelineshub.Provider,
 	providerSvc corev1.Service,
-	_ *pipelineshub.TestResource,
+	resource *pipelineshub.TestResource,
 ) (*argo.Workflow, error) {
 	f.CalledWithProvider = &provider
 	f.CalledWithProviderSvc = &providerSvc
+	f.CalledWithResource = resource
 	if f.shouldFail {
 		return nil, fmt.Errorf("an error occurred")
 	}
@@ -64,16 +70,31 @@ func (f *TestWorkflowFactory) ConstructUpdateWorkflow(
 func (f *TestWorkflowFactory) ConstructDeletionWorkflow(
 	provider pipelineshub.Provider,
 	providerSvc corev1.Service,
-	_ *pipelineshub.TestResource,
+	resource *pipelineshub.TestResource,
 ) (*argo.Workflow, error) {
 	f.CalledWithProvider = &provider
 	f.CalledWithProviderSvc = &providerSvc
+	f.CalledWithResource = resource
 	if f.shouldFail {
 		return nil, fmt.Errorf("an error occurred")
 	}
 	return &argo.Workflow{}, nil
 }
 
+func (f *TestWorkflowFactory) ConstructPlan(
+	provider pipelineshub.Provider,
+	providerSvc corev1.Service,
+	resource *pipelineshub.TestResource,
+) (*Plan, error) {
+	f.CalledWithProvider = &provider
+	f.CalledWithProviderSvc = &providerSvc
+	f.CalledWithResource = resource
+	if f.shouldFail {
+		return nil, fmt.Errorf("an error occurred")
+	}
+	return &Plan{}, nil
+}
+
 func (st StateTransitionTestCase) WorkflowConstructionFails() StateTransitionTestCase {
 	st.workflowFactory.shouldFail = true
 	return st
@@ -207,6 +228,11 @@ func (st StateTransitionTestCase) DeletionRequested() StateTransitionTestCase {
 	return st
 }
 
+func (st StateTransitionTestCase) WithDeletionPolicy(policy pipelineshub.DeletionPolicy) StateTransitionTestCase {
+	st.Experiment.DeletionPolicy = policy
+	return st
+}
+
 func anyNonDeletedState() apis.SynchronizationState {
 	for {
 		if state := apis.RandomSynchronizationState(); state != apis.Deleted {
@@ -226,6 +252,8 @@ func RandomProviderService() *corev1.Service {
 
 var _ = Describe("State handler", func() {
 	provider := pipelineshub.RandomProvider()
+	provider.Spec.AllowedNamespaces = nil
+	provider.Spec.AllowedNamespaceSelector = nil
 	providerSvc := RandomProviderService()
 	providerId := pipelineshub.ProviderAndId{
 		Name: common.NamespacedName{
@@ -412,6 +440,19 @@ var _ = Describe("State handler", func() {
 					WithSyncStateCondition(apis.Failed, transitionTime, "operation failed")).
 				MarksAllWorkflowsAsProcessed(),
 		),
+		Check("Creating fails with workflow message",
+			From(apis.Creating, emptyProviderId, v1, irrelevant, transitionTime).
+				AcquireExperiment().
+				WithWorkFlow(func() *argo.Workflow {
+					workflow := CreateTestWorkflow(argo.WorkflowFailed)
+					workflow.Status.Message = "child 'upload' failed"
+					return workflow
+				}()).
+				IssuesCommand(*NewSetStatus().
+					WithVersion(v1).
+					WithSyncStateCondition(apis.Failed, transitionTime, "operation failed: child 'upload' failed")).
+				MarksAllWorkflowsAsProcessed(),
+		),
 		Check("Creating without version",
 			From(apis.Creating, emptyProviderId, "", irrelevant, transitionTime).
 				AcquireExperiment().
@@ -588,6 +629,28 @@ var _ = Describe("State handler", func() {
 					WithVersion(v1).
 					WithSyncStateCondition(apis.Deleted, transitionTime, "")),
 		),
+		Check("Deleting from Succeeded with the Retain deletion policy",
+			From(apis.Succeeded, providerId, v1, irrelevant, transitionTime).
+				AcquireExperiment().
+				DeletionRequested().
+				WithDeletionPolicy(pipelineshub.DeletionPolicyRetain).
+				IssuesCommand(RecordRetainedResource{Provider: *provider}).
+				IssuesCommand(*NewSetStatus().
+					WithProvider(providerId).
+					WithVersion(v1).
+					WithSyncStateCondition(apis.Deleted, transitionTime, StateHandlerConstants.ProviderResourceRetained)),
+		),
+		Check("Deleting from Succeeded with the Delete deletion policy",
+			From(apis.Succeeded, providerId, v1, irrelevant, transitionTime).
+				AcquireExperiment().
+				DeletionRequested().
+				WithDeletionPolicy(pipelineshub.DeletionPolicyDelete).
+				IssuesCommand(*NewSetStatus().
+					WithProvider(providerId).
+					WithVersion(v1).
+					WithSyncStateCondition(apis.Deleting, transitionTime, "")).
+				IssuesDeletionWorkflow(),
+		),
 		Check("Deleting from Failed",
 			From(apis.Failed, providerId, v1, irrelevant, transitionTime).
 				AcquireExperiment().
@@ -664,3 +727,622 @@ var _ = Describe("State handler", func() {
 		),
 	)
 })
+
+var _ = Describe("Namespace restrictions", func() {
+	transitionTime := metav1.Now()
+	namespace := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   apis.RandomLowercaseString(),
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+
+	newResource := func() *pipelineshub.TestResource {
+		resource := pipelineshub.RandomResource()
+		resource.Namespace = namespace.Name
+		resource.Status.Provider = pipelineshub.ProviderAndId{}
+		resource.Status.Conditions = nil
+		return resource
+	}
+
+	stateHandler := func() StateHandler[*pipelineshub.TestResource] {
+		return StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			Namespaces:         fake.NewClientBuilder().WithObjects(&namespace).Build(),
+		}
+	}
+
+	notAllowedMessage := fmt.Sprintf("%s: %s", StateHandlerConstants.NamespaceNotAllowedError, namespace.Name)
+
+	It("creates resources in namespaces matching the selector", func() {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		handler := stateHandler()
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), newResource(), transitionTime)
+
+		Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	It("creates resources when the provider does not restrict namespaces", func() {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = nil
+		provider.Spec.AllowedNamespaceSelector = nil
+		handler := stateHandler()
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), newResource(), transitionTime)
+
+		Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	It("creates resources in listed namespaces", func() {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = []string{namespace.Name}
+		provider.Spec.AllowedNamespaceSelector = nil
+		handler := stateHandler()
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), newResource(), transitionTime)
+
+		Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	It("fails resources in namespaces that are not listed", func() {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = []string{apis.RandomLowercaseString()}
+		provider.Spec.AllowedNamespaceSelector = nil
+		handler := stateHandler()
+		resource := newResource()
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(*From(resource.Status).
+			WithVersion("").
+			WithSyncStateCondition(apis.Failed, transitionTime, notAllowedMessage)))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	It("aborts without failing the resource when the namespace cannot be looked up", func() {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = nil
+		provider.Spec.AllowedNamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		handler := stateHandler()
+		handler.Namespaces = fake.NewClientBuilder().Build()
+		resource := newResource()
+		resource.Status = From(resource.Status).WithSyncStateCondition(apis.Succeeded, transitionTime, "").Status
+		resource.Status.Version = apis.RandomShortHash()
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(HaveLen(2))
+		Expect(commands[1]).To(BeAssignableToTypeOf(Abort{}))
+		Expect(commands[1].(Abort).Err).To(HaveOccurred())
+	})
+
+	It("fails resources in namespaces that are neither listed nor matching the selector", func() {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+		handler := stateHandler()
+		resource := newResource()
+		resource.Status.Version = apis.RandomShortHash()
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(*From(resource.Status).
+			WithVersion("").
+			WithSyncStateCondition(apis.Failed, transitionTime, notAllowedMessage)))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	It("does not update resources that have already been failed", func() {
+		provider := pipelineshub.RandomProvider()
+		handler := stateHandler()
+		resource := newResource()
+		resource.Status = From(resource.Status).WithSyncStateCondition(apis.Failed, transitionTime, notAllowedMessage).Status
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(Equal([]Command{AcquireResource{}}))
+	})
+
+	It("still deletes resources in namespaces that are not allowed", func() {
+		provider := pipelineshub.RandomProvider()
+		handler := stateHandler()
+		resource := newResource()
+		resource.Status = From(resource.Status).WithSyncStateCondition(apis.Succeeded, transitionTime, "").Status
+		resource.DeletionTimestamp = &transitionTime
+
+		commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(*From(resource.Status).WithSyncStateCondition(apis.Deleted, transitionTime, "")))
+	})
+})
+
+var _ = Describe("Direct execution", func() {
+	ctx := context.Background()
+	transitionTime := metav1.Now()
+	providerError := "a provider error has occurred"
+
+	directProvider := func() *pipelineshub.Provider {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = nil
+		provider.Spec.AllowedNamespaceSelector = nil
+		provider.Spec.ExecutionMode = pipelineshub.ExecutionModes.Direct
+		return provider
+	}
+
+	resourceIn := func(state apis.SynchronizationState, id string, operation *pipelineshub.OperationStatus) *pipelineshub.TestResource {
+		resource := pipelineshub.RandomResource()
+		resource.Status.Version = apis.RandomShortHash()
+		resource.Status.Provider = pipelineshub.ProviderAndId{Id: id}
+		resource.Status.Conditions = nil
+		resource.Status.Operation = operation
+		resource.Status = From(resource.Status).WithSyncStateCondition(state, transitionTime, "").Status
+		return resource
+	}
+
+	stateHandler := func(executor *StubbedDirectExecutor, workflows ...argo.Workflow) StateHandler[*pipelineshub.TestResource] {
+		return StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{Workflows: workflows},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			DirectOperations:   NewDirectOperations(executor),
+		}
+	}
+
+	finished := func(handler StateHandler[*pipelineshub.TestResource], operation ProviderOperation) *pipelineshub.OperationStatus {
+		handler.DirectOperations.Start(ctx, "operation-id", operation, &argo.Workflow{})
+		Eventually(func() *DirectOperationResult {
+			result, _ := handler.DirectOperations.Result("operation-id")
+			return result
+		}).ShouldNot(BeNil())
+
+		return &pipelineshub.OperationStatus{Name: string(operation), Id: "operation-id"}
+	}
+
+	// startedOperation returns the operation that is recorded with the
+	// transition and starts it like the reconciler does.
+	startedOperation := func(commands []Command) *pipelineshub.OperationStatus {
+		setStatus, found := lo.Find(commands, func(command Command) bool {
+			_, ok := command.(SetStatus)
+			return ok
+		})
+		Expect(found).To(BeTrue())
+		operation := setStatus.(SetStatus).Status.Operation
+		Expect(operation).NotTo(BeNil())
+
+		start, found := lo.Find(commands, func(command Command) bool {
+			_, ok := command.(StartDirectOperation)
+			return ok
+		})
+		Expect(found).To(BeTrue())
+		Expect(start.(StartDirectOperation).Id).To(Equal(operation.Id))
+		Expect(commands).To(ContainElement(Requeue{After: DirectOperationPollInterval}))
+		Expect(start.execute(ctx, K8sExecutionContext{}, nil)).To(Succeed())
+
+		return operation
+	}
+
+	It("records the operation with the transition into the in-progress state and starts it", func() {
+		executor := &StubbedDirectExecutor{}
+		resource := resourceIn(apis.Succeeded, "", nil)
+		resource.SetComputedVersion(apis.RandomShortHash())
+
+		handler := stateHandler(executor)
+
+		commands := handler.stateTransition(ctx, *directProvider(), *RandomProviderService(), resource, transitionTime)
+
+		operation := startedOperation(commands)
+		Expect(operation.Name).To(Equal(string(ProviderOperations.Create)))
+		Expect(commands).To(ContainElement(*From(resource.Status).
+			WithVersion(resource.ComputeVersion()).
+			WithSyncStateCondition(apis.Creating, transitionTime, "").
+			WithOperation(operation)))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+		Eventually(executor.Calls).Should(Equal([]ProviderOperation{ProviderOperations.Create}))
+	})
+
+	It("still submits workflows for providers that do not execute directly", func() {
+		provider := directProvider()
+		provider.Spec.ExecutionMode = pipelineshub.ExecutionModes.Argo
+		resource := resourceIn(apis.Succeeded, "", nil)
+		resource.SetComputedVersion(apis.RandomShortHash())
+
+		handler := stateHandler(&StubbedDirectExecutor{})
+
+		commands := handler.stateTransition(ctx, *provider, *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(StartDirectOperation{})))
+	})
+
+	DescribeTable("records the result of the operation of in-progress resources",
+		func(state apis.SynchronizationState, id string, output providers.Output, operation ProviderOperation, expectedState apis.SynchronizationState, expectedMessage string, setsProvider bool) {
+			provider := directProvider()
+			handler := stateHandler(&StubbedDirectExecutor{Output: output})
+			resource := resourceIn(state, id, finished(handler, operation))
+
+			commands := handler.stateTransition(ctx, *provider, *RandomProviderService(), resource, transitionTime)
+
+			expectedStatus := From(resource.Status).
+				WithOperation(nil).
+				WithSyncStateCondition(expectedState, transitionTime, expectedMessage)
+			if setsProvider {
+				expectedStatus.WithProvider(pipelineshub.ProviderAndId{Name: provider.GetCommonNamespacedName(), Id: output.Id})
+			}
+			Expect(commands).To(ContainElement(*expectedStatus))
+			Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(MarkWorkflowsAsProcessed{})))
+			Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(StartDirectOperation{})))
+		},
+		Entry("creation succeeds", apis.Creating, "", providers.Output{Id: "new-id"}, ProviderOperations.Create, apis.Succeeded, "", true),
+		Entry("creation without id", apis.Creating, "", providers.Output{}, ProviderOperations.Create, apis.Failed, "id was empty", false),
+		Entry("creation with provider error", apis.Creating, "", providers.Output{ProviderError: providerError}, ProviderOperations.Create, apis.Failed, providerError, true),
+		Entry("update succeeds", apis.Updating, "old-id", providers.Output{Id: "new-id"}, ProviderOperations.Update, apis.Succeeded, "", true),
+		Entry("deletion succeeds", apis.Deleting, "old-id", providers.Output{}, ProviderOperations.Delete, apis.Deleted, "", true),
+		Entry("deletion with remaining id", apis.Deleting, "old-id", providers.Output{Id: "old-id"}, ProviderOperations.Delete, apis.Deleting, "id should be empty", false),
+		Entry("deletion with provider error", apis.Deleting, "old-id", providers.Output{Id: "old-id", ProviderError: providerError}, ProviderOperations.Delete, apis.Deleting, providerError, true),
+	)
+
+	It("requeues the resource while the operation is running", func() {
+		executor := &StubbedDirectExecutor{Release: make(chan struct{})}
+		defer close(executor.Release)
+		handler := stateHandler(executor)
+		handler.DirectOperations.Start(ctx, "operation-id", ProviderOperations.Create, &argo.Workflow{})
+		resource := resourceIn(apis.Creating, "", &pipelineshub.OperationStatus{Name: string(ProviderOperations.Create), Id: "operation-id"})
+
+		commands := handler.stateTransition(ctx, *directProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(Equal([]Command{AcquireResource{}, Requeue{After: DirectOperationPollInterval}}))
+	})
+
+	It("fails the resource when the provider-service cannot be called", func() {
+		handler := stateHandler(&StubbedDirectExecutor{Err: errors.New("connection refused")})
+		resource := resourceIn(apis.Creating, "", finished(handler, ProviderOperations.Create))
+
+		commands := handler.stateTransition(ctx, *directProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(*From(resource.Status).
+			WithOperation(nil).
+			WithSyncStateCondition(apis.Failed, transitionTime, "operation failed: connection refused")))
+	})
+
+	DescribeTable("repeats operations whose outcome is unknown with the recorded id",
+		func(state apis.SynchronizationState, providerId string, operation ProviderOperation) {
+			executor := &StubbedDirectExecutor{}
+			handler := stateHandler(executor)
+			resource := resourceIn(state, providerId, &pipelineshub.OperationStatus{Name: string(operation), Id: "operation-id"})
+
+			commands := handler.stateTransition(ctx, *directProvider(), *RandomProviderService(), resource, transitionTime)
+
+			Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(SetStatus{})))
+			Expect(commands).To(ContainElement(Requeue{After: DirectOperationPollInterval}))
+			start, found := lo.Find(commands, func(command Command) bool {
+				_, ok := command.(StartDirectOperation)
+				return ok
+			})
+			Expect(found).To(BeTrue())
+			Expect(start.(StartDirectOperation).Id).To(Equal("operation-id"))
+			Expect(start.(StartDirectOperation).Operation).To(Equal(operation))
+
+			Expect(start.execute(ctx, K8sExecutionContext{}, nil)).To(Succeed())
+			Eventually(executor.Calls).Should(Equal([]ProviderOperation{operation}))
+		},
+		Entry("creation", apis.Creating, "", ProviderOperations.Create),
+		Entry("update", apis.Updating, "old-id", ProviderOperations.Update),
+		Entry("deletion", apis.Deleting, "old-id", ProviderOperations.Delete),
+	)
+
+	It("starts the operation of in-progress resources without a recorded operation", func() {
+		executor := &StubbedDirectExecutor{}
+		handler := stateHandler(executor)
+		resource := resourceIn(apis.Creating, "", nil)
+
+		commands := handler.stateTransition(ctx, *directProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(startedOperation(commands).Name).To(Equal(string(ProviderOperations.Create)))
+		Eventually(executor.Calls).Should(Equal([]ProviderOperation{ProviderOperations.Create}))
+	})
+
+	It("observes workflows submitted before the provider switched to the direct execution mode", func() {
+		executor := &StubbedDirectExecutor{}
+		resource := resourceIn(apis.Creating, "", nil)
+
+		handler := stateHandler(executor, *CreateTestWorkflow(argo.WorkflowRunning))
+
+		commands := handler.stateTransition(ctx, *directProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(Equal([]Command{AcquireResource{}}))
+		Consistently(executor.Calls).Should(BeEmpty())
+	})
+
+	It("submits workflows that compile resources to Argo", func() {
+		handler := stateHandler(&StubbedDirectExecutor{})
+		workflow := argo.Workflow{Spec: argo.WorkflowSpec{WorkflowTemplateRef: &argo.WorkflowTemplateRef{Name: "create-compiled"}}}
+		inProgress := *NewSetStatus().WithSyncStateCondition(apis.Creating, transitionTime, "")
+
+		Expect(handler.executesDirectly(*directProvider(), &workflow)).To(BeFalse())
+		Expect(handler.submit(*directProvider(), inProgress, &workflow)).To(Equal([]Command{inProgress, CreateWorkflow{Workflow: workflow}}))
+	})
+})
+
+var _ = Describe("Adoption", func() {
+	transitionTime := metav1.Now()
+
+	allowingProvider := func() *pipelineshub.Provider {
+		provider := pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = nil
+		provider.Spec.AllowedNamespaceSelector = nil
+		return provider
+	}
+
+	adoptingResource := func(state apis.SynchronizationState) *pipelineshub.TestResource {
+		resource := pipelineshub.RandomResource()
+		resource.SetAnnotations(map[string]string{pipelineshub.AdoptProviderIdAnnotation: "existing-id"})
+		resource.SetComputedVersion(apis.RandomShortHash())
+		resource.Status.Provider = pipelineshub.ProviderAndId{}
+		resource.Status.Conditions = nil
+		if state != apis.Unknown {
+			resource.Status = From(resource.Status).WithSyncStateCondition(state, transitionTime, "").Status
+		}
+		return resource
+	}
+
+	DescribeTable("updates the existing provider resource instead of creating one",
+		func(state apis.SynchronizationState) {
+			provider := allowingProvider()
+			factory := &TestWorkflowFactory{}
+			getter := StubbedProviderResourceGetter{Outputs: map[string]providers.Output{"existing-id": {Id: "existing-id"}}}
+			resource := adoptingResource(state)
+
+			handler := StateHandler[*pipelineshub.TestResource]{
+				WorkflowRepository: StubbedWorkflows{},
+				WorkflowFactory:    factory,
+				ResourceGetter:     getter,
+			}
+
+			commands := handler.stateTransition(context.Background(), *provider, *RandomProviderService(), resource, transitionTime)
+
+			adoptedProvider := pipelineshub.ProviderAndId{Name: provider.GetCommonNamespacedName(), Id: "existing-id"}
+			Expect(commands).To(ContainElement(*From(resource.Status).
+				WithProvider(adoptedProvider).
+				WithSyncStateCondition(apis.Updating, transitionTime, "").
+				WithVersion(resource.ComputeVersion())))
+			Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+			Expect(commands).To(ContainElement(ForgetRetainedResource{Provider: *provider, Id: "existing-id"}))
+			Expect(factory.CalledWithResource.Status.Provider).To(Equal(adoptedProvider))
+		},
+		Entry("new resources", apis.Unknown),
+		Entry("succeeded resources without a provider id", apis.Succeeded),
+		Entry("failed resources without a provider id", apis.Failed),
+	)
+
+	It("fails resources when the provider resource does not exist", func() {
+		getter := StubbedProviderResourceGetter{Errors: map[string]error{"existing-id": ErrProviderResourceNotFound}}
+		resource := adoptingResource(apis.Unknown)
+
+		handler := StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			ResourceGetter:     getter,
+		}
+
+		commands := handler.stateTransition(context.Background(), *allowingProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(*From(resource.Status).
+			WithVersion(resource.ComputeVersion()).
+			WithSyncStateCondition(
+				apis.Failed,
+				transitionTime,
+				fmt.Sprintf("%s existing-id: %s", StateHandlerConstants.AdoptionFailedError, ErrProviderResourceNotFound),
+			)))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	failedAdoption := func(providerId string) *pipelineshub.TestResource {
+		resource := adoptingResource(apis.Unknown)
+		resource.Status = From(resource.Status).
+			WithVersion(resource.ComputeVersion()).
+			WithSyncStateCondition(
+				apis.Failed,
+				transitionTime,
+				fmt.Sprintf("%s %s: %s", StateHandlerConstants.AdoptionFailedError, providerId, ErrProviderResourceNotFound),
+			).Status
+		return resource
+	}
+
+	It("does not adopt again when the adoption already failed", func() {
+		getter := StubbedProviderResourceGetter{Outputs: map[string]providers.Output{"existing-id": {Id: "existing-id"}}}
+
+		handler := StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			ResourceGetter:     getter,
+		}
+
+		commands := handler.stateTransition(context.Background(), *allowingProvider(), *RandomProviderService(), failedAdoption("existing-id"), transitionTime)
+
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(SetStatus{})))
+	})
+
+	It("adopts again once the annotation changes", func() {
+		getter := StubbedProviderResourceGetter{Outputs: map[string]providers.Output{"existing-id": {Id: "existing-id"}}}
+		resource := failedAdoption("previous-id")
+
+		handler := StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			ResourceGetter:     getter,
+		}
+
+		commands := handler.stateTransition(context.Background(), *allowingProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+	})
+
+	It("ignores the annotation once the resource has a provider id", func() {
+		resource := adoptingResource(apis.Succeeded)
+		resource.Status.Provider.Id = "created-id"
+		factory := &TestWorkflowFactory{}
+
+		handler := StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    factory,
+			ResourceGetter:     StubbedProviderResourceGetter{},
+		}
+
+		handler.stateTransition(context.Background(), *allowingProvider(), *RandomProviderService(), resource, transitionTime)
+
+		Expect(factory.CalledWithResource.Status.Provider.Id).To(Equal("created-id"))
+	})
+})
+
+type StubbedWorkflowThrottle struct {
+	Throttling *Throttling
+}
+
+func (swt StubbedWorkflowThrottle) Throttle(_ context.Context, _ pipelineshub.Provider) (*Throttling, error) {
+	return swt.Throttling, nil
+}
+
+var _ = Describe("Throttling", func() {
+	throttling := &Throttling{
+		Reason:     apis.ThrottleReasons.MaxConcurrent,
+		Message:    "maximum of 1 concurrent workflows reached",
+		RetryAfter: time.Minute,
+	}
+
+	var provider *pipelineshub.Provider
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		provider.Spec.AllowedNamespaces = nil
+		provider.Spec.AllowedNamespaceSelector = nil
+	})
+
+	updatedResource := func() *pipelineshub.TestResource {
+		resource := pipelineshub.RandomResource()
+		resource.Status.Provider.Name = provider.GetCommonNamespacedName()
+		resource.SetComputedVersion(apis.RandomShortHash())
+		resource.Status = From(resource.Status).WithSyncStateCondition(apis.Succeeded, metav1.Now(), "").Status
+		return resource
+	}
+
+	stateHandler := func(throttling *Throttling) *StateHandler[*pipelineshub.TestResource] {
+		return &StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+			Throttle:           StubbedWorkflowThrottle{Throttling: throttling},
+		}
+	}
+
+	It("holds the resource in its current state while throttled", func() {
+		resource := updatedResource()
+
+		commands := stateHandler(throttling).StateTransition(context.Background(), *provider, *RandomProviderService(), resource)
+
+		Expect(commands).To(HaveLen(3))
+		Expect(commands[0]).To(Equal(AcquireResource{}))
+		setThrottled, ok := commands[1].(SetThrottled)
+		Expect(ok).To(BeTrue())
+		Expect(setThrottled.Condition.Type).To(Equal(apis.ConditionTypes.Throttled))
+		Expect(setThrottled.Condition.Reason).To(Equal(throttling.Reason))
+		Expect(setThrottled.Condition.Message).To(Equal(throttling.Message))
+		Expect(commands[2]).To(Equal(Requeue{After: time.Minute}))
+	})
+
+	It("does not set the condition again while the throttling is unchanged", func() {
+		resource := updatedResource()
+		resource.Status.Conditions = resource.Status.Conditions.MergeIntoConditions(metav1.Condition{
+			Type:    apis.ConditionTypes.Throttled,
+			Status:  metav1.ConditionTrue,
+			Reason:  throttling.Reason,
+			Message: throttling.Message,
+		})
+
+		commands := stateHandler(throttling).StateTransition(context.Background(), *provider, *RandomProviderService(), resource)
+
+		Expect(commands).To(Equal([]Command{AcquireResource{}, Requeue{After: time.Minute}}))
+	})
+
+	It("submits the workflow and removes the condition once admitted", func() {
+		resource := updatedResource()
+		resource.Status.Conditions = resource.Status.Conditions.MergeIntoConditions(metav1.Condition{
+			Type:   apis.ConditionTypes.Throttled,
+			Status: metav1.ConditionTrue,
+			Reason: throttling.Reason,
+		})
+
+		commands := stateHandler(nil).StateTransition(context.Background(), *provider, *RandomProviderService(), resource)
+
+		Expect(commands).To(ContainElement(BeAssignableToTypeOf(CreateWorkflow{})))
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(Requeue{})))
+		setStatus, found := lo.Find(commands, func(command Command) bool {
+			_, ok := command.(SetStatus)
+			return ok
+		})
+		Expect(found).To(BeTrue())
+		Expect(setStatus.(SetStatus).Status.Conditions).NotTo(ContainElement(HaveField("Type", apis.ConditionTypes.Throttled)))
+		Expect(setStatus.(SetStatus).Status.Conditions.GetSyncStateFromReason()).To(Equal(apis.Updating))
+	})
+
+	It("does not throttle resources that create no workflow", func() {
+		resource := updatedResource()
+		resource.Status.Version = resource.ComputeVersion()
+
+		commands := stateHandler(throttling).StateTransition(context.Background(), *provider, *RandomProviderService(), resource)
+
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(Requeue{})))
+	})
+})
+
+var _ = Describe("Dry run", func() {
+	var provider *pipelineshub.Provider
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+	})
+
+	dryRunResource := func() *pipelineshub.TestResource {
+		resource := pipelineshub.RandomResource()
+		resource.SetAnnotations(map[string]string{pipelineshub.DryRunAnnotation: "true"})
+		return resource
+	}
+
+	It("stores the plan instead of synchronising the resource", func() {
+		factory := &TestWorkflowFactory{}
+		stateHandler := &StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    factory,
+		}
+		resource := dryRunResource()
+
+		commands := stateHandler.StateTransition(context.Background(), *provider, *RandomProviderService(), resource)
+
+		Expect(commands).To(Equal([]Command{StorePlan{Plan: &Plan{}}}))
+		Expect(factory.CalledWithResource).To(Equal(resource))
+	})
+
+	It("stores the error when the resource cannot be planned", func() {
+		stateHandler := &StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{shouldFail: true},
+		}
+
+		commands := stateHandler.StateTransition(context.Background(), *provider, *RandomProviderService(), dryRunResource())
+
+		Expect(commands).To(Equal([]Command{StorePlan{Error: "an error occurred"}}))
+	})
+
+	It("deletes resources as usual", func() {
+		stateHandler := &StateHandler[*pipelineshub.TestResource]{
+			WorkflowRepository: StubbedWorkflows{},
+			WorkflowFactory:    &TestWorkflowFactory{},
+		}
+		resource := dryRunResource()
+		resource.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+
+		commands := stateHandler.StateTransition(context.Background(), *provider, *RandomProviderService(), resource)
+
+		Expect(commands).NotTo(ContainElement(BeAssignableToTypeOf(StorePlan{})))
+	})
+})
@@ -4,10 +4,13 @@ package pipelines
 
 import (
 	"context"
+	"slices"
+	"sync"
 
 	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/sky-uk/kfp-operator/apis"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	providers "github.com/sky-uk/kfp-operator/pkg/providers/base"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +34,38 @@ func (sw *StubbedWorkflows) AddWorkflow(workflow argo.Workflow) {
 	sw.Workflows = append(sw.Workflows, workflow)
 }
 
+type StubbedDirectExecutor struct {
+	Output providers.Output
+	Err    error
+	// Release blocks operations until it is closed when set.
+	Release       chan struct{}
+	mutex         sync.Mutex
+	calledWithOps []ProviderOperation
+}
+
+func (sde *StubbedDirectExecutor) Execute(ctx context.Context, operation ProviderOperation, _ string, _ *argo.Workflow) (providers.Output, error) {
+	if sde.Release != nil {
+		<-sde.Release
+	}
+
+	sde.mutex.Lock()
+	defer sde.mutex.Unlock()
+
+	sde.calledWithOps = append(sde.calledWithOps, operation)
+	if err := ctx.Err(); err != nil {
+		return providers.Output{}, err
+	}
+
+	return sde.Output, sde.Err
+}
+
+func (sde *StubbedDirectExecutor) Calls() []ProviderOperation {
+	sde.mutex.Lock()
+	defer sde.mutex.Unlock()
+
+	return slices.Clone(sde.calledWithOps)
+}
+
 func CreateTestWorkflow(phase argo.WorkflowPhase) *argo.Workflow {
 	return &argo.Workflow{
 		ObjectMeta: metav1.ObjectMeta{
@@ -124,7 +124,9 @@ var _ = BeforeSuite(func() {
 	Expect(pipelineshub.NewPipelineValidatorWebhook(k8sManager)).To(Succeed())
 	Expect(pipelineshub.NewRunConfigurationValidatorWebhook(k8sManager)).To(Succeed())
 	Expect(pipelineshub.NewRunValidatorWebhook(k8sManager)).To(Succeed())
+	Expect(pipelineshub.NewRunScheduleValidatorWebhook(k8sManager)).To(Succeed())
 	Expect(pipelineshub.NewProviderValidatorWebhook(k8sManager)).To(Succeed())
+	Expect(pipelineshub.NewExperimentValidatorWebhook(k8sManager)).To(Succeed())
 
 	var managerCtx context.Context
 	managerCtx, cancel = context.WithCancel(ctrl.SetupSignalHandler())
@@ -0,0 +1,221 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/logkeys"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	DefaultWorkflowGarbageCollectionInterval = 10 * time.Minute
+	// WorkflowGarbageCollectionPageSize limits the number of workflows
+	// fetched from the API server per request.
+	WorkflowGarbageCollectionPageSize = 500
+)
+
+// WorkflowGarbageCollector periodically deletes processed workflows that
+// exceed the configured retention limits of the resource that owns them.
+type WorkflowGarbageCollector struct {
+	Client          controllers.OptInClient
+	Retention       config.WorkflowRetention
+	Scope           Scope
+	deletedCounter  metric.Int64Counter
+	failedCounter   metric.Int64Counter
+	currentTimeFunc func() time.Time
+}
+
+func NewWorkflowGarbageCollector(
+	client controllers.OptInClient,
+	retention config.WorkflowRetention,
+	scope Scope,
+) (*WorkflowGarbageCollector, error) {
+	meter := otel.Meter("workflow_garbage_collector")
+	deletedCounter, err := meter.Int64Counter(
+		"workflow_garbage_collector_deleted_workflows",
+		metric.WithDescription("Total number of processed workflows deleted by the workflow garbage collector"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deleted workflows counter: %w", err)
+	}
+
+	failedCounter, err := meter.Int64Counter(
+		"workflow_garbage_collector_failed_deletions",
+		metric.WithDescription("Total number of processed workflows the workflow garbage collector failed to delete"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create failed deletions counter: %w", err)
+	}
+
+	return &WorkflowGarbageCollector{
+		Client:          client,
+		Retention:       retention,
+		Scope:           scope,
+		deletedCounter:  deletedCounter,
+		failedCounter:   failedCounter,
+		currentTimeFunc: time.Now,
+	}, nil
+}
+
+// NeedLeaderElection ensures that only the leading manager collects workflows.
+func (wgc *WorkflowGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+func (wgc *WorkflowGarbageCollector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("workflow-garbage-collector")
+	ctx = log.IntoContext(ctx, logger)
+
+	interval := DefaultWorkflowGarbageCollectionInterval
+	if wgc.Retention.Interval != nil {
+		interval = wgc.Retention.Interval.Duration
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := wgc.Collect(ctx); err != nil {
+			logger.Error(err, "workflow garbage collection failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes all processed workflows that exceed the retention limits.
+// Workflows that cannot be deleted are logged and skipped so that they do not
+// prevent the deletion of the others, and are reported in the returned error.
+func (wgc *WorkflowGarbageCollector) Collect(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	processed, err := labels.NewRequirement(WorkflowRepositoryConstants.WorkflowProcessedLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+
+	var inScope []argo.Workflow
+	continueToken := ""
+	for {
+		var workflows argo.WorkflowList
+		if err := wgc.Client.NonCached.List(
+			ctx,
+			&workflows,
+			client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*processed)},
+			client.Limit(WorkflowGarbageCollectionPageSize),
+			client.Continue(continueToken),
+		); err != nil {
+			return err
+		}
+
+		inScope = append(inScope, lo.Filter(workflows.Items, func(workflow argo.Workflow, _ int) bool {
+			return wgc.Scope.ContainsNamespace(ctx, workflow.GetLabels()[workflowconstants.OwnerNamespaceLabelKey])
+		})...)
+
+		continueToken = workflows.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+
+	expired := ExpiredWorkflows(inScope, wgc.Retention, wgc.currentTimeFunc())
+	logger.V(2).Info("collecting workflows", "processed", len(inScope), "expired", len(expired))
+
+	failed := 0
+	for i := range expired {
+		workflow := &expired[i]
+		if err := wgc.Client.Delete(ctx, workflow); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "failed to delete processed workflow", logkeys.Workflow, client.ObjectKeyFromObject(workflow))
+			wgc.failedCounter.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("phase", string(workflow.Status.Phase)),
+			))
+			failed++
+			continue
+		}
+
+		logger.V(1).Info("deleted processed workflow", logkeys.Workflow, client.ObjectKeyFromObject(workflow))
+		wgc.deletedCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("phase", string(workflow.Status.Phase)),
+		))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d expired workflows", failed, len(expired))
+	}
+
+	return nil
+}
+
+func workflowOwner(workflow argo.Workflow) string {
+	workflowLabels := workflow.GetLabels()
+
+	return fmt.Sprintf(
+		"%s/%s/%s",
+		workflowLabels[workflowconstants.OwnerKindLabelKey],
+		workflowLabels[workflowconstants.OwnerNamespaceLabelKey],
+		workflowLabels[workflowconstants.OwnerNameLabelKey],
+	)
+}
+
+func workflowFinishedAt(workflow argo.Workflow) time.Time {
+	if !workflow.Status.FinishedAt.IsZero() {
+		return workflow.Status.FinishedAt.Time
+	}
+
+	return workflow.CreationTimestamp.Time
+}
+
+// ExpiredWorkflows returns the finished workflows that exceed the retention
+// limits for their phase. Workflows are counted per owning resource and phase,
+// newest first.
+func ExpiredWorkflows(workflows []argo.Workflow, retention config.WorkflowRetention, now time.Time) []argo.Workflow {
+	var expired []argo.Workflow
+
+	finished := lo.Filter(workflows, func(workflow argo.Workflow, _ int) bool {
+		return workflow.Status.Phase.Completed()
+	})
+
+	groups := lo.GroupBy(finished, func(workflow argo.Workflow) string {
+		return fmt.Sprintf("%s/%t", workflowOwner(workflow), workflow.Status.Phase == argo.WorkflowSucceeded)
+	})
+
+	for _, group := range groups {
+		limits := retention.Failed
+		if group[0].Status.Phase == argo.WorkflowSucceeded {
+			limits = retention.Succeeded
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return workflowFinishedAt(group[i]).After(workflowFinishedAt(group[j]))
+		})
+
+		for i, workflow := range group {
+			exceedsCount := limits.MaxCount != nil && i >= *limits.MaxCount
+			exceedsAge := limits.MaxAge != nil && now.Sub(workflowFinishedAt(workflow)) > limits.MaxAge.Duration
+
+			if exceedsCount || exceedsAge {
+				expired = append(expired, workflow)
+			}
+		}
+	}
+
+	return expired
+}
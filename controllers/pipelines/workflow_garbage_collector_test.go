@@ -0,0 +1,213 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sky-uk/kfp-operator/controllers"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	"github.com/sky-uk/kfp-operator/external"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("WorkflowGarbageCollector", func() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	workflow := func(name string, owner string, phase argo.WorkflowPhase, finishedAgo time.Duration) argo.Workflow {
+		return argo.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					workflowconstants.OwnerKindLabelKey:                "experiment",
+					workflowconstants.OwnerNamespaceLabelKey:           "default",
+					workflowconstants.OwnerNameLabelKey:                owner,
+					WorkflowRepositoryConstants.WorkflowProcessedLabel: "true",
+				},
+			},
+			Status: argo.WorkflowStatus{
+				Phase:      phase,
+				FinishedAt: metav1.NewTime(now.Add(-finishedAgo)),
+			},
+		}
+	}
+
+	names := func(workflows []argo.Workflow) []string {
+		return lo.Map(workflows, func(workflow argo.Workflow, _ int) string {
+			return workflow.Name
+		})
+	}
+
+	Describe("ExpiredWorkflows", func() {
+		workflows := []argo.Workflow{
+			workflow("a-succeeded-1", "a", argo.WorkflowSucceeded, time.Minute),
+			workflow("a-succeeded-2", "a", argo.WorkflowSucceeded, 2*time.Minute),
+			workflow("a-succeeded-3", "a", argo.WorkflowSucceeded, 3*time.Hour),
+			workflow("a-failed-1", "a", argo.WorkflowFailed, time.Minute),
+			workflow("a-failed-2", "a", argo.WorkflowError, 2*time.Minute),
+			workflow("b-succeeded-1", "b", argo.WorkflowSucceeded, 5*time.Minute),
+			workflow("b-running", "b", argo.WorkflowRunning, 10*time.Hour),
+		}
+
+		It("keeps all workflows without limits", func() {
+			Expect(ExpiredWorkflows(workflows, config.WorkflowRetention{}, now)).To(BeEmpty())
+		})
+
+		It("limits the number of workflows per owner and phase", func() {
+			retention := config.WorkflowRetention{
+				Succeeded: config.RetentionLimits{MaxCount: lo.ToPtr(1)},
+				Failed:    config.RetentionLimits{MaxCount: lo.ToPtr(0)},
+			}
+
+			Expect(names(ExpiredWorkflows(workflows, retention, now))).To(ConsistOf(
+				"a-succeeded-2", "a-succeeded-3", "a-failed-1", "a-failed-2",
+			))
+		})
+
+		It("limits the age of workflows", func() {
+			retention := config.WorkflowRetention{
+				Succeeded: config.RetentionLimits{MaxAge: &metav1.Duration{Duration: time.Hour}},
+			}
+
+			Expect(names(ExpiredWorkflows(workflows, retention, now))).To(ConsistOf("a-succeeded-3"))
+		})
+
+		It("never deletes unfinished workflows", func() {
+			retention := config.WorkflowRetention{
+				Succeeded: config.RetentionLimits{MaxCount: lo.ToPtr(0)},
+				Failed:    config.RetentionLimits{MaxCount: lo.ToPtr(0)},
+			}
+
+			Expect(names(ExpiredWorkflows(workflows, retention, now))).NotTo(ContainElement("b-running"))
+		})
+	})
+
+	Describe("Collect", func() {
+		It("deletes expired processed workflows", func() {
+			scheme := runtime.NewScheme()
+			Expect(external.InitSchemes(scheme)).To(Succeed())
+
+			expired := workflow("expired", "a", argo.WorkflowSucceeded, 2*time.Hour)
+			retained := workflow("retained", "a", argo.WorkflowSucceeded, time.Minute)
+			unprocessed := workflow("unprocessed", "a", argo.WorkflowSucceeded, 2*time.Hour)
+			delete(unprocessed.Labels, WorkflowRepositoryConstants.WorkflowProcessedLabel)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&expired, &retained, &unprocessed).Build()
+
+			collector, err := NewWorkflowGarbageCollector(
+				controllers.OptInClient{Writer: fakeClient, StatusClient: fakeClient, Cached: fakeClient, NonCached: fakeClient},
+				config.WorkflowRetention{
+					Succeeded: config.RetentionLimits{MaxAge: &metav1.Duration{Duration: time.Hour}},
+				},
+				Scope{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			collector.currentTimeFunc = func() time.Time { return now }
+
+			Expect(collector.Collect(context.Background())).To(Succeed())
+
+			var remaining argo.WorkflowList
+			Expect(fakeClient.List(context.Background(), &remaining, client.InNamespace("default"))).To(Succeed())
+			Expect(names(remaining.Items)).To(ConsistOf("retained", "unprocessed"))
+		})
+
+		It("deletes the other workflows when a deletion fails", func() {
+			scheme := runtime.NewScheme()
+			Expect(external.InitSchemes(scheme)).To(Succeed())
+
+			failing := workflow("failing", "a", argo.WorkflowSucceeded, 2*time.Hour)
+			expired := workflow("expired", "a", argo.WorkflowSucceeded, 3*time.Hour)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(&failing, &expired).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if obj.GetName() == failing.Name {
+							return errors.New("failed")
+						}
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			collector, err := NewWorkflowGarbageCollector(
+				controllers.OptInClient{Writer: fakeClient, StatusClient: fakeClient, Cached: fakeClient, NonCached: fakeClient},
+				config.WorkflowRetention{
+					Succeeded: config.RetentionLimits{MaxAge: &metav1.Duration{Duration: time.Hour}},
+				},
+				Scope{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			collector.currentTimeFunc = func() time.Time { return now }
+
+			Expect(collector.Collect(context.Background())).NotTo(Succeed())
+
+			var remaining argo.WorkflowList
+			Expect(fakeClient.List(context.Background(), &remaining, client.InNamespace("default"))).To(Succeed())
+			Expect(names(remaining.Items)).To(ConsistOf("failing"))
+		})
+
+		It("counts workflows across all pages", func() {
+			scheme := runtime.NewScheme()
+			Expect(external.InitSchemes(scheme)).To(Succeed())
+
+			newest := workflow("newest", "a", argo.WorkflowSucceeded, time.Minute)
+			oldest := workflow("oldest", "a", argo.WorkflowSucceeded, time.Hour)
+
+			var continueTokens, deleted []string
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(&newest, &oldest).
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						listOptions := (&client.ListOptions{}).ApplyOptions(opts)
+						continueTokens = append(continueTokens, listOptions.Continue)
+						if err := c.List(ctx, list, opts...); err != nil {
+							return err
+						}
+
+						workflows := list.(*argo.WorkflowList)
+						if listOptions.Continue == "" {
+							workflows.Items = workflows.Items[:1]
+							workflows.Continue = "next"
+						} else {
+							workflows.Items = workflows.Items[1:]
+						}
+						return nil
+					},
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						deleted = append(deleted, obj.GetName())
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			collector, err := NewWorkflowGarbageCollector(
+				controllers.OptInClient{Writer: fakeClient, StatusClient: fakeClient, Cached: fakeClient, NonCached: fakeClient},
+				config.WorkflowRetention{
+					Succeeded: config.RetentionLimits{MaxCount: lo.ToPtr(1)},
+				},
+				Scope{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			collector.currentTimeFunc = func() time.Time { return now }
+
+			Expect(collector.Collect(context.Background())).To(Succeed())
+			Expect(continueTokens).To(Equal([]string{"", "next"}))
+			Expect(deleted).To(ConsistOf("oldest"))
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const DefaultThrottleRetryInterval = 30 * time.Second
+
+// Throttling explains why workflows of a provider may not be submitted and
+// when to try again.
+type Throttling struct {
+	Reason     string
+	Message    string
+	RetryAfter time.Duration
+}
+
+type WorkflowThrottle interface {
+	// Throttle returns nil when a workflow may be submitted for the
+	// resources of the provider.
+	Throttle(ctx context.Context, provider pipelineshub.Provider) (*Throttling, error)
+}
+
+// WorkflowLimitThrottle enforces the workflow limits of providers by
+// counting the workflows labelled with the name of the provider. Workflows
+// are counted rather than tracked in memory so that the limits hold across
+// controllers and restarts of the manager. Workflows are counted from the
+// cache, so reconciliations running concurrently or before the cache has
+// observed recently submitted workflows may exceed the limits slightly.
+// Operations that the provider executes directly do not submit workflows and
+// are not limited.
+type WorkflowLimitThrottle struct {
+	Client          client.Reader
+	currentTimeFunc func() time.Time
+}
+
+func NewWorkflowLimitThrottle(client client.Reader) WorkflowLimitThrottle {
+	return WorkflowLimitThrottle{
+		Client:          client,
+		currentTimeFunc: time.Now,
+	}
+}
+
+func (wlt WorkflowLimitThrottle) Throttle(ctx context.Context, provider pipelineshub.Provider) (*Throttling, error) {
+	limits := provider.Spec.WorkflowLimits
+	if limits == nil || (limits.MaxConcurrent == 0 && limits.MaxSubmissionsPerMinute == 0) {
+		return nil, nil
+	}
+
+	var workflows argo.WorkflowList
+	if err := wlt.Client.List(
+		ctx,
+		&workflows,
+		client.InNamespace(provider.Namespace),
+		client.MatchingLabels{workflowconstants.ProviderNameLabelKey: provider.Name},
+	); err != nil {
+		return nil, err
+	}
+
+	now := wlt.currentTimeFunc()
+	inFlight := int32(0)
+	submitted := int32(0)
+	var oldestSubmission time.Time
+
+	for _, workflow := range workflows.Items {
+		if !workflow.Status.Fulfilled() {
+			inFlight++
+		}
+
+		created := workflow.CreationTimestamp.Time
+		if now.Sub(created) < time.Minute {
+			submitted++
+			if oldestSubmission.IsZero() || created.Before(oldestSubmission) {
+				oldestSubmission = created
+			}
+		}
+	}
+
+	if limits.MaxConcurrent > 0 && inFlight >= limits.MaxConcurrent {
+		return &Throttling{
+			Reason:     apis.ThrottleReasons.MaxConcurrent,
+			Message:    fmt.Sprintf("maximum of %d concurrent workflows reached", limits.MaxConcurrent),
+			RetryAfter: DefaultThrottleRetryInterval,
+		}, nil
+	}
+
+	if limits.MaxSubmissionsPerMinute > 0 && submitted >= limits.MaxSubmissionsPerMinute {
+		return &Throttling{
+			Reason:     apis.ThrottleReasons.MaxSubmissionsPerMinute,
+			Message:    fmt.Sprintf("maximum of %d workflow submissions per minute reached", limits.MaxSubmissionsPerMinute),
+			RetryAfter: oldestSubmission.Add(time.Minute).Sub(now),
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,112 @@
+//go:build unit
+
+package pipelines
+
+import (
+	"context"
+	"time"
+
+	argo "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/controllers/pipelines/internal/workflowconstants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("WorkflowLimitThrottle", func() {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	var provider *pipelineshub.Provider
+
+	providerWorkflow := func(providerName string, created time.Time, phase argo.WorkflowPhase) *argo.Workflow {
+		return &argo.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              apis.RandomLowercaseString(),
+				Namespace:         provider.Namespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels:            map[string]string{workflowconstants.ProviderNameLabelKey: providerName},
+			},
+			Status: argo.WorkflowStatus{Phase: phase},
+		}
+	}
+
+	throttle := func(workflows ...client.Object) *Throttling {
+		scheme := runtime.NewScheme()
+		Expect(argo.AddToScheme(scheme)).To(Succeed())
+
+		wlt := WorkflowLimitThrottle{
+			Client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(workflows...).Build(),
+			currentTimeFunc: func() time.Time { return now },
+		}
+
+		throttling, err := wlt.Throttle(ctx, *provider)
+		Expect(err).NotTo(HaveOccurred())
+		return throttling
+	}
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		provider.Spec.WorkflowLimits = &pipelineshub.WorkflowLimits{}
+	})
+
+	It("admits workflows without limits", func() {
+		provider.Spec.WorkflowLimits = nil
+
+		Expect(throttle(providerWorkflow(provider.Name, now, argo.WorkflowRunning))).To(BeNil())
+	})
+
+	When("the number of concurrent workflows is limited", func() {
+		BeforeEach(func() {
+			provider.Spec.WorkflowLimits.MaxConcurrent = 2
+		})
+
+		It("throttles once the limit is reached", func() {
+			throttling := throttle(
+				providerWorkflow(provider.Name, now.Add(-time.Hour), argo.WorkflowRunning),
+				providerWorkflow(provider.Name, now.Add(-time.Hour), argo.WorkflowPending),
+			)
+
+			Expect(throttling).NotTo(BeNil())
+			Expect(throttling.Reason).To(Equal(apis.ThrottleReasons.MaxConcurrent))
+			Expect(throttling.RetryAfter).To(Equal(DefaultThrottleRetryInterval))
+		})
+
+		It("does not count completed workflows or those of other providers", func() {
+			Expect(throttle(
+				providerWorkflow(provider.Name, now.Add(-time.Hour), argo.WorkflowRunning),
+				providerWorkflow(provider.Name, now.Add(-time.Hour), argo.WorkflowSucceeded),
+				providerWorkflow("other-provider", now.Add(-time.Hour), argo.WorkflowRunning),
+			)).To(BeNil())
+		})
+	})
+
+	When("the number of submissions per minute is limited", func() {
+		BeforeEach(func() {
+			provider.Spec.WorkflowLimits.MaxSubmissionsPerMinute = 2
+		})
+
+		It("throttles until the oldest submission is a minute old", func() {
+			throttling := throttle(
+				providerWorkflow(provider.Name, now.Add(-40*time.Second), argo.WorkflowSucceeded),
+				providerWorkflow(provider.Name, now.Add(-10*time.Second), argo.WorkflowSucceeded),
+			)
+
+			Expect(throttling).NotTo(BeNil())
+			Expect(throttling.Reason).To(Equal(apis.ThrottleReasons.MaxSubmissionsPerMinute))
+			Expect(throttling.RetryAfter).To(Equal(20 * time.Second))
+		})
+
+		It("does not count submissions older than a minute", func() {
+			Expect(throttle(
+				providerWorkflow(provider.Name, now.Add(-2*time.Minute), argo.WorkflowSucceeded),
+				providerWorkflow(provider.Name, now.Add(-10*time.Second), argo.WorkflowSucceeded),
+			)).To(BeNil())
+		})
+	})
+})
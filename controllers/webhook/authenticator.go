@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	HttpHeaderAuthorization = "Authorization"
+	bearerPrefix            = "Bearer "
+	// TokenReviewCacheTTL is how long the outcome of a successful token
+	// review is reused for. It never outlives the token.
+	TokenReviewCacheTTL = time.Minute
+)
+
+// Authenticator verifies that a run completion event was sent by the
+// provider it names.
+type Authenticator interface {
+	Authenticate(ctx context.Context, request *http.Request, provider common.NamespacedName) EventError
+}
+
+// ServiceAccountAuthenticator authenticates provider services by the
+// ServiceAccount token they send as a bearer token. The token is verified
+// with a TokenReview, must be issued for the audience of the operator only
+// and must belong to the ServiceAccount of the provider. Tokens that are also
+// valid for other audiences, such as the API server, are rejected so that
+// provider services never send credentials that grant access to the cluster.
+type ServiceAccountAuthenticator struct {
+	client          client.Client
+	audience        string
+	mutex           sync.Mutex
+	reviews         map[[sha256.Size]byte]cachedReview
+	currentTimeFunc func() time.Time
+}
+
+type cachedReview struct {
+	username string
+	expires  time.Time
+}
+
+func NewServiceAccountAuthenticator(client client.Client, audience string) *ServiceAccountAuthenticator {
+	return &ServiceAccountAuthenticator{
+		client:          client,
+		audience:        audience,
+		reviews:         map[[sha256.Size]byte]cachedReview{},
+		currentTimeFunc: time.Now,
+	}
+}
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+func (saa *ServiceAccountAuthenticator) Authenticate(ctx context.Context, request *http.Request, providerName common.NamespacedName) EventError {
+	logger := log.FromContext(ctx)
+
+	token, found := strings.CutPrefix(request.Header.Get(HttpHeaderAuthorization), bearerPrefix)
+	if !found || token == "" {
+		return &UnauthenticatedError{"bearer token missing"}
+	}
+
+	username, eventErr := saa.review(ctx, token)
+	if eventErr != nil {
+		return eventErr
+	}
+
+	name := fmt.Sprintf("%s/%s", providerName.Namespace, providerName.Name)
+	provider := &pipelineshub.Provider{}
+	if err := saa.client.Get(ctx, client.ObjectKey{Namespace: providerName.Namespace, Name: providerName.Name}, provider); err != nil {
+		logger.Error(err, "failed to load", "Provider", providerName)
+		if k8sErrors.IsNotFound(err) {
+			return &UnauthorisedError{fmt.Sprintf("unknown provider %s", name)}
+		}
+		return &FatalError{err.Error()}
+	}
+
+	expectedUsername := serviceAccountUsername(provider.Namespace, provider.Spec.ServiceAccount)
+	if username != expectedUsername {
+		logger.Info("rejecting run completion event from another identity", "provider", name, "username", username)
+		return &UnauthorisedError{fmt.Sprintf("%s is not the service account of provider %s", username, name)}
+	}
+
+	return nil
+}
+
+// review returns the username of the token, reusing the outcome of previous
+// reviews of the same token.
+func (saa *ServiceAccountAuthenticator) review(ctx context.Context, token string) (string, EventError) {
+	key := sha256.Sum256([]byte(token))
+	now := saa.currentTimeFunc()
+
+	saa.mutex.Lock()
+	cached, ok := saa.reviews[key]
+	saa.mutex.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.username, nil
+	}
+
+	claims, err := parseTokenClaims(token)
+	if err != nil {
+		return "", &UnauthenticatedError{fmt.Sprintf("token rejected: %s", err)}
+	}
+	if len(claims.Audiences) != 1 || claims.Audiences[0] != saa.audience {
+		return "", &UnauthenticatedError{fmt.Sprintf("token rejected: must only be issued for the audience %s", saa.audience)}
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{saa.audience},
+		},
+	}
+	if err := saa.client.Create(ctx, review); err != nil {
+		log.FromContext(ctx).Error(err, "failed to review token")
+		return "", &FatalError{err.Error()}
+	}
+
+	if !review.Status.Authenticated {
+		return "", &UnauthenticatedError{fmt.Sprintf("token rejected: %s", review.Status.Error)}
+	}
+
+	expires := now.Add(TokenReviewCacheTTL)
+	if claims.Expiry != 0 {
+		expires = time.Unix(min(claims.Expiry, expires.Unix()), 0)
+	}
+
+	saa.mutex.Lock()
+	for k, r := range saa.reviews {
+		if !now.Before(r.expires) {
+			delete(saa.reviews, k)
+		}
+	}
+	saa.reviews[key] = cachedReview{username: review.Status.User.Username, expires: expires}
+	saa.mutex.Unlock()
+
+	return review.Status.User.Username, nil
+}
+
+// tokenClaims are the claims of a ServiceAccount token that are checked in
+// addition to its review. The audience may be a string or a list.
+type tokenClaims struct {
+	Audiences audiences `json:"aud"`
+	Expiry    int64     `json:"exp"`
+}
+
+type audiences []string
+
+func (a *audiences) UnmarshalJSON(data []byte) error {
+	var audience string
+	if err := json.Unmarshal(data, &audience); err == nil {
+		*a = audiences{audience}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// parseTokenClaims decodes the claims of a JWT without verifying them, which
+// is left to the TokenReview.
+func parseTokenClaims(token string) (tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return tokenClaims{}, errors.New("malformed token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return tokenClaims{}, fmt.Errorf("malformed token: %w", err)
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return tokenClaims{}, fmt.Errorf("malformed token: %w", err)
+	}
+
+	return claims, nil
+}
+
+func serviceAccountUsername(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
@@ -0,0 +1,200 @@
+//go:build unit
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("ServiceAccountAuthenticator", func() {
+	ctx := context.Background()
+
+	var (
+		provider *pipelineshub.Provider
+		reviewed authenticationv1.TokenReviewSpec
+		reviews  int
+	)
+
+	BeforeEach(func() {
+		provider = pipelineshub.RandomProvider()
+		provider.Spec.ServiceAccount = "provider-sa"
+		reviewed = authenticationv1.TokenReviewSpec{}
+		reviews = 0
+	})
+
+	authenticator := func(status authenticationv1.TokenReviewStatus) *ServiceAccountAuthenticator {
+		scheme := runtime.NewScheme()
+		Expect(k8sScheme.AddToScheme(scheme)).To(Succeed())
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(provider).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					review := obj.(*authenticationv1.TokenReview)
+					reviewed = review.Spec
+					reviews++
+					review.Status = status
+					return nil
+				},
+			}).
+			Build()
+
+		return NewServiceAccountAuthenticator(k8sClient, "kfp-operator")
+	}
+
+	tokenFor := func(audience any, expiry time.Time) string {
+		encode := func(v any) string {
+			data, err := json.Marshal(v)
+			Expect(err).NotTo(HaveOccurred())
+			return base64.RawURLEncoding.EncodeToString(data)
+		}
+
+		return encode(map[string]string{"alg": "RS256"}) + "." +
+			encode(map[string]any{"aud": audience, "exp": expiry.Unix()}) +
+			".signature"
+	}
+
+	token := tokenFor("kfp-operator", time.Now().Add(time.Hour))
+
+	request := func(token string) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte("{}")))
+		Expect(err).NotTo(HaveOccurred())
+		if token != "" {
+			req.Header.Set(HttpHeaderAuthorization, "Bearer "+token)
+		}
+		return req
+	}
+
+	providerName := func() common.NamespacedName {
+		return common.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}
+	}
+
+	authenticated := func(username string) authenticationv1.TokenReviewStatus {
+		return authenticationv1.TokenReviewStatus{
+			Authenticated: true,
+			User:          authenticationv1.UserInfo{Username: username},
+		}
+	}
+
+	statusCode := func(err EventError) int {
+		recorder := httptest.NewRecorder()
+		err.SendHttpError(recorder)
+		return recorder.Code
+	}
+
+	It("accepts the service account of the provider", func() {
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa"))).
+			Authenticate(ctx, request(token), providerName())
+
+		Expect(err).To(BeNil())
+		Expect(reviewed).To(Equal(authenticationv1.TokenReviewSpec{Token: token, Audiences: []string{"kfp-operator"}}))
+	})
+
+	It("accepts tokens with a list of only the audience of the operator", func() {
+		listToken := tokenFor([]string{"kfp-operator"}, time.Now().Add(time.Hour))
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa"))).
+			Authenticate(ctx, request(listToken), providerName())
+
+		Expect(err).To(BeNil())
+	})
+
+	It("rejects tokens issued for the API server without reviewing them", func() {
+		apiServerToken := tokenFor([]string{"https://kubernetes.default.svc"}, time.Now().Add(time.Hour))
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa"))).
+			Authenticate(ctx, request(apiServerToken), providerName())
+
+		Expect(statusCode(err)).To(Equal(http.StatusUnauthorized))
+		Expect(reviews).To(BeZero())
+	})
+
+	It("rejects tokens that are also issued for other audiences", func() {
+		sharedToken := tokenFor([]string{"kfp-operator", "https://kubernetes.default.svc"}, time.Now().Add(time.Hour))
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa"))).
+			Authenticate(ctx, request(sharedToken), providerName())
+
+		Expect(statusCode(err)).To(Equal(http.StatusUnauthorized))
+		Expect(reviews).To(BeZero())
+	})
+
+	It("rejects malformed tokens", func() {
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa"))).
+			Authenticate(ctx, request("a-token"), providerName())
+
+		Expect(statusCode(err)).To(Equal(http.StatusUnauthorized))
+		Expect(reviews).To(BeZero())
+	})
+
+	It("reuses the review of a token until the cache expires", func() {
+		now := time.Now()
+		saa := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa")))
+		saa.currentTimeFunc = func() time.Time { return now }
+
+		Expect(saa.Authenticate(ctx, request(token), providerName())).To(BeNil())
+		Expect(saa.Authenticate(ctx, request(token), providerName())).To(BeNil())
+		Expect(reviews).To(Equal(1))
+
+		now = now.Add(TokenReviewCacheTTL)
+		Expect(saa.Authenticate(ctx, request(token), providerName())).To(BeNil())
+		Expect(reviews).To(Equal(2))
+	})
+
+	It("does not reuse the review of a token beyond its expiry", func() {
+		now := time.Now()
+		shortLivedToken := tokenFor("kfp-operator", now.Add(time.Second))
+		saa := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa")))
+		saa.currentTimeFunc = func() time.Time { return now }
+
+		Expect(saa.Authenticate(ctx, request(shortLivedToken), providerName())).To(BeNil())
+
+		now = now.Add(2 * time.Second)
+		Expect(saa.Authenticate(ctx, request(shortLivedToken), providerName())).To(BeNil())
+		Expect(reviews).To(Equal(2))
+	})
+
+	It("rejects requests without a token", func() {
+		err := authenticator(authenticationv1.TokenReviewStatus{}).Authenticate(ctx, request(""), providerName())
+
+		Expect(statusCode(err)).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects tokens that fail the review", func() {
+		err := authenticator(authenticationv1.TokenReviewStatus{Error: "token expired"}).
+			Authenticate(ctx, request(token), providerName())
+
+		Expect(err).To(MatchError("token rejected: token expired"))
+		Expect(statusCode(err)).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects other service accounts", func() {
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "another-sa"))).
+			Authenticate(ctx, request(token), providerName())
+
+		Expect(statusCode(err)).To(Equal(http.StatusForbidden))
+	})
+
+	It("rejects unknown providers", func() {
+		err := authenticator(authenticated(serviceAccountUsername(provider.Namespace, "provider-sa"))).
+			Authenticate(ctx, request(token), common.NamespacedName{Namespace: provider.Namespace, Name: "unknown"})
+
+		Expect(statusCode(err)).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,373 @@
+package webhook
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	HttpHeaderAccept        = "Accept"
+	HttpContentTypeGraphviz = "text/vnd.graphviz"
+)
+
+var DependencyGraphEdgeTypes = struct {
+	Pipeline  string
+	Trigger   string
+	ValueFrom string
+	Owner     string
+}{
+	Pipeline:  "pipeline",
+	Trigger:   "trigger",
+	ValueFrom: "valueFrom",
+	Owner:     "owner",
+}
+
+type DependencyGraphNode struct {
+	Id        string                       `json:"id"`
+	Kind      string                       `json:"kind"`
+	Namespace string                       `json:"namespace"`
+	Name      string                       `json:"name"`
+	SyncState apis.SynchronizationState    `json:"syncState,omitempty"`
+	LatestRun pipelineshub.CompletionState `json:"latestRun,omitempty"`
+}
+
+// DependencyGraphEdge points from a resource to a resource that depends on it,
+// i.e. in the direction in which changes and run completions propagate.
+type DependencyGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+type DependencyGraph struct {
+	Nodes []DependencyGraphNode `json:"nodes"`
+	Edges []DependencyGraphEdge `json:"edges"`
+}
+
+func dependencyGraphNodeId(kind string, name types.NamespacedName) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// BuildDependencyGraph computes the graph of the given resources. Resources
+// that do not match the selector are omitted unless they are referenced by a
+// selected resource, in which case they are added without annotations.
+// Runs are used to determine the latest run outcome of RunConfigurations
+// whether or not they are selected.
+func BuildDependencyGraph(
+	pipelines []pipelineshub.Pipeline,
+	runConfigurations []pipelineshub.RunConfiguration,
+	runs []pipelineshub.Run,
+	selector labels.Selector,
+) DependencyGraph {
+	nodes := map[string]DependencyGraphNode{}
+	var edges []DependencyGraphEdge
+
+	addNode := func(node DependencyGraphNode) string {
+		node.Id = dependencyGraphNodeId(node.Kind, types.NamespacedName{Namespace: node.Namespace, Name: node.Name})
+		nodes[node.Id] = node
+		return node.Id
+	}
+
+	addReference := func(kind string, name types.NamespacedName) string {
+		id := dependencyGraphNodeId(kind, name)
+		if _, exists := nodes[id]; !exists {
+			nodes[id] = DependencyGraphNode{Id: id, Kind: kind, Namespace: name.Namespace, Name: name.Name}
+		}
+		return id
+	}
+
+	selected := func(object metav1.Object) bool {
+		return selector.Matches(labels.Set(object.GetLabels()))
+	}
+
+	latestRuns := latestRunOutcomes(runs)
+
+	for _, pipeline := range pipelines {
+		if !selected(&pipeline) {
+			continue
+		}
+
+		addNode(DependencyGraphNode{
+			Kind:      pipeline.GetKind(),
+			Namespace: pipeline.Namespace,
+			Name:      pipeline.Name,
+			SyncState: pipeline.Status.Conditions.GetSyncStateFromReason(),
+		})
+	}
+
+	for _, rc := range runConfigurations {
+		if !selected(&rc) {
+			continue
+		}
+
+		id := addNode(DependencyGraphNode{
+			Kind:      rc.GetKind(),
+			Namespace: rc.Namespace,
+			Name:      rc.Name,
+			SyncState: rc.Status.Conditions.GetSyncStateFromReason(),
+			LatestRun: latestRuns[rc.GetNamespacedName()],
+		})
+
+		edges = append(edges, DependencyGraphEdge{
+			From: addReference(pipelineshub.Pipeline{}.GetKind(), types.NamespacedName{
+				Namespace: rc.Namespace,
+				Name:      rc.Spec.Run.Pipeline.Name,
+			}),
+			To:   id,
+			Type: DependencyGraphEdgeTypes.Pipeline,
+		})
+
+		for _, trigger := range rc.Spec.Triggers.RunConfigurations {
+			edges = append(edges, DependencyGraphEdge{
+				From: addReference(rc.GetKind(), resolveNamespace(trigger.Namespace, trigger.Name, rc.Namespace)),
+				To:   id,
+				Type: DependencyGraphEdgeTypes.Trigger,
+			})
+		}
+
+		edges = append(edges, valueFromEdges(rc.Spec.Run.Parameters, rc.Namespace, id, addReference)...)
+	}
+
+	for _, run := range runs {
+		if !selected(&run) {
+			continue
+		}
+
+		id := addNode(DependencyGraphNode{
+			Kind:      run.GetKind(),
+			Namespace: run.Namespace,
+			Name:      run.Name,
+			SyncState: run.Status.Conditions.GetSyncStateFromReason(),
+			LatestRun: run.Status.CompletionState,
+		})
+
+		edges = append(edges, DependencyGraphEdge{
+			From: addReference(pipelineshub.Pipeline{}.GetKind(), types.NamespacedName{
+				Namespace: run.Namespace,
+				Name:      run.Spec.Pipeline.Name,
+			}),
+			To:   id,
+			Type: DependencyGraphEdgeTypes.Pipeline,
+		})
+
+		if owner := runConfigurationOwner(&run); owner != nil {
+			edges = append(edges, DependencyGraphEdge{
+				From: addReference((&pipelineshub.RunConfiguration{}).GetKind(), *owner),
+				To:   id,
+				Type: DependencyGraphEdgeTypes.Owner,
+			})
+		}
+
+		edges = append(edges, valueFromEdges(run.Spec.Parameters, run.Namespace, id, addReference)...)
+	}
+
+	graph := DependencyGraph{
+		Nodes: slices.SortedFunc(func(yield func(DependencyGraphNode) bool) {
+			for _, node := range nodes {
+				if !yield(node) {
+					return
+				}
+			}
+		}, func(a, b DependencyGraphNode) int {
+			return cmp.Compare(a.Id, b.Id)
+		}),
+		Edges: lo.Uniq(edges),
+	}
+
+	slices.SortFunc(graph.Edges, func(a, b DependencyGraphEdge) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To), cmp.Compare(a.Type, b.Type))
+	})
+
+	if graph.Edges == nil {
+		graph.Edges = []DependencyGraphEdge{}
+	}
+
+	return graph
+}
+
+func resolveNamespace(namespace, name, defaultNamespace string) types.NamespacedName {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+func valueFromEdges(
+	parameters []pipelineshub.Parameter,
+	namespace string,
+	to string,
+	addReference func(string, types.NamespacedName) string,
+) []DependencyGraphEdge {
+	return lo.FilterMap(parameters, func(p pipelineshub.Parameter, _ int) (DependencyGraphEdge, bool) {
+		if p.ValueFrom == nil || p.ValueFrom.RunConfigurationRef.Name.Name == "" {
+			return DependencyGraphEdge{}, false
+		}
+
+		reference := p.ValueFrom.RunConfigurationRef.Name
+		return DependencyGraphEdge{
+			From: addReference(
+				(&pipelineshub.RunConfiguration{}).GetKind(),
+				resolveNamespace(reference.Namespace, reference.Name, namespace),
+			),
+			To:   to,
+			Type: DependencyGraphEdgeTypes.ValueFrom,
+		}, true
+	})
+}
+
+func runConfigurationOwner(run *pipelineshub.Run) *types.NamespacedName {
+	owner := metav1.GetControllerOf(run)
+	if owner == nil || owner.Kind != "RunConfiguration" {
+		return nil
+	}
+
+	return &types.NamespacedName{Namespace: run.Namespace, Name: owner.Name}
+}
+
+// latestRunOutcomes maps RunConfigurations to the completion state of the most
+// recently created Run they own that has completed.
+func latestRunOutcomes(runs []pipelineshub.Run) map[types.NamespacedName]pipelineshub.CompletionState {
+	latest := map[types.NamespacedName]pipelineshub.Run{}
+
+	for _, run := range runs {
+		owner := runConfigurationOwner(&run)
+		if owner == nil || run.Status.CompletionState == "" {
+			continue
+		}
+
+		if current, exists := latest[*owner]; !exists || current.CreationTimestamp.Before(&run.CreationTimestamp) {
+			latest[*owner] = run
+		}
+	}
+
+	return lo.MapValues(latest, func(run pipelineshub.Run, _ types.NamespacedName) pipelineshub.CompletionState {
+		return run.Status.CompletionState
+	})
+}
+
+// DOT renders the graph in the Graphviz DOT language.
+func (g DependencyGraph) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph dependencies {\n")
+
+	for _, node := range g.Nodes {
+		label := []string{node.Kind, fmt.Sprintf("%s/%s", node.Namespace, node.Name)}
+		if node.SyncState != "" {
+			label = append(label, string(node.SyncState))
+		}
+		if node.LatestRun != "" {
+			label = append(label, fmt.Sprintf("latest run: %s", node.LatestRun))
+		}
+
+		fmt.Fprintf(&sb, "  %q [label=%q];\n", node.Id, strings.Join(label, "\n"))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Type)
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+type DependencyGraphHandler struct {
+	client client.Reader
+}
+
+func NewDependencyGraphHandler(client client.Reader) DependencyGraphHandler {
+	return DependencyGraphHandler{client: client}
+}
+
+func (dgh DependencyGraphHandler) buildGraph(ctx context.Context, namespace string, selector labels.Selector) (DependencyGraph, error) {
+	pipelines := &pipelineshub.PipelineList{}
+	if err := dgh.client.List(ctx, pipelines, client.InNamespace(namespace)); err != nil {
+		return DependencyGraph{}, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	runConfigurations := &pipelineshub.RunConfigurationList{}
+	if err := dgh.client.List(ctx, runConfigurations, client.InNamespace(namespace)); err != nil {
+		return DependencyGraph{}, fmt.Errorf("failed to list run configurations: %w", err)
+	}
+
+	runs := &pipelineshub.RunList{}
+	if err := dgh.client.List(ctx, runs, client.InNamespace(namespace)); err != nil {
+		return DependencyGraph{}, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	return BuildDependencyGraph(pipelines.Items, runConfigurations.Items, runs.Items, selector), nil
+}
+
+func wantsDOT(request *http.Request) bool {
+	switch request.URL.Query().Get("format") {
+	case "dot":
+		return true
+	case "json":
+		return false
+	default:
+		return strings.Contains(request.Header.Get(HttpHeaderAccept), HttpContentTypeGraphviz)
+	}
+}
+
+func (dgh DependencyGraphHandler) HandleGraph(ctx context.Context) func(responseWriter http.ResponseWriter, request *http.Request) {
+	logger := log.FromContext(ctx)
+
+	return func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodGet {
+			logger.Error(errors.New("DependencyGraph call failed"), "Invalid http method used, only GET supported", "method", request.Method)
+			http.Error(responseWriter, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := request.URL.Query()
+
+		namespace := query.Get("namespace")
+		if namespace == "" {
+			http.Error(responseWriter, "namespace is required", http.StatusBadRequest)
+			return
+		}
+
+		selector, err := labels.Parse(query.Get("labelSelector"))
+		if err != nil {
+			http.Error(responseWriter, fmt.Sprintf("invalid labelSelector: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		graph, err := dgh.buildGraph(request.Context(), namespace, selector)
+		if err != nil {
+			logger.Error(err, "failed to build dependency graph")
+			http.Error(responseWriter, "Failed to build dependency graph", http.StatusInternalServerError)
+			return
+		}
+
+		if wantsDOT(request) {
+			responseWriter.Header().Set(HttpHeaderContentType, HttpContentTypeGraphviz)
+			if _, err := responseWriter.Write([]byte(graph.DOT())); err != nil {
+				logger.Error(err, "failed to write dependency graph")
+			}
+			return
+		}
+
+		responseWriter.Header().Set(HttpHeaderContentType, HttpContentTypeJSON)
+		if err := json.NewEncoder(responseWriter).Encode(graph); err != nil {
+			logger.Error(err, "failed to write dependency graph")
+		}
+	}
+}
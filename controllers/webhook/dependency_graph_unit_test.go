@@ -0,0 +1,202 @@
+//go:build unit
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/apis"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/internal/log"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Context("DependencyGraph", func() {
+	pipeline := pipelineshub.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "default"},
+	}
+	pipeline.Status.Conditions = apis.Conditions{{Type: apis.ConditionTypes.SynchronizationSucceeded, Reason: string(apis.Succeeded)}}
+
+	runConfiguration := func(name string, labelSet map[string]string) pipelineshub.RunConfiguration {
+		rc := pipelineshub.RunConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labelSet},
+		}
+		rc.Spec.Run.Pipeline = pipelineshub.PipelineIdentifier{Name: "pipeline"}
+		rc.Status.Conditions = apis.Conditions{{Type: apis.ConditionTypes.SynchronizationSucceeded, Reason: string(apis.Succeeded)}}
+		return rc
+	}
+
+	upstream := runConfiguration("upstream", map[string]string{"team": "a"})
+	downstream := runConfiguration("downstream", map[string]string{"team": "b"})
+	downstream.Spec.Triggers.RunConfigurations = []common.NamespacedName{{Name: "upstream"}}
+	downstream.Spec.Run.Parameters = []pipelineshub.Parameter{{
+		Name: "param",
+		ValueFrom: &pipelineshub.ValueFrom{
+			RunConfigurationRef: pipelineshub.RunConfigurationRef{
+				Name:           common.NamespacedName{Namespace: "other", Name: "source"},
+				OutputArtifact: "artifact",
+			},
+		},
+	}}
+
+	ownedRun := func(name string, created time.Time, state pipelineshub.CompletionState) pipelineshub.Run {
+		run := pipelineshub.Run{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+				OwnerReferences: []metav1.OwnerReference{{
+					Kind:       "RunConfiguration",
+					Name:       "upstream",
+					Controller: &[]bool{true}[0],
+				}},
+			},
+		}
+		run.Spec.Pipeline = pipelineshub.PipelineIdentifier{Name: "pipeline"}
+		run.Status.CompletionState = state
+		return run
+	}
+
+	now := time.Now()
+	runs := []pipelineshub.Run{
+		ownedRun("older", now.Add(-time.Hour), pipelineshub.CompletionStates.Succeeded),
+		ownedRun("newer", now, pipelineshub.CompletionStates.Failed),
+	}
+
+	Describe("BuildDependencyGraph", func() {
+		It("builds nodes and edges from references", func() {
+			graph := BuildDependencyGraph(
+				[]pipelineshub.Pipeline{pipeline},
+				[]pipelineshub.RunConfiguration{upstream, downstream},
+				runs,
+				labels.Everything(),
+			)
+
+			Expect(graph.Nodes).To(ConsistOf(
+				DependencyGraphNode{Id: "pipeline/default/pipeline", Kind: "pipeline", Namespace: "default", Name: "pipeline", SyncState: apis.Succeeded},
+				DependencyGraphNode{Id: "runconfiguration/default/upstream", Kind: "runconfiguration", Namespace: "default", Name: "upstream", SyncState: apis.Succeeded, LatestRun: pipelineshub.CompletionStates.Failed},
+				DependencyGraphNode{Id: "runconfiguration/default/downstream", Kind: "runconfiguration", Namespace: "default", Name: "downstream", SyncState: apis.Succeeded},
+				DependencyGraphNode{Id: "runconfiguration/other/source", Kind: "runconfiguration", Namespace: "other", Name: "source"},
+				DependencyGraphNode{Id: "run/default/older", Kind: "run", Namespace: "default", Name: "older", SyncState: apis.Unknown, LatestRun: pipelineshub.CompletionStates.Succeeded},
+				DependencyGraphNode{Id: "run/default/newer", Kind: "run", Namespace: "default", Name: "newer", SyncState: apis.Unknown, LatestRun: pipelineshub.CompletionStates.Failed},
+			))
+
+			Expect(graph.Edges).To(ContainElements(
+				DependencyGraphEdge{From: "pipeline/default/pipeline", To: "runconfiguration/default/upstream", Type: DependencyGraphEdgeTypes.Pipeline},
+				DependencyGraphEdge{From: "runconfiguration/default/upstream", To: "runconfiguration/default/downstream", Type: DependencyGraphEdgeTypes.Trigger},
+				DependencyGraphEdge{From: "runconfiguration/other/source", To: "runconfiguration/default/downstream", Type: DependencyGraphEdgeTypes.ValueFrom},
+				DependencyGraphEdge{From: "runconfiguration/default/upstream", To: "run/default/newer", Type: DependencyGraphEdgeTypes.Owner},
+			))
+			Expect(graph.Edges).To(HaveLen(8))
+		})
+
+		It("only annotates resources that match the selector", func() {
+			selector, err := labels.Parse("team=b")
+			Expect(err).NotTo(HaveOccurred())
+
+			graph := BuildDependencyGraph(
+				[]pipelineshub.Pipeline{pipeline},
+				[]pipelineshub.RunConfiguration{upstream, downstream},
+				runs,
+				selector,
+			)
+
+			Expect(graph.Nodes).To(ConsistOf(
+				DependencyGraphNode{Id: "pipeline/default/pipeline", Kind: "pipeline", Namespace: "default", Name: "pipeline"},
+				DependencyGraphNode{Id: "runconfiguration/default/upstream", Kind: "runconfiguration", Namespace: "default", Name: "upstream"},
+				DependencyGraphNode{Id: "runconfiguration/default/downstream", Kind: "runconfiguration", Namespace: "default", Name: "downstream", SyncState: apis.Succeeded},
+				DependencyGraphNode{Id: "runconfiguration/other/source", Kind: "runconfiguration", Namespace: "other", Name: "source"},
+			))
+			Expect(graph.Edges).To(HaveLen(3))
+		})
+	})
+
+	Describe("DOT", func() {
+		It("renders nodes and edges", func() {
+			graph := DependencyGraph{
+				Nodes: []DependencyGraphNode{
+					{Id: "pipeline/default/pipeline", Kind: "pipeline", Namespace: "default", Name: "pipeline", SyncState: apis.Succeeded},
+					{Id: "runconfiguration/default/rc", Kind: "runconfiguration", Namespace: "default", Name: "rc", LatestRun: pipelineshub.CompletionStates.Failed},
+				},
+				Edges: []DependencyGraphEdge{
+					{From: "pipeline/default/pipeline", To: "runconfiguration/default/rc", Type: DependencyGraphEdgeTypes.Pipeline},
+				},
+			}
+
+			Expect(graph.DOT()).To(Equal(`digraph dependencies {
+  "pipeline/default/pipeline" [label="pipeline\ndefault/pipeline\nSucceeded"];
+  "runconfiguration/default/rc" [label="runconfiguration\ndefault/rc\nlatest run: Failed"];
+  "pipeline/default/pipeline" -> "runconfiguration/default/rc" [label="pipeline"];
+}
+`))
+		})
+	})
+
+	Describe("HandleGraph", func() {
+		logger, _ := log.NewLogger(zapcore.DebugLevel)
+		ctx := logr.NewContext(context.Background(), logger)
+
+		scheme := runtime.NewScheme()
+		Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+
+		handler := NewDependencyGraphHandler(fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(&pipeline, &upstream, &downstream).
+			Build())
+
+		serve := func(method string, target string, accept string) *httptest.ResponseRecorder {
+			request := httptest.NewRequestWithContext(ctx, method, target, nil)
+			if accept != "" {
+				request.Header.Set(HttpHeaderAccept, accept)
+			}
+			recorder := httptest.NewRecorder()
+			handler.HandleGraph(ctx)(recorder, request)
+			return recorder
+		}
+
+		It("returns JSON by default", func() {
+			response := serve(http.MethodGet, "http://example.com/graph?namespace=default", "")
+
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Header().Get(HttpHeaderContentType)).To(Equal(HttpContentTypeJSON))
+
+			graph := DependencyGraph{}
+			Expect(json.Unmarshal(response.Body.Bytes(), &graph)).To(Succeed())
+			Expect(graph.Nodes).To(HaveLen(4))
+		})
+
+		It("returns DOT when requested", func() {
+			Expect(serve(http.MethodGet, "http://example.com/graph?namespace=default&format=dot", "").Header().Get(HttpHeaderContentType)).
+				To(Equal(HttpContentTypeGraphviz))
+			Expect(serve(http.MethodGet, "http://example.com/graph?namespace=default", HttpContentTypeGraphviz).Header().Get(HttpHeaderContentType)).
+				To(Equal(HttpContentTypeGraphviz))
+		})
+
+		It("rejects invalid label selectors", func() {
+			Expect(serve(http.MethodGet, "http://example.com/graph?namespace=default&labelSelector=%3D%3D", "").Code).
+				To(Equal(http.StatusBadRequest))
+		})
+
+		It("rejects requests without a namespace", func() {
+			Expect(serve(http.MethodGet, "http://example.com/graph", "").Code).
+				To(Equal(http.StatusBadRequest))
+		})
+
+		It("rejects methods other than GET", func() {
+			Expect(serve(http.MethodPost, "http://example.com/graph", "").Code).
+				To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+})
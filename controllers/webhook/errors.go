@@ -42,3 +42,27 @@ func (e *MissingResourceError) Error() string {
 func (e *MissingResourceError) SendHttpError(response http.ResponseWriter) {
 	http.Error(response, e.Error(), http.StatusGone)
 }
+
+type UnauthenticatedError struct {
+	Msg string
+}
+
+func (e *UnauthenticatedError) Error() string {
+	return e.Msg
+}
+
+func (e *UnauthenticatedError) SendHttpError(response http.ResponseWriter) {
+	http.Error(response, e.Error(), http.StatusUnauthorized)
+}
+
+type UnauthorisedError struct {
+	Msg string
+}
+
+func (e *UnauthorisedError) Error() string {
+	return e.Msg
+}
+
+func (e *UnauthorisedError) SendHttpError(response http.ResponseWriter) {
+	http.Error(response, e.Error(), http.StatusForbidden)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/go-bexpr"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 )
 
 type EventProcessor interface {
@@ -32,10 +33,61 @@ func (ep ResourceArtifactsEventProcessor) ToRunCompletionEvent(eventData *common
 
 	runCompletionEvent := eventData.ToRunCompletionEvent()
 	runCompletionEvent.Artifacts = ep.filter(eventData.PipelineComponents, artifacts)
+	enrichFromResources(&runCompletionEvent, runConfiguration, run)
 
 	return &runCompletionEvent, nil
 }
 
+// enrichFromResources adds the details of the run that are only known to the
+// operator, preferring the Run over the RunConfiguration as the latter may
+// have changed since the run was created.
+func enrichFromResources(event *common.RunCompletionEvent, runConfiguration *pipelineshub.RunConfiguration, run *pipelineshub.Run) {
+	if run != nil {
+		event.PipelineVersion = run.GetObservedPipelineVersion()
+		event.Parameters = resolvedParameters(run.Spec, run.Status.Dependencies)
+		if indicator := triggers.FromLabels(run.Labels); indicator != (triggers.Indicator{}) {
+			event.TriggerIndicator = &indicator
+		}
+		return
+	}
+
+	event.PipelineVersion = runConfiguration.GetObservedPipelineVersion()
+	event.Parameters = resolvedParameters(runConfiguration.Spec.Run, runConfiguration.Status.Dependencies)
+	// runs of RunConfigurations without a Run are created by their schedules.
+	// Runs whose Run cannot be found anymore were triggered by something else,
+	// which is not known.
+	if event.RunName == nil || event.RunName.Name == "" {
+		event.TriggerIndicator = &triggers.Indicator{
+			Type:            triggers.Schedule,
+			Source:          runConfiguration.Name,
+			SourceNamespace: runConfiguration.Namespace,
+		}
+	}
+}
+
+// resolvedParameters omits parameters that cannot be resolved anymore.
+func resolvedParameters(runSpec pipelineshub.RunSpec, dependencies pipelineshub.Dependencies) map[string]string {
+	parameters := map[string]string{}
+	for _, parameter := range runSpec.Parameters {
+		if parameter.ValueFrom == nil {
+			parameters[parameter.Name] = parameter.Value
+		}
+	}
+
+	resolved, _, err := runSpec.ResolveParameters(dependencies)
+	if err == nil {
+		for _, parameter := range resolved {
+			parameters[parameter.Name] = parameter.Value
+		}
+	}
+
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	return parameters
+}
+
 func filterByResourceArtifacts(pipelineComponents []common.PipelineComponent, outputArtifacts []pipelineshub.OutputArtifact) []common.Artifact {
 	artifacts := make([]common.Artifact, 0)
 	for _, outputArtifact := range outputArtifacts {
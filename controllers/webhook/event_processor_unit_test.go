@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 )
 
 var _ = Context("ToRunCompletionEvent", func() {
@@ -14,6 +15,8 @@ var _ = Context("ToRunCompletionEvent", func() {
 		It("returns RunCompletionEvent with filtered run configuration artifacts", func() {
 			rc := pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
 			rc.Spec.Run.Artifacts = []pipelineshub.OutputArtifact{{Name: "runconfig-artifact"}}
+			rc.Spec.Run.Parameters = []pipelineshub.Parameter{{Name: "some-parameter", Value: "some-value"}}
+			rc.Status.Dependencies.Pipeline.Version = "some-version"
 
 			runCompletionEventData := RandomRunCompletionEventData()
 			runCompletionEventData.RunConfigurationName = &common.NamespacedName{
@@ -36,6 +39,13 @@ var _ = Context("ToRunCompletionEvent", func() {
 				RunEndTime:           runCompletionEventData.RunEndTime,
 				Artifacts:            expectedArtifacts,
 				Provider:             runCompletionEventData.Provider,
+				PipelineVersion:      "some-version",
+				Parameters:           map[string]string{"some-parameter": "some-value"},
+				TriggerIndicator: &triggers.Indicator{
+					Type:            triggers.Schedule,
+					Source:          rc.Name,
+					SourceNamespace: rc.Namespace,
+				},
 			}))
 
 		})
@@ -45,6 +55,14 @@ var _ = Context("ToRunCompletionEvent", func() {
 		It("returns RunCompletionEvent with filtered run artifacts", func() {
 			run := pipelineshub.RandomRun(common.RandomNamespacedName())
 			run.Spec.Artifacts = []pipelineshub.OutputArtifact{{Name: "run-artifact"}}
+			run.Spec.Parameters = []pipelineshub.Parameter{{Name: "some-parameter", Value: "some-value"}}
+			run.Status.Dependencies.Pipeline.Version = "some-version"
+			indicator := triggers.Indicator{
+				Type:            triggers.OnChangeRunSpec,
+				Source:          "some-rc",
+				SourceNamespace: run.Namespace,
+			}
+			run.Labels = indicator.AsK8sLabels()
 
 			runCompletionEventData := RandomRunCompletionEventData()
 			runCompletionEventData.RunName = &common.NamespacedName{
@@ -67,14 +85,19 @@ var _ = Context("ToRunCompletionEvent", func() {
 				RunEndTime:           runCompletionEventData.RunEndTime,
 				Artifacts:            expectedArtifacts,
 				Provider:             runCompletionEventData.Provider,
+				PipelineVersion:      "some-version",
+				Parameters:           map[string]string{"some-parameter": "some-value"},
+				TriggerIndicator:     &indicator,
 			}))
 		})
 	})
 
-	When("both run configuration and run passed", func() {
-		It("returns RunCompletionEvent with filtered run configuration artifacts", func() {
+	When("the event names a run that cannot be found", func() {
+		It("returns RunCompletionEvent with filtered run configuration artifacts and no trigger indicator", func() {
 			rc := pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
 			rc.Spec.Run.Artifacts = []pipelineshub.OutputArtifact{{Name: "runconfig-artifact"}}
+			rc.Spec.Run.Parameters = []pipelineshub.Parameter{{Name: "some-parameter", Value: "some-value"}}
+			rc.Status.Dependencies.Pipeline.Version = "some-version"
 			run := pipelineshub.RandomRun(common.RandomNamespacedName())
 			run.Spec.Artifacts = []pipelineshub.OutputArtifact{{Name: "run-artifact"}}
 
@@ -102,6 +125,8 @@ var _ = Context("ToRunCompletionEvent", func() {
 				RunEndTime:           runCompletionEventData.RunEndTime,
 				Artifacts:            expectedArtifacts,
 				Provider:             runCompletionEventData.Provider,
+				PipelineVersion:      "some-version",
+				Parameters:           map[string]string{"some-parameter": "some-value"},
 			}))
 
 		})
@@ -120,6 +145,41 @@ var _ = Context("ToRunCompletionEvent", func() {
 	})
 })
 
+var _ = Context("resolvedParameters", func() {
+	referencedRc := common.NamespacedName{Namespace: "namespace", Name: "referenced"}
+	runSpec := pipelineshub.RunSpec{
+		Parameters: []pipelineshub.Parameter{
+			{Name: "value", Value: "some-value"},
+			{Name: "reference", ValueFrom: &pipelineshub.ValueFrom{
+				RunConfigurationRef: pipelineshub.RunConfigurationRef{Name: referencedRc, OutputArtifact: "model"},
+			}},
+		},
+	}
+
+	It("resolves references to RunConfigurations", func() {
+		dependencies := pipelineshub.Dependencies{
+			RunConfigurations: map[string]pipelineshub.RunReference{
+				"namespace/referenced": {Artifacts: []common.Artifact{{Name: "model", Location: "gs://model"}}},
+			},
+		}
+
+		Expect(resolvedParameters(runSpec, dependencies)).To(Equal(map[string]string{
+			"value":     "some-value",
+			"reference": "gs://model",
+		}))
+	})
+
+	It("omits references that cannot be resolved", func() {
+		Expect(resolvedParameters(runSpec, pipelineshub.Dependencies{})).To(Equal(map[string]string{
+			"value": "some-value",
+		}))
+	})
+
+	It("returns nothing without parameters", func() {
+		Expect(resolvedParameters(pipelineshub.RunSpec{}, pipelineshub.Dependencies{})).To(BeNil())
+	})
+})
+
 var _ = Context("filter", func() {
 	basePipelineComponent := randomPipelineComponent()
 	baseOutputArtifact := pipelineshub.OutputArtifact{
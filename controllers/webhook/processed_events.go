@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+)
+
+// ProcessedEventsCapacity is the number of run completion events that are
+// remembered to recognise duplicate deliveries.
+const ProcessedEventsCapacity = 1024
+
+// processedEvents remembers the most recently processed run completion
+// events, evicting the least recently seen once the capacity is reached.
+type processedEvents struct {
+	mutex    sync.Mutex
+	capacity int
+	order    *list.List
+	index    map[string]*list.Element
+}
+
+func newProcessedEvents(capacity int) *processedEvents {
+	return &processedEvents{
+		capacity: capacity,
+		order:    list.New(),
+		index:    map[string]*list.Element{},
+	}
+}
+
+// processedEventKey identifies the completion of a run in a given state.
+// Events without a run id cannot be identified.
+func processedEventKey(eventData *common.RunCompletionEventData) (string, bool) {
+	if eventData.RunId == "" {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s", eventData.Provider.Namespace, eventData.Provider.Name, eventData.RunId, eventData.Status), true
+}
+
+// reserve records the event unless it has been processed or is being
+// processed already, in which case it returns false. Events are reserved
+// atomically so that concurrent deliveries of the same event are handled only
+// once. Events that fail to be handled must be released.
+func (pe *processedEvents) reserve(eventData *common.RunCompletionEventData) bool {
+	key, ok := processedEventKey(eventData)
+	if pe == nil || !ok {
+		return true
+	}
+
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+
+	if element, found := pe.index[key]; found {
+		pe.order.MoveToFront(element)
+		return false
+	}
+
+	pe.index[key] = pe.order.PushFront(key)
+
+	if pe.order.Len() > pe.capacity {
+		oldest := pe.order.Back()
+		pe.order.Remove(oldest)
+		delete(pe.index, oldest.Value.(string))
+	}
+
+	return true
+}
+
+// release forgets a reserved event so that it is handled again when it is
+// redelivered.
+func (pe *processedEvents) release(eventData *common.RunCompletionEventData) {
+	key, ok := processedEventKey(eventData)
+	if pe == nil || !ok {
+		return
+	}
+
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+
+	if element, found := pe.index[key]; found {
+		pe.order.Remove(element)
+		delete(pe.index, key)
+	}
+}
+
+// alreadyRecorded checks whether the completion of the run has been recorded
+// on the status of every resource the event refers to, which is the case when
+// it has been processed before the cache was populated, e.g. by an earlier
+// instance of the operator.
+func alreadyRecorded(
+	eventData *common.RunCompletionEventData,
+	runConfiguration *pipelineshub.RunConfiguration,
+	run *pipelineshub.Run,
+) bool {
+	if eventData.RunId == "" || (runConfiguration == nil && run == nil) {
+		return false
+	}
+
+	if runConfiguration != nil &&
+		(eventData.Status != common.RunCompletionStatuses.Succeeded ||
+			runConfiguration.Status.LatestRuns.Succeeded.ProviderId != eventData.RunId) {
+		return false
+	}
+
+	if run != nil {
+		completionState := completionStateForRunCompletionStatus(eventData.Status)
+		if completionState == nil ||
+			run.Status.Provider.Id != eventData.RunId ||
+			run.Status.CompletionState != *completionState {
+			return false
+		}
+	}
+
+	return true
+}
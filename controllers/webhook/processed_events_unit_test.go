@@ -0,0 +1,148 @@
+//go:build unit
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+)
+
+var _ = Context("processedEvents", func() {
+	It("reserves each event once", func() {
+		processed := newProcessedEvents(2)
+		eventData := RandomRunCompletionEventData()
+
+		Expect(processed.reserve(&eventData)).To(BeTrue())
+		Expect(processed.reserve(&eventData)).To(BeFalse())
+	})
+
+	It("reserves events again once they are released", func() {
+		processed := newProcessedEvents(2)
+		eventData := RandomRunCompletionEventData()
+
+		Expect(processed.reserve(&eventData)).To(BeTrue())
+		processed.release(&eventData)
+		Expect(processed.reserve(&eventData)).To(BeTrue())
+	})
+
+	It("reserves each event once when delivered concurrently", func() {
+		processed := newProcessedEvents(2)
+		eventData := RandomRunCompletionEventData()
+		reserved := make(chan bool)
+
+		for range 10 {
+			go func() {
+				reserved <- processed.reserve(&eventData)
+			}()
+		}
+
+		reservations := 0
+		for range 10 {
+			if <-reserved {
+				reservations++
+			}
+		}
+		Expect(reservations).To(Equal(1))
+	})
+
+	It("distinguishes the status of a run", func() {
+		processed := newProcessedEvents(2)
+		eventData := RandomRunCompletionEventData()
+		processed.reserve(&eventData)
+
+		eventData.Status = common.RunCompletionStatuses.Failed
+		Expect(processed.reserve(&eventData)).To(BeTrue())
+	})
+
+	It("always reserves events without a run id", func() {
+		processed := newProcessedEvents(2)
+		eventData := RandomRunCompletionEventData()
+		eventData.RunId = ""
+		processed.reserve(&eventData)
+
+		Expect(processed.reserve(&eventData)).To(BeTrue())
+	})
+
+	It("evicts the least recently seen event", func() {
+		processed := newProcessedEvents(2)
+		first := RandomRunCompletionEventData()
+		second := RandomRunCompletionEventData()
+		third := RandomRunCompletionEventData()
+
+		processed.reserve(&first)
+		processed.reserve(&second)
+		Expect(processed.reserve(&first)).To(BeFalse())
+		processed.reserve(&third)
+
+		Expect(processed.reserve(&first)).To(BeFalse())
+		Expect(processed.reserve(&second)).To(BeTrue())
+	})
+
+	It("always reserves events when not set", func() {
+		var processed *processedEvents
+		eventData := RandomRunCompletionEventData()
+		processed.reserve(&eventData)
+
+		Expect(processed.reserve(&eventData)).To(BeTrue())
+		Expect(func() { processed.release(&eventData) }).NotTo(Panic())
+	})
+})
+
+var _ = Context("alreadyRecorded", func() {
+	var eventData common.RunCompletionEventData
+
+	BeforeEach(func() {
+		eventData = RandomRunCompletionEventData()
+	})
+
+	recordedRun := func() *pipelineshub.Run {
+		run := pipelineshub.RandomRun(common.RandomNamespacedName())
+		run.Status.Provider.Id = eventData.RunId
+		run.Status.CompletionState = pipelineshub.CompletionStates.Succeeded
+		return run
+	}
+
+	recordedRunConfiguration := func() *pipelineshub.RunConfiguration {
+		rc := pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
+		rc.Status.LatestRuns.Succeeded.ProviderId = eventData.RunId
+		return rc
+	}
+
+	It("is true when every resource has recorded the completion", func() {
+		Expect(alreadyRecorded(&eventData, recordedRunConfiguration(), recordedRun())).To(BeTrue())
+		Expect(alreadyRecorded(&eventData, nil, recordedRun())).To(BeTrue())
+		Expect(alreadyRecorded(&eventData, recordedRunConfiguration(), nil)).To(BeTrue())
+	})
+
+	It("is false without resources", func() {
+		Expect(alreadyRecorded(&eventData, nil, nil)).To(BeFalse())
+	})
+
+	It("is false when the run has not completed", func() {
+		run := recordedRun()
+		run.Status.CompletionState = ""
+
+		Expect(alreadyRecorded(&eventData, recordedRunConfiguration(), run)).To(BeFalse())
+	})
+
+	It("is false when the run completed in another state", func() {
+		eventData.Status = common.RunCompletionStatuses.Failed
+
+		Expect(alreadyRecorded(&eventData, nil, recordedRun())).To(BeFalse())
+	})
+
+	It("is false when the run configuration recorded another run", func() {
+		rc := recordedRunConfiguration()
+		rc.Status.LatestRuns.Succeeded.ProviderId = common.RandomString()
+
+		Expect(alreadyRecorded(&eventData, rc, recordedRun())).To(BeFalse())
+	})
+
+	It("is false for failed runs of run configurations", func() {
+		eventData.Status = common.RunCompletionStatuses.Failed
+
+		Expect(alreadyRecorded(&eventData, recordedRunConfiguration(), nil)).To(BeFalse())
+	})
+})
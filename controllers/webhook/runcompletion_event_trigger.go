@@ -6,6 +6,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/sky-uk/kfp-operator/internal/config"
@@ -116,11 +117,28 @@ func RunCompletionEventToProto(event common.RunCompletionEvent) (*pb.RunCompleti
 		Status:               statusToProto(event.Status),
 		RunStartTime:         runStartTime,
 		RunEndTime:           runEndTime,
+		PipelineVersion:      event.PipelineVersion,
+		Parameters:           event.Parameters,
+		TriggerIndicator:     triggerIndicatorToProto(event.TriggerIndicator),
+		RunUrl:               event.RunUrl,
+		FailureReason:        event.FailureReason,
 	}
 
 	return &runCompletionEvent, nil
 }
 
+func triggerIndicatorToProto(indicator *triggers.Indicator) *pb.TriggerIndicator {
+	if indicator == nil {
+		return nil
+	}
+
+	return &pb.TriggerIndicator{
+		Type:            indicator.Type,
+		Source:          indicator.Source,
+		SourceNamespace: indicator.SourceNamespace,
+	}
+}
+
 func artifactToProto(commonArtifacts []common.Artifact) []*pb.Artifact {
 	pbArtifacts := []*pb.Artifact{}
 	for _, commonArtifact := range commonArtifacts {
@@ -16,6 +16,7 @@ import (
 	"github.com/sky-uk/kfp-operator/internal/config"
 	"github.com/sky-uk/kfp-operator/internal/log"
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 	pb "github.com/sky-uk/kfp-operator/triggers/run-completion-event-trigger/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -132,6 +133,33 @@ var _ = Context("RunCompletionEventToProto", func() {
 		})
 	})
 
+	When("event has the details of the run", func() {
+		It("converts the details", func() {
+			rce.PipelineVersion = "some-version"
+			rce.Parameters = map[string]string{"some-parameter": "some-value"}
+			rce.TriggerIndicator = &triggers.Indicator{
+				Type:            triggers.Schedule,
+				Source:          "name",
+				SourceNamespace: "namespace",
+			}
+			rce.RunUrl = "https://some/where"
+			rce.FailureReason = "some-reason"
+
+			protoRce, err := RunCompletionEventToProto(rce)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(protoRce.PipelineVersion).To(Equal("some-version"))
+			Expect(protoRce.Parameters).To(Equal(map[string]string{"some-parameter": "some-value"}))
+			Expect(protoRce.TriggerIndicator).To(Equal(&pb.TriggerIndicator{
+				Type:            triggers.Schedule,
+				Source:          "name",
+				SourceNamespace: "namespace",
+			}))
+			Expect(protoRce.RunUrl).To(Equal("https://some/where"))
+			Expect(protoRce.FailureReason).To(Equal("some-reason"))
+		})
+	})
+
 	When("There are no artifacts", func() {
 		It("returns empty slices", func() {
 			rce.Artifacts = []common.Artifact{}
@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"slices"
 
 	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/pkg/common"
@@ -28,11 +30,25 @@ type RunCompletionFeed struct {
 	client         client.Reader
 	eventProcessor EventProcessor
 	eventHandlers  []RunCompletionEventHandler
+	// statusUpdater records the completion on the resources and always runs
+	// after the other handlers. Events whose completion has been recorded are
+	// treated as duplicates, so recording it before another handler failed
+	// would prevent that handler from ever receiving the event.
+	statusUpdater RunCompletionEventHandler
+	// authenticator verifies the sender of events, which are accepted from
+	// anyone when it is not set.
+	authenticator Authenticator
+	// processed remembers events that have been handled so that duplicate
+	// deliveries are acknowledged without invoking the handlers again.
+	processed         *processedEvents
+	duplicatesCounter metric.Int64Counter
 }
 
 func NewRunCompletionFeed(
 	client client.Reader,
 	handlers []RunCompletionEventHandler,
+	statusUpdater RunCompletionEventHandler,
+	authenticator Authenticator,
 ) RunCompletionFeed {
 	eventProcessor := NewResourceArtifactsEventProcessor()
 
@@ -40,6 +56,9 @@ func NewRunCompletionFeed(
 		client:         client,
 		eventProcessor: eventProcessor,
 		eventHandlers:  handlers,
+		statusUpdater:  statusUpdater,
+		authenticator:  authenticator,
+		processed:      newProcessedEvents(ProcessedEventsCapacity),
 	}
 }
 
@@ -69,12 +88,48 @@ func getRequestBody(ctx context.Context, request *http.Request) ([]byte, error)
 	return body, nil
 }
 
+// mediaType returns the media type of the request without parameters such as
+// the charset, or nothing when the content type cannot be parsed.
+func mediaType(request *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get(HttpHeaderContentType))
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
 func (rcf RunCompletionFeed) extractRunCompletionEventData(ctx context.Context, request *http.Request) (*common.RunCompletionEventData, EventError) {
 	body, err := getRequestBody(ctx, request)
 	if err != nil {
 		return nil, &InvalidEvent{err.Error()}
 	}
 
+	if mediaType(request) == common.CloudEventsContentType {
+		cloudEvent := common.CloudEvent[common.RunCompletionEventData]{}
+		if err := json.Unmarshal(body, &cloudEvent); err != nil {
+			return nil, &FatalError{err.Error()}
+		}
+		if err := cloudEvent.Validate(common.RunCompletionEventDataType); err != nil {
+			return nil, &InvalidEvent{err.Error()}
+		}
+		return &cloudEvent.Data, nil
+	}
+
+	// binary CloudEvents carry their attributes in headers and the event data
+	// in the body, which is the legacy format
+	if specVersion := request.Header.Get(common.CloudEventsHeaderSpecVersion); specVersion != "" {
+		if err := common.ValidateCloudEventAttributes(
+			specVersion,
+			request.Header.Get(common.CloudEventsHeaderId),
+			request.Header.Get(common.CloudEventsHeaderSource),
+			request.Header.Get(common.CloudEventsHeaderType),
+			common.RunCompletionEventDataType,
+		); err != nil {
+			return nil, &InvalidEvent{err.Error()}
+		}
+	}
+
 	rced := &common.RunCompletionEventData{}
 	if err := json.Unmarshal(body, &rced); err != nil {
 		return nil, &FatalError{err.Error()}
@@ -130,9 +185,9 @@ func (rcf RunCompletionFeed) HandleEvent(ctx context.Context) func(responseWrite
 		switch request.Method {
 		case http.MethodPost:
 
-			if request.Header.Get(HttpHeaderContentType) != HttpContentTypeJSON {
-				logger.Error(errors.New("RunCompletionFeed call failed"), fmt.Sprintf("invalid %s [%s], want `%s`", HttpHeaderContentType, request.Header.Get(HttpHeaderContentType), HttpContentTypeJSON))
-				http.Error(responseWriter, fmt.Sprintf("invalid %s, want `%s`", HttpHeaderContentType, HttpContentTypeJSON), http.StatusUnsupportedMediaType)
+			if contentType := mediaType(request); contentType != HttpContentTypeJSON && contentType != common.CloudEventsContentType {
+				logger.Error(errors.New("RunCompletionFeed call failed"), fmt.Sprintf("invalid %s [%s], want `%s` or `%s`", HttpHeaderContentType, request.Header.Get(HttpHeaderContentType), HttpContentTypeJSON, common.CloudEventsContentType))
+				http.Error(responseWriter, fmt.Sprintf("invalid %s, want `%s` or `%s`", HttpHeaderContentType, HttpContentTypeJSON, common.CloudEventsContentType), http.StatusUnsupportedMediaType)
 				return
 			}
 
@@ -142,6 +197,14 @@ func (rcf RunCompletionFeed) HandleEvent(ctx context.Context) func(responseWrite
 				return
 			}
 
+			if rcf.authenticator != nil {
+				if err := rcf.authenticator.Authenticate(ctx, request, eventData.Provider); err != nil {
+					logger.Error(err, "RunCompletionFeed call rejected")
+					err.SendHttpError(responseWriter)
+					return
+				}
+			}
+
 			var runConfiguration *pipelineshub.RunConfiguration
 			var runConfigurationErr EventError
 			if eventData.RunConfigurationName != nil && eventData.RunConfigurationName.Name != "" {
@@ -166,15 +229,27 @@ func (rcf RunCompletionFeed) HandleEvent(ctx context.Context) func(responseWrite
 				}
 			}
 
+			if alreadyRecorded(eventData, runConfiguration, run) || !rcf.processed.reserve(eventData) {
+				logger.Info("Ignoring duplicate run completion event", "RunId", eventData.RunId, "Status", eventData.Status)
+				if rcf.duplicatesCounter != nil {
+					rcf.duplicatesCounter.Add(ctx, 1, metric.WithAttributes(
+						attribute.String("status", string(eventData.Status)),
+					))
+				}
+				return
+			}
+
 			event, err := rcf.eventProcessor.ToRunCompletionEvent(eventData, runConfiguration, run)
 			if err != nil {
+				rcf.processed.release(eventData)
 				err.SendHttpError(responseWriter)
 				return
 			}
 
-			for _, handler := range rcf.eventHandlers {
+			for _, handler := range rcf.orderedHandlers() {
 				err := handler.Handle(ctx, *event)
 				if err != nil {
+					rcf.processed.release(eventData)
 					logger.Error(err, "Run completion event handler operation failed")
 					err.SendHttpError(responseWriter)
 					return
@@ -188,6 +263,15 @@ func (rcf RunCompletionFeed) HandleEvent(ctx context.Context) func(responseWrite
 	}
 }
 
+// orderedHandlers returns the event handlers followed by the status updater.
+func (rcf RunCompletionFeed) orderedHandlers() []RunCompletionEventHandler {
+	if rcf.statusUpdater == nil {
+		return rcf.eventHandlers
+	}
+
+	return append(slices.Clone(rcf.eventHandlers), rcf.statusUpdater)
+}
+
 type ObservedRunCompletionFeed struct {
 	delegate        RunCompletionFeed
 	requestsCounter metric.Int64Counter
@@ -196,6 +280,8 @@ type ObservedRunCompletionFeed struct {
 func NewObservedRunCompletionFeed(
 	client client.Reader,
 	handlers []RunCompletionEventHandler,
+	statusUpdater RunCompletionEventHandler,
+	authenticator Authenticator,
 ) (ObservedRunCompletionFeed, error) {
 	meter := otel.Meter("run_completion_feed")
 	requestsCounter, err := meter.Int64Counter(
@@ -207,8 +293,20 @@ func NewObservedRunCompletionFeed(
 		return ObservedRunCompletionFeed{}, fmt.Errorf("failed to create requests counter: %w", err)
 	}
 
+	duplicatesCounter, err := meter.Int64Counter(
+		"run_completion_feed_duplicates",
+		metric.WithDescription("Total number of duplicate run completion events acknowledged without being handled again"),
+	)
+
+	if err != nil {
+		return ObservedRunCompletionFeed{}, fmt.Errorf("failed to create duplicates counter: %w", err)
+	}
+
+	delegate := NewRunCompletionFeed(client, handlers, statusUpdater, authenticator)
+	delegate.duplicatesCounter = duplicatesCounter
+
 	return ObservedRunCompletionFeed{
-		delegate:        NewRunCompletionFeed(client, handlers),
+		delegate:        delegate,
 		requestsCounter: requestsCounter,
 	}, nil
 }
@@ -118,6 +118,43 @@ var _ = Describe("Run the run completion feed webhook", Serial, func() {
 		})
 	})
 
+	When("called with an event that has been handled before", func() {
+		It("acknowledges the event without calling the handlers again", func() {
+			deduplicating := withHandlers
+			deduplicating.processed = newProcessedEvents(ProcessedEventsCapacity)
+
+			req, resp := setupRequestResponse(ctx, http.MethodPost, bytes.NewReader(requestStr), HttpContentTypeJSON)
+			deduplicating.HandleEvent(ctx)(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+
+			req, resp = setupRequestResponse(ctx, http.MethodPost, bytes.NewReader(requestStr), HttpContentTypeJSON)
+			deduplicating.HandleEvent(ctx)(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusOK))
+
+			Expect(mockRCEHandlerHandleCounter).To(Equal(len(handlers)))
+		})
+	})
+
+	When("called with an event that has been recorded on the run configuration", func() {
+		It("acknowledges the event without calling the handlers", func() {
+			recordedRc := pipelineshub.RandomRunConfiguration(common.RandomNamespacedName())
+			recordedRc.Status.LatestRuns.Succeeded.ProviderId = rced.RunId
+			recorded := withHandlers
+			recorded.client = fake.NewClientBuilder().WithScheme(schemeWithCRDs()).WithObjects(recordedRc).Build()
+
+			recordedRced := rced
+			recordedRced.RunConfigurationName = &common.NamespacedName{Name: recordedRc.Name, Namespace: recordedRc.Namespace}
+			recordedRequestStr, err := json.Marshal(recordedRced)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, resp := setupRequestResponse(ctx, http.MethodPost, bytes.NewReader(recordedRequestStr), HttpContentTypeJSON)
+			recorded.HandleEvent(ctx)(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(mockRCEHandlerHandleCounter).To(Equal(0))
+		})
+	})
+
 	When("called with empty body", func() {
 		It("returns bad request", func() {
 			req, resp := setupRequestResponse(ctx, http.MethodPost, nil, HttpContentTypeJSON)
@@ -5,14 +5,19 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	"github.com/sky-uk/kfp-operator/internal/log"
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = Context("getRequestBody", func() {
@@ -61,6 +66,71 @@ var _ = Context("extractRunCompletionEventData", func() {
 		})
 	})
 
+	When("passed a structured CloudEvent", func() {
+		It("returns the RunCompletionEventData of the CloudEvent", func() {
+			eventData := RandomRunCompletionEventData()
+			cloudEvent, err := json.Marshal(common.CloudEvent[common.RunCompletionEventData]{
+				SpecVersion: common.CloudEventsSpecVersion,
+				Id:          eventData.RunId,
+				Source:      common.RunCompletionEventSource(eventData.Provider),
+				Type:        common.RunCompletionEventDataType,
+				Data:        eventData,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader(cloudEvent))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(HttpHeaderContentType, common.CloudEventsContentType+"; charset=utf-8")
+
+			extracted, err := rcf.extractRunCompletionEventData(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(extracted).To(Equal(&eventData))
+		})
+
+		It("rejects published run completion events", func() {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte(`{"specversion":"1.0","id":"id","source":"source","type":"`+common.RunCompletionEventType+`","data":{}}`)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(HttpHeaderContentType, common.CloudEventsContentType)
+
+			_, err = rcf.extractRunCompletionEventData(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("unsupported CloudEvent type")))
+		})
+
+		It("rejects CloudEvents of other types", func() {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte(`{"specversion":"1.0","id":"id","source":"source","type":"other","data":{}}`)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(HttpHeaderContentType, common.CloudEventsContentType)
+
+			_, err = rcf.extractRunCompletionEventData(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("unsupported CloudEvent type [other]")))
+		})
+	})
+
+	When("passed a binary CloudEvent", func() {
+		binaryRequest := func(eventType string) *http.Request {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte("{\"runId\":\"run-id\"}")))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(HttpHeaderContentType, HttpContentTypeJSON)
+			req.Header.Set(common.CloudEventsHeaderSpecVersion, common.CloudEventsSpecVersion)
+			req.Header.Set(common.CloudEventsHeaderId, "run-id")
+			req.Header.Set(common.CloudEventsHeaderSource, "source")
+			req.Header.Set(common.CloudEventsHeaderType, eventType)
+			return req
+		}
+
+		It("returns the RunCompletionEventData of the body", func() {
+			extracted, err := rcf.extractRunCompletionEventData(ctx, binaryRequest(common.RunCompletionEventDataType))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(extracted).To(Equal(&common.RunCompletionEventData{RunId: "run-id"}))
+		})
+
+		It("rejects CloudEvents of other types", func() {
+			_, err := rcf.extractRunCompletionEventData(ctx, binaryRequest("other"))
+			Expect(err).To(MatchError(ContainSubstring("unsupported CloudEvent type [other]")))
+		})
+	})
+
 	When("passed a request with empty body", func() {
 		It("returns an error", func() {
 			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte("")))
@@ -79,3 +149,148 @@ var _ = Context("extractRunCompletionEventData", func() {
 		})
 	})
 })
+
+type StubbedAuthenticator struct {
+	err EventError
+}
+
+func (sa StubbedAuthenticator) Authenticate(_ context.Context, _ *http.Request, _ common.NamespacedName) EventError {
+	return sa.err
+}
+
+type recordingHandler struct {
+	name    string
+	err     EventError
+	handled *[]string
+}
+
+func (rh recordingHandler) Handle(_ context.Context, _ common.RunCompletionEvent) EventError {
+	*rh.handled = append(*rh.handled, rh.name)
+	return rh.err
+}
+
+var _ = Context("HandleEvent", func() {
+	ctx := context.Background()
+
+	Describe("the status updater", func() {
+		var (
+			handled []string
+			request func() (*http.Request, *httptest.ResponseRecorder)
+			feed    func(handlers ...RunCompletionEventHandler) RunCompletionFeed
+		)
+
+		BeforeEach(func() {
+			handled = nil
+
+			run := pipelineshub.RandomRun(common.RandomNamespacedName())
+			scheme := runtime.NewScheme()
+			Expect(pipelineshub.AddToScheme(scheme)).To(Succeed())
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(run).Build()
+
+			eventData := RandomRunCompletionEventData()
+			eventData.RunConfigurationName = nil
+			eventData.RunName = &common.NamespacedName{Namespace: run.Namespace, Name: run.Name}
+			body, err := json.Marshal(eventData)
+			Expect(err).NotTo(HaveOccurred())
+
+			request = func() (*http.Request, *httptest.ResponseRecorder) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set(HttpHeaderContentType, HttpContentTypeJSON)
+				return req, httptest.NewRecorder()
+			}
+
+			feed = func(handlers ...RunCompletionEventHandler) RunCompletionFeed {
+				return NewRunCompletionFeed(k8sClient, handlers, recordingHandler{name: "status updater", handled: &handled}, nil)
+			}
+		})
+
+		It("runs after the other handlers", func() {
+			rcf := feed(
+				recordingHandler{name: "first", handled: &handled},
+				recordingHandler{name: "second", handled: &handled},
+			)
+			req, recorder := request()
+
+			rcf.HandleEvent(ctx)(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(handled).To(Equal([]string{"first", "second", "status updater"}))
+		})
+
+		It("does not run when another handler fails", func() {
+			rcf := feed(recordingHandler{name: "failing", err: &FatalError{"failed"}, handled: &handled})
+			req, recorder := request()
+
+			rcf.HandleEvent(ctx)(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			Expect(handled).To(Equal([]string{"failing"}))
+		})
+
+		It("handles repeated events once", func() {
+			rcf := feed()
+
+			for range 2 {
+				req, recorder := request()
+				rcf.HandleEvent(ctx)(recorder, req)
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			}
+
+			Expect(handled).To(Equal([]string{"status updater"}))
+		})
+
+		It("handles an event again once a handler has failed", func() {
+			failing := &recordingHandler{name: "failing", err: &FatalError{"failed"}, handled: &handled}
+			rcf := feed(failing)
+
+			req, recorder := request()
+			rcf.HandleEvent(ctx)(recorder, req)
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+
+			failing.err = nil
+			req, recorder = request()
+			rcf.HandleEvent(ctx)(recorder, req)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			Expect(handled).To(Equal([]string{"failing", "failing", "status updater"}))
+		})
+	})
+
+	When("the sender is not authenticated", func() {
+		It("rejects the event", func() {
+			rcf := RunCompletionFeed{
+				authenticator: StubbedAuthenticator{err: &UnauthenticatedError{"bearer token missing"}},
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte("{}")))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(HttpHeaderContentType, HttpContentTypeJSON)
+			recorder := httptest.NewRecorder()
+
+			rcf.HandleEvent(ctx)(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	DescribeTable("the content type", func(contentType string, expectedCode int) {
+		// the authenticator rejects every event that passes the content type
+		rcf := RunCompletionFeed{
+			authenticator: StubbedAuthenticator{err: &UnauthenticatedError{"bearer token missing"}},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/events", bytes.NewReader([]byte("{}")))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set(HttpHeaderContentType, contentType)
+		recorder := httptest.NewRecorder()
+
+		rcf.HandleEvent(ctx)(recorder, req)
+
+		Expect(recorder.Code).To(Equal(expectedCode))
+	},
+		Entry("accepts JSON", HttpContentTypeJSON, http.StatusUnauthorized),
+		Entry("accepts JSON with a charset", "application/json; charset=utf-8", http.StatusUnauthorized),
+		Entry("accepts JSON in another case", "Application/JSON", http.StatusUnauthorized),
+		Entry("rejects other media types", "application/json-patch+json", http.StatusUnsupportedMediaType),
+		Entry("rejects malformed content types", "application/json; charset", http.StatusUnsupportedMediaType),
+	)
+})
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
@@ -43,12 +44,113 @@ type ConfigSpec struct {
 	DefaultTfxImage       string                `yaml:"defaultTfxImage,omitempty"`
 	// Deprecated: WorkflowNamespace no longer controls where workflows run;
 	// workflows always execute in their Provider's namespace. It is retained only
-	// to seed the legacy v1alpha6 DefaultProviderNamespace conversion default and
-	// will be removed together with v1alpha6.
+	// to seed the legacy v1alpha6 DefaultProviderNamespace conversion default,
+	// which is why it is cached regardless of the scope, and will be removed
+	// together with v1alpha6.
 	WorkflowNamespace string           `yaml:"workflowNamespace,omitempty"`
 	Multiversion      bool             `yaml:"multiversion,omitempty"`
 	RunCompletionTTL  *metav1.Duration `yaml:"runCompletionTTL,omitempty"`
-	RunCompletionFeed ServiceConfig    `yaml:"runCompletionFeed,omitempty"`
+	// SucceededRunCompletionTTL and FailedRunCompletionTTL override
+	// RunCompletionTTL for runs that completed in the respective state.
+	SucceededRunCompletionTTL *metav1.Duration `yaml:"succeededRunCompletionTTL,omitempty"`
+	FailedRunCompletionTTL    *metav1.Duration `yaml:"failedRunCompletionTTL,omitempty"`
+	RunCompletionFeed         ServiceConfig    `yaml:"runCompletionFeed,omitempty"`
+	// WorkflowRetention limits how many processed Argo workflows are kept.
+	// Processed workflows are never deleted when no limits are configured.
+	WorkflowRetention WorkflowRetention `yaml:"workflowRetention,omitempty"`
+	// DriftDetection periodically compares synchronised resources with their
+	// counterparts on the provider.
+	DriftDetection DriftDetection `yaml:"driftDetection,omitempty"`
+	// OrphanReaping periodically looks for provider resources that are not
+	// owned by any resource of the operator.
+	OrphanReaping OrphanReaping `yaml:"orphanReaping,omitempty"`
+	// Scope restricts the objects the manager watches so that several
+	// instances of the operator can share a cluster.
+	Scope Scope `yaml:"scope,omitempty"`
+	// Retry resubmits the workflows of resources whose creation or update
+	// failed.
+	Retry RetryPolicy `yaml:"retry,omitempty"`
+}
+
+// Validate rejects configuration that cannot be used at runtime.
+func (cs ConfigSpec) Validate() error {
+	intervals := []struct {
+		name     string
+		interval *metav1.Duration
+	}{
+		{"workflowRetention.interval", cs.WorkflowRetention.Interval},
+		{"driftDetection.interval", cs.DriftDetection.Interval},
+		{"orphanReaping.interval", cs.OrphanReaping.Interval},
+	}
+
+	for _, i := range intervals {
+		if i.interval != nil && i.interval.Duration <= 0 {
+			return fmt.Errorf("%s must be greater than zero, got %s", i.name, i.interval.Duration)
+		}
+	}
+
+	return nil
+}
+
+type RetryPolicy struct {
+	// MaxAttempts is the number of retries after a failure. Failures are not
+	// retried when it is zero.
+	MaxAttempts int32 `yaml:"maxAttempts,omitempty"`
+	// InitialBackoff before the first retry, defaults to 30 seconds. The
+	// backoff doubles with every attempt.
+	InitialBackoff *metav1.Duration `yaml:"initialBackoff,omitempty"`
+	// MaxBackoff between retries, defaults to 1 hour.
+	MaxBackoff *metav1.Duration `yaml:"maxBackoff,omitempty"`
+}
+
+type Scope struct {
+	// Namespaces to watch. All namespaces are watched when neither
+	// Namespaces nor NamespaceSelector are set.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	// NamespaceSelector watches namespaces with matching labels in addition
+	// to those listed in Namespaces.
+	NamespaceSelector *metav1.LabelSelector `yaml:"namespaceSelector,omitempty"`
+	// LabelSelector restricts the resources of the operator to those with
+	// matching labels.
+	LabelSelector *metav1.LabelSelector `yaml:"labelSelector,omitempty"`
+}
+
+type DriftDetection struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Interval between drift detections, defaults to 10 minutes.
+	Interval *metav1.Duration `yaml:"interval,omitempty"`
+	// Recreate resynchronises drifted resources instead of only reporting them.
+	Recreate bool `yaml:"recreate,omitempty"`
+}
+
+type OrphanReaping struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Interval between reapings, defaults to 10 minutes.
+	Interval *metav1.Duration `yaml:"interval,omitempty"`
+	// Delete deletes orphaned provider resources instead of only reporting them.
+	Delete bool `yaml:"delete,omitempty"`
+}
+
+type WorkflowRetention struct {
+	// Interval between garbage collections, defaults to 10 minutes.
+	Interval  *metav1.Duration `yaml:"interval,omitempty"`
+	Succeeded RetentionLimits  `yaml:"succeeded,omitempty"`
+	Failed    RetentionLimits  `yaml:"failed,omitempty"`
+}
+
+func (wr WorkflowRetention) Enabled() bool {
+	return wr.Succeeded.Enabled() || wr.Failed.Enabled()
+}
+
+// RetentionLimits apply per resource that owns the workflows. Workflows are
+// deleted once either limit is exceeded.
+type RetentionLimits struct {
+	MaxAge   *metav1.Duration `yaml:"maxAge,omitempty"`
+	MaxCount *int             `yaml:"maxCount,omitempty"`
+}
+
+func (rl RetentionLimits) Enabled() bool {
+	return rl.MaxAge != nil || rl.MaxCount != nil
 }
 
 type DefaultProviderValues struct {
@@ -58,11 +160,45 @@ type DefaultProviderValues struct {
 	ServiceContainerName string             `yaml:"serviceContainerName,omitempty"`
 	ServicePort          int                `yaml:"servicePort,omitempty"`
 	MetricsPort          int                `yaml:"metricsPort,omitempty"`
+	// Autoscaling and DisruptionBudget apply to providers that do not declare
+	// their own.
+	Autoscaling      *pipelineshub.ProviderAutoscaling      `yaml:"autoscaling,omitempty"`
+	DisruptionBudget *pipelineshub.ProviderDisruptionBudget `yaml:"disruptionBudget,omitempty"`
 }
 
 type ServiceConfig struct {
 	Port      int        `yaml:"port,omitempty"`
 	Endpoints []Endpoint `yaml:"endpoints,omitempty"`
+	// Authentication requires provider services to authenticate with the
+	// token of their ServiceAccount. Events are accepted from anyone when it
+	// is not set.
+	Authentication *FeedAuthentication `yaml:"authentication,omitempty"`
+	// DependencyGraph serves the dependency graph of resources on the port
+	// of the run completion feed. The graph is served without
+	// authentication, so it is only served when enabled.
+	DependencyGraph DependencyGraph `yaml:"dependencyGraph,omitempty"`
+}
+
+type DependencyGraph struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// DefaultFeedAudience is the audience of the tokens that provider services
+// authenticate run completion events with.
+const DefaultFeedAudience = "kfp-operator"
+
+type FeedAuthentication struct {
+	// Audience the tokens must be issued for, defaults to DefaultFeedAudience.
+	// It must not be an audience of the API server.
+	Audience string `yaml:"audience,omitempty"`
+}
+
+func (fa FeedAuthentication) TokenAudience() string {
+	if fa.Audience == "" {
+		return DefaultFeedAudience
+	}
+
+	return fa.Audience
 }
 
 type Endpoint struct {
@@ -0,0 +1,37 @@
+//go:build unit
+
+package config
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ConfigSpec", func() {
+	Describe("Validate", func() {
+		It("accepts unset intervals", func() {
+			Expect(ConfigSpec{}.Validate()).To(Succeed())
+		})
+
+		It("accepts positive intervals", func() {
+			interval := &metav1.Duration{Duration: time.Minute}
+
+			Expect(ConfigSpec{
+				WorkflowRetention: WorkflowRetention{Interval: interval},
+				DriftDetection:    DriftDetection{Interval: interval},
+				OrphanReaping:     OrphanReaping{Interval: interval},
+			}.Validate()).To(Succeed())
+		})
+
+		DescribeTable("rejects intervals that are not positive", func(spec ConfigSpec) {
+			Expect(spec.Validate()).NotTo(Succeed())
+		},
+			Entry("workflow retention", ConfigSpec{WorkflowRetention: WorkflowRetention{Interval: &metav1.Duration{}}}),
+			Entry("drift detection", ConfigSpec{DriftDetection: DriftDetection{Interval: &metav1.Duration{Duration: -time.Minute}}}),
+			Entry("orphan reaping", ConfigSpec{OrphanReaping: OrphanReaping{Interval: &metav1.Duration{}}}),
+		)
+	})
+})
@@ -71,6 +71,14 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "plan" {
+		if err := plan(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	opts := zap.Options{
 		Development: false,
 	}
@@ -103,10 +111,22 @@ func main() {
 		options.LeaderElectionID = ctrlConfig.System.LeaderElection.Id
 	}
 
+	if err = ctrlConfig.Spec.Validate(); err != nil {
+		setupLog.Error(err, "invalid configuration")
+		os.Exit(1)
+	}
+
 	// TODO: This is temporary whilst have conversion from v1alpha6 to v1beta1, this is to be removed once v1alpha6 is removed.
 	pipelineshubalpha6.DefaultProviderNamespace = ctrlConfig.Spec.WorkflowNamespace
 	pipelineshubalpha6.DefaultTfxImage = ctrlConfig.Spec.DefaultTfxImage
 
+	scope, err := pipelinescontrollers.NewScope(ctrlConfig.Spec.Scope, nil)
+	if err != nil {
+		setupLog.Error(err, "invalid scope configuration")
+		os.Exit(1)
+	}
+	options.Cache = scope.CacheOptions(ctrlConfig.Spec.WorkflowNamespace)
+
 	var mgr ctrl.Manager
 
 	mgr, err = ctrl.NewManager(ctrl.GetConfigOrDie(), options)
@@ -116,6 +136,7 @@ func main() {
 	}
 
 	client := controllers.NewOptInClient(mgr)
+	scope.Namespaces = client.Cached
 
 	workflowRepository := pipelinescontrollers.WorkflowRepositoryImpl{
 		Client: client,
@@ -123,11 +144,19 @@ func main() {
 		Scheme: mgr.GetScheme(),
 	}
 
+	resourceMetrics, err := pipelinescontrollers.NewResourceMetrics(client.Cached)
+	if err != nil {
+		setupLog.Error(err, "unable to create resource metrics")
+		os.Exit(1)
+	}
+
 	ec := pipelinescontrollers.K8sExecutionContext{
 		Client:             client,
 		Recorder:           mgr.GetEventRecorderFor("kfp-operator"),
 		Scheme:             mgr.GetScheme(),
 		WorkflowRepository: workflowRepository,
+		Metrics:            resourceMetrics,
+		Scope:              scope,
 	}
 
 	if err := pipelinescontrollers.NewPipelineReconciler(
@@ -179,6 +208,42 @@ func main() {
 		os.Exit(1)
 	}
 
+	if ctrlConfig.Spec.WorkflowRetention.Enabled() {
+		workflowGarbageCollector, err := pipelinescontrollers.NewWorkflowGarbageCollector(client, ctrlConfig.Spec.WorkflowRetention, scope)
+		if err != nil {
+			setupLog.Error(err, "unable to create workflow garbage collector")
+			os.Exit(1)
+		}
+		if err = mgr.Add(workflowGarbageCollector); err != nil {
+			setupLog.Error(err, "unable to add workflow garbage collector")
+			os.Exit(1)
+		}
+	}
+
+	if ctrlConfig.Spec.DriftDetection.Enabled {
+		driftDetector, err := pipelinescontrollers.NewDriftDetector(ec, ctrlConfig.Spec)
+		if err != nil {
+			setupLog.Error(err, "unable to create drift detector")
+			os.Exit(1)
+		}
+		if err = mgr.Add(driftDetector); err != nil {
+			setupLog.Error(err, "unable to add drift detector")
+			os.Exit(1)
+		}
+	}
+
+	if ctrlConfig.Spec.OrphanReaping.Enabled {
+		orphanReaper, err := pipelinescontrollers.NewOrphanReaper(ec, ctrlConfig.Spec)
+		if err != nil {
+			setupLog.Error(err, "unable to create orphan reaper")
+			os.Exit(1)
+		}
+		if err = mgr.Add(orphanReaper); err != nil {
+			setupLog.Error(err, "unable to add orphan reaper")
+			os.Exit(1)
+		}
+	}
+
 	if ctrlConfig.Spec.Multiversion {
 		if err = pipelineshub.NewPipelineValidatorWebhook(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Pipeline")
@@ -188,6 +253,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Experiment")
 			os.Exit(1)
 		}
+		if err = pipelineshub.NewExperimentValidatorWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Experiment")
+			os.Exit(1)
+		}
 		if err = pipelineshub.NewRunConfigurationValidatorWebhook(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "RunConfiguration")
 			os.Exit(1)
@@ -196,6 +265,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "RunSchedule")
 			os.Exit(1)
 		}
+		if err = pipelineshub.NewRunScheduleValidatorWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RunSchedule")
+			os.Exit(1)
+		}
 		if err = (&pipelineshub.Provider{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Provider")
 			os.Exit(1)
@@ -238,10 +311,17 @@ func main() {
 		setupLog.Error(err, "unable to create status updater")
 		os.Exit(1)
 	}
-	handlers = append(handlers, statusUpdater)
+	var authenticator webhook.Authenticator
+	if feedAuthentication := ctrlConfig.Spec.RunCompletionFeed.Authentication; feedAuthentication != nil {
+		authenticator = webhook.NewServiceAccountAuthenticator(mgr.GetClient(), feedAuthentication.TokenAudience())
+	} else {
+		setupLog.Info("WARNING: run completion feed authentication is not configured, run completion events are accepted from anyone who can reach the feed")
+	}
 	rcf, err := webhook.NewObservedRunCompletionFeed(
 		client.NonCached,
 		handlers,
+		statusUpdater,
+		authenticator,
 	)
 	if err != nil {
 		setupLog.Error(err, "unable to create run completion feed")
@@ -249,6 +329,9 @@ func main() {
 	}
 	go func() {
 		http.HandleFunc("/events", rcf.HandleEvent(ctx))
+		if ctrlConfig.Spec.RunCompletionFeed.DependencyGraph.Enabled {
+			http.HandleFunc("/graph", webhook.NewDependencyGraphHandler(client.Cached).HandleGraph(ctx))
+		}
 
 		http.ListenAndServe(fmt.Sprintf(":%d", ctrlConfig.Spec.RunCompletionFeed.Port), nil)
 		if err != nil {
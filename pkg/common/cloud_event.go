@@ -0,0 +1,107 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsContentType is the content type of CloudEvents in the
+	// structured JSON format.
+	CloudEventsContentType = "application/cloudevents+json"
+	// RunCompletionEventDataType is the type of the run completion event data
+	// that providers send to the operator.
+	RunCompletionEventDataType = "org.kubeflow.pipelines.run-completion-event-data.v1"
+	// RunCompletionEventType is the type of the run completion events that the
+	// operator publishes.
+	RunCompletionEventType = "org.kubeflow.pipelines.run-completion-event.v1"
+)
+
+// Headers of CloudEvents in the binary HTTP format.
+const (
+	CloudEventsHeaderSpecVersion = "ce-specversion"
+	CloudEventsHeaderId          = "ce-id"
+	CloudEventsHeaderSource      = "ce-source"
+	CloudEventsHeaderType        = "ce-type"
+	CloudEventsHeaderSubject     = "ce-subject"
+)
+
+// CloudEvent is a CloudEvent in the structured JSON format.
+type CloudEvent[T any] struct {
+	SpecVersion     string     `json:"specversion"`
+	Id              string     `json:"id"`
+	Source          string     `json:"source"`
+	Type            string     `json:"type"`
+	Subject         string     `json:"subject,omitempty"`
+	Time            *time.Time `json:"time,omitempty"`
+	DataContentType string     `json:"datacontenttype,omitempty"`
+	Data            T          `json:"data"`
+}
+
+// Validate checks the attributes the operator relies on and that the event is
+// of the expected type.
+func (ce CloudEvent[T]) Validate(expectedType string) error {
+	return ValidateCloudEventAttributes(ce.SpecVersion, ce.Id, ce.Source, ce.Type, expectedType)
+}
+
+func ValidateCloudEventAttributes(specVersion, id, source, eventType, expectedType string) error {
+	if specVersion != CloudEventsSpecVersion {
+		return fmt.Errorf("unsupported CloudEvents specversion [%s], want `%s`", specVersion, CloudEventsSpecVersion)
+	}
+
+	if id == "" || source == "" {
+		return fmt.Errorf("CloudEvent is missing its id or source")
+	}
+
+	if eventType != expectedType {
+		return fmt.Errorf("unsupported CloudEvent type [%s], want `%s`", eventType, expectedType)
+	}
+
+	return nil
+}
+
+// RunCompletionEventSource is the source of the run completion events of a
+// provider.
+func RunCompletionEventSource(provider NamespacedName) string {
+	return fmt.Sprintf("kfp-operator/providers/%s/%s", provider.Namespace, provider.Name)
+}
+
+// ToCloudEvent wraps the event in a CloudEvent that is identified by the run
+// and whose subject is the RunConfiguration of the run, if any. Events without
+// a run id are identified by their content so that the same event always has
+// the same id.
+func (sre RunCompletionEvent) ToCloudEvent() (CloudEvent[RunCompletionEvent], error) {
+	id := sre.RunId
+	if id == "" {
+		eventBytes, err := json.Marshal(sre)
+		if err != nil {
+			return CloudEvent[RunCompletionEvent]{}, err
+		}
+		hash := sha256.Sum256(eventBytes)
+		id = hex.EncodeToString(hash[:])
+	}
+
+	var subject string
+	if sre.RunConfigurationName != nil {
+		var err error
+		subject, err = sre.RunConfigurationName.String()
+		if err != nil {
+			return CloudEvent[RunCompletionEvent]{}, err
+		}
+	}
+
+	return CloudEvent[RunCompletionEvent]{
+		SpecVersion:     CloudEventsSpecVersion,
+		Id:              id,
+		Source:          RunCompletionEventSource(sre.Provider),
+		Type:            RunCompletionEventType,
+		Subject:         subject,
+		Time:            sre.RunEndTime,
+		DataContentType: "application/json",
+		Data:            sre,
+	}, nil
+}
@@ -0,0 +1,86 @@
+//go:build unit
+
+package common
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Context("RunCompletionEvent.ToCloudEvent", func() {
+	endTime := time.Now()
+	runConfigurationName := NamespacedName{Namespace: "namespace", Name: "rc"}
+	event := RunCompletionEvent{
+		Status:               RunCompletionStatuses.Succeeded,
+		RunConfigurationName: &runConfigurationName,
+		RunId:                "run-id",
+		RunEndTime:           &endTime,
+		Provider:             NamespacedName{Namespace: "namespace", Name: "provider"},
+	}
+
+	It("identifies the event by its run and provider", func() {
+		cloudEvent, err := event.ToCloudEvent()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloudEvent).To(Equal(CloudEvent[RunCompletionEvent]{
+			SpecVersion:     CloudEventsSpecVersion,
+			Id:              "run-id",
+			Source:          "kfp-operator/providers/namespace/provider",
+			Type:            RunCompletionEventType,
+			Subject:         "namespace/rc",
+			Time:            &endTime,
+			DataContentType: "application/json",
+			Data:            event,
+		}))
+		Expect(cloudEvent.Validate(RunCompletionEventType)).To(Succeed())
+	})
+
+	It("is identified by its content without a run id", func() {
+		withoutRunId := event
+		withoutRunId.RunId = ""
+
+		cloudEvent, err := withoutRunId.ToCloudEvent()
+		Expect(err).NotTo(HaveOccurred())
+		again, err := withoutRunId.ToCloudEvent()
+		Expect(err).NotTo(HaveOccurred())
+		other, err := RunCompletionEvent{Status: RunCompletionStatuses.Failed, Provider: withoutRunId.Provider}.ToCloudEvent()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cloudEvent.Id).NotTo(BeEmpty())
+		Expect(cloudEvent.Id).To(Equal(again.Id))
+		Expect(cloudEvent.Id).NotTo(Equal(other.Id))
+		Expect(cloudEvent.Validate(RunCompletionEventType)).To(Succeed())
+	})
+
+	It("has no subject without a RunConfiguration", func() {
+		withoutRunConfiguration := event
+		withoutRunConfiguration.RunConfigurationName = nil
+
+		cloudEvent, err := withoutRunConfiguration.ToCloudEvent()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloudEvent.Subject).To(BeEmpty())
+	})
+})
+
+var _ = Context("ValidateCloudEventAttributes", func() {
+	It("accepts events of the expected type", func() {
+		Expect(ValidateCloudEventAttributes(CloudEventsSpecVersion, "id", "source", RunCompletionEventDataType, RunCompletionEventDataType)).To(Succeed())
+	})
+
+	It("rejects other spec versions", func() {
+		Expect(ValidateCloudEventAttributes("0.3", "id", "source", RunCompletionEventDataType, RunCompletionEventDataType)).NotTo(Succeed())
+	})
+
+	It("rejects events without id or source", func() {
+		Expect(ValidateCloudEventAttributes(CloudEventsSpecVersion, "", "source", RunCompletionEventDataType, RunCompletionEventDataType)).NotTo(Succeed())
+		Expect(ValidateCloudEventAttributes(CloudEventsSpecVersion, "id", "", RunCompletionEventDataType, RunCompletionEventDataType)).NotTo(Succeed())
+	})
+
+	It("rejects other types", func() {
+		Expect(ValidateCloudEventAttributes(CloudEventsSpecVersion, "id", "source", "other", RunCompletionEventDataType)).NotTo(Succeed())
+		Expect(ValidateCloudEventAttributes(CloudEventsSpecVersion, "id", "source", RunCompletionEventType, RunCompletionEventDataType)).NotTo(Succeed())
+	})
+})
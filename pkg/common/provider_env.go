@@ -7,3 +7,8 @@ const (
 	PipelineRootStorageEnvVar = "PIPELINEROOTSTORAGE"
 	ParametersEnvVarPrefix    = "PARAMETERS_"
 )
+
+// OperatorWebhookTokenPath is where the operator projects the token that
+// provider services authenticate run completion events with, and where they
+// read it from by default.
+const OperatorWebhookTokenPath = "/var/run/secrets/kfp-operator/token"
@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"time"
+
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 )
 
 type Artifact struct {
@@ -31,6 +33,17 @@ type RunCompletionEvent struct {
 	RunEndTime           *time.Time      `json:"runEndTime,omitempty"`
 	Artifacts            []Artifact      `json:"artifacts"`
 	Provider             NamespacedName  `json:"provider"`
+	// PipelineVersion is the version of the pipeline the run was created with.
+	PipelineVersion string `json:"pipelineVersion,omitempty"`
+	// Parameters are the runtime parameters of the run with their references
+	// to other RunConfigurations resolved.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// TriggerIndicator identifies what caused the run.
+	TriggerIndicator *triggers.Indicator `json:"triggerIndicator,omitempty"`
+	// RunUrl links to the run on the provider.
+	RunUrl string `json:"runUrl,omitempty"`
+	// FailureReason explains why a failed run failed.
+	FailureReason string `json:"failureReason,omitempty"`
 }
 
 func (sre RunCompletionEvent) String() string {
@@ -66,6 +79,10 @@ type RunCompletionEventData struct {
 	RunEndTime           *time.Time          `json:"runEndTime,omitempty"`
 	PipelineComponents   []PipelineComponent `json:"pipelineComponents"`
 	Provider             NamespacedName      `json:"provider"`
+	// RunUrl links to the run on the provider, if the provider can tell.
+	RunUrl string `json:"runUrl,omitempty"`
+	// FailureReason explains why a failed run failed, if the provider can tell.
+	FailureReason string `json:"failureReason,omitempty"`
 }
 
 func (rced RunCompletionEventData) ToRunCompletionEvent() RunCompletionEvent {
@@ -79,5 +96,7 @@ func (rced RunCompletionEventData) ToRunCompletionEvent() RunCompletionEvent {
 		RunEndTime:           rced.RunEndTime,
 		Artifacts:            nil, // to be populated later
 		Provider:             rced.Provider,
+		RunUrl:               rced.RunUrl,
+		FailureReason:        rced.FailureReason,
 	}
 }
@@ -46,7 +46,16 @@ type RunDefinition struct {
 	TriggerIndicator     *triggers.Indicator           `json:"triggerIndicator,omitempty" yaml:"labels,omitempty"`
 }
 
+// IdempotencyKeyHeader identifies an operation across repeated requests to
+// the provider service, which responds to a repeated request with the
+// response of the first instead of performing the operation again.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type Output struct {
 	Id            string `json:"id,omitempty" yaml:"id"`
 	ProviderError string `json:"providerError,omitempty" yaml:"providerError"`
+	// Version is the version of the resource as known by the provider. It is
+	// only returned by Get operations and left empty when the provider
+	// does not track versions of the resource.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 }
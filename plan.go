@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	pipelineshub "github.com/sky-uk/kfp-operator/apis/pipelines/hub"
+	pipelineshubalpha6 "github.com/sky-uk/kfp-operator/apis/pipelines/v1alpha6"
+	pipelinescontrollers "github.com/sky-uk/kfp-operator/controllers/pipelines"
+	"github.com/sky-uk/kfp-operator/internal/config"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const planUsage = `Usage: manager plan [--config <file>] <manifest>...
+
+Prints what the operator would submit to the provider for the resources in
+the manifests. The providers the resources refer to must be part of the
+manifests. Runs and RunConfigurations must reference an explicit pipeline
+version.
+`
+
+// plan renders the plans of resources in manifests from disk.
+func plan(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("plan", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), planUsage)
+		flags.PrintDefaults()
+	}
+
+	var configFile string
+	flags.StringVar(&configFile, "config", "", "The configuration of the controller.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("no manifests given")
+	}
+
+	ctrlConfig := config.OperatorConfig{}
+	if configFile != "" {
+		bytes, err := os.ReadFile(configFile)
+		if err != nil {
+			return fmt.Errorf("unable to read the config file: %w", err)
+		}
+		if err = yaml.Unmarshal(bytes, &ctrlConfig); err != nil {
+			return fmt.Errorf("unable to parse the config file: %w", err)
+		}
+	}
+
+	pipelineshubalpha6.DefaultProviderNamespace = ctrlConfig.Spec.WorkflowNamespace
+	pipelineshubalpha6.DefaultTfxImage = ctrlConfig.Spec.DefaultTfxImage
+
+	var resources []client.Object
+	for _, manifest := range flags.Args() {
+		objects, err := readManifest(manifest)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", manifest, err)
+		}
+		resources = append(resources, objects...)
+	}
+
+	providers := map[common.NamespacedName]pipelineshub.Provider{}
+	for _, resource := range resources {
+		if provider, ok := resource.(*pipelineshub.Provider); ok {
+			providers[provider.GetCommonNamespacedName()] = *provider
+		}
+	}
+
+	planner := pipelinescontrollers.Planner{Config: ctrlConfig.Spec, Scheme: scheme}
+
+	for _, resource := range resources {
+		if _, ok := resource.(*pipelineshub.Provider); ok {
+			continue
+		}
+
+		providerName, err := pipelinescontrollers.ProviderOf(resource)
+		if err != nil {
+			return err
+		}
+
+		provider, found := providers[providerName]
+		if !found {
+			return fmt.Errorf("provider %s/%s of %s/%s not found in the manifests", providerName.Namespace, providerName.Name, resource.GetNamespace(), resource.GetName())
+		}
+
+		withExplicitPipelineVersion(resource)
+
+		resourcePlan, err := planner.Plan(provider, planner.ProviderService(provider), resource)
+		if err != nil {
+			return fmt.Errorf("unable to plan %s/%s: %w", resource.GetNamespace(), resource.GetName(), err)
+		}
+
+		marshalled, err := pipelinescontrollers.MarshalPlan(resourcePlan)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "# %s %s/%s\n%s\n", resource.GetObjectKind().GroupVersionKind().Kind, resource.GetNamespace(), resource.GetName(), marshalled)
+	}
+
+	return nil
+}
+
+// readManifest decodes all resources of the operator in a manifest and
+// converts them to the hub version.
+func readManifest(path string) ([]client.Object, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	decoder := yaml.NewYAMLOrJSONDecoder(file, 4096)
+
+	var objects []client.Object
+	for {
+		raw := runtime.RawExtension{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+
+		if len(raw.Raw) == 0 {
+			continue
+		}
+
+		object, gvk, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if gvk.Group != pipelineshub.GroupVersion.Group {
+			continue
+		}
+
+		if convertible, ok := object.(conversion.Convertible); ok {
+			hub, err := scheme.New(pipelineshub.GroupVersion.WithKind(gvk.Kind))
+			if err != nil {
+				return nil, err
+			}
+			if err := convertible.ConvertTo(hub.(conversion.Hub)); err != nil {
+				return nil, err
+			}
+			object = hub
+		}
+
+		resource, ok := object.(client.Object)
+		if !ok {
+			continue
+		}
+
+		resource.GetObjectKind().SetGroupVersionKind(pipelineshub.GroupVersion.WithKind(gvk.Kind))
+		if resource.GetNamespace() == "" {
+			resource.SetNamespace("default")
+		}
+
+		objects = append(objects, resource)
+	}
+}
+
+// withExplicitPipelineVersion resolves the pipeline version of Runs and
+// RunConfigurations from their spec, as the controllers would for pipelines
+// that are referenced with a version.
+func withExplicitPipelineVersion(resource client.Object) {
+	switch r := resource.(type) {
+	case *pipelineshub.Run:
+		r.Status.Dependencies.Pipeline.Version = r.Spec.Pipeline.Version
+	case *pipelineshub.RunConfiguration:
+		r.Status.Dependencies.Pipeline.Version = r.Spec.Run.Pipeline.Version
+	}
+}
@@ -5,18 +5,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	"github.com/spf13/viper"
 )
 
+// DefaultOperatorWebhookTokenPath is where the operator projects the token
+// for its audience. The token of the ServiceAccount that the kubelet mounts is
+// never sent as it grants access to the API server.
+const DefaultOperatorWebhookTokenPath = common.OperatorWebhookTokenPath
+
 type Config struct {
 	ProviderName        common.NamespacedName `mapstructure:"providerName"`
 	PipelineRootStorage string                `mapstructure:"pipelineRootStorage"`
 	OperatorWebhook     string                `mapstructure:"operatorWebhook"`
-	Server              Server                `mapstructure:"server"`
-	Metrics             MetricsConfig         `mapstructure:"metrics"`
+	// OperatorWebhookTokenPath is the file of the ServiceAccount token that
+	// authenticates run completion events with the operator. Events are sent
+	// without credentials when it does not exist.
+	OperatorWebhookTokenPath string        `mapstructure:"operatorWebhookTokenPath"`
+	Server                   Server        `mapstructure:"server"`
+	Metrics                  MetricsConfig `mapstructure:"metrics"`
+	Outbox                   OutboxConfig  `mapstructure:"outbox"`
 }
 
 type Server struct {
@@ -28,6 +39,15 @@ type MetricsConfig struct {
 	Port int `mapstructure:"port"`
 }
 
+type OutboxConfig struct {
+	// Directory keeps run completion events until the operator has accepted
+	// them. Events are kept in memory when it is not set.
+	Directory string `mapstructure:"directory"`
+	// MaxAge is how long run completion events are retried for before they
+	// are dropped. Events are never dropped when it is not positive.
+	MaxAge time.Duration `mapstructure:"maxAge"`
+}
+
 func LoadConfig[T any](initConfig T) (*T, error) {
 	viper.SetConfigType("json")
 	viper.AutomaticEnv()
@@ -44,7 +64,10 @@ func LoadConfig[T any](initConfig T) (*T, error) {
 	}
 
 	var config T
-	if err := viper.Unmarshal(&config, viper.DecodeHook(mapstructure.TextUnmarshallerHookFunc())); err != nil {
+	if err := viper.Unmarshal(&config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(),
+		mapstructure.StringToTimeDurationHookFunc(),
+	))); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config %w", err)
 	}
 
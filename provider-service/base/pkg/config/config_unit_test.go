@@ -4,6 +4,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -20,6 +21,9 @@ var _ = Context("load", func() {
 			Host: "0.0.0.0",
 			Port: 8080,
 		},
+		Outbox: OutboxConfig{
+			MaxAge: 24 * time.Hour,
+		},
 	}
 
 	When("given no environment variable overrides", func() {
@@ -29,4 +33,14 @@ var _ = Context("load", func() {
 			Expect(config).To(Equal(&defaultConfig))
 		})
 	})
+
+	When("given an outbox max age as an environment variable", func() {
+		It("parses the duration", func() {
+			GinkgoT().Setenv("OUTBOX_MAXAGE", "90m")
+
+			config, err := LoadConfig(defaultConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Outbox.MaxAge).To(Equal(90 * time.Minute))
+		})
+	})
 })
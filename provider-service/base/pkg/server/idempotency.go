@@ -0,0 +1,139 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sky-uk/kfp-operator/pkg/providers/base"
+)
+
+// IdempotencyKeyRetention is how long the responses of requests with an
+// idempotency key are kept for repeated requests.
+const IdempotencyKeyRetention = time.Hour
+
+type idempotentResponse struct {
+	done       chan struct{}
+	statusCode int
+	body       []byte
+	finishedAt time.Time
+}
+
+// idempotentResponses responds to repeated requests with the same
+// idempotency key with the response of the first, waiting for it if it is
+// still in progress. Server errors are not kept so that repeated requests
+// perform the operation again. Responses are kept in memory and are lost when
+// the provider service restarts.
+type idempotentResponses struct {
+	mutex     sync.Mutex
+	responses map[string]*idempotentResponse
+	now       func() time.Time
+}
+
+func newIdempotentResponses() *idempotentResponses {
+	return &idempotentResponses{
+		responses: map[string]*idempotentResponse{},
+		now:       time.Now,
+	}
+}
+
+// reserve returns the response for the key and whether the caller is the
+// first request, which has to perform the operation.
+func (ir *idempotentResponses) reserve(key string) (*idempotentResponse, bool) {
+	ir.mutex.Lock()
+	defer ir.mutex.Unlock()
+
+	now := ir.now()
+	for k, response := range ir.responses {
+		if !response.finishedAt.IsZero() && now.Sub(response.finishedAt) > IdempotencyKeyRetention {
+			delete(ir.responses, k)
+		}
+	}
+
+	if response, ok := ir.responses[key]; ok {
+		return response, false
+	}
+
+	response := &idempotentResponse{done: make(chan struct{})}
+	ir.responses[key] = response
+
+	return response, true
+}
+
+func (ir *idempotentResponses) finish(key string, response *idempotentResponse, recorder *responseRecorder) {
+	ir.mutex.Lock()
+	defer ir.mutex.Unlock()
+
+	// nothing is written when the handler panics
+	response.statusCode = recorder.statusCode
+	if response.statusCode == 0 {
+		response.statusCode = http.StatusInternalServerError
+	}
+	response.body = recorder.body.Bytes()
+	response.finishedAt = ir.now()
+	if response.statusCode >= http.StatusInternalServerError {
+		delete(ir.responses, key)
+	}
+
+	close(response.done)
+}
+
+func (ir *idempotentResponses) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey := r.Header.Get(base.IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := r.Method + " " + r.URL.Path + " " + idempotencyKey
+		response, first := ir.reserve(key)
+		if !first {
+			select {
+			case <-response.done:
+			case <-r.Context().Done():
+				return
+			}
+
+			// the first request failed with a server error, which is not
+			// kept, so the operation is performed again
+			if response.statusCode >= http.StatusInternalServerError {
+				ir.middleware(next).ServeHTTP(w, r)
+				return
+			}
+
+			w.WriteHeader(response.statusCode)
+			w.Write(response.body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		defer ir.finish(key, response, recorder)
+
+		// the operation is completed when the caller goes away, e.g. because
+		// the operator restarts, so that the repeated request gets its response
+		next.ServeHTTP(recorder, r.WithContext(context.WithoutCancel(r.Context())))
+	})
+}
+
+// responseRecorder passes the response on and keeps a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(statusCode int) {
+	rr.statusCode = statusCode
+	rr.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rr *responseRecorder) Write(data []byte) (int, error) {
+	if rr.statusCode == 0 {
+		rr.statusCode = http.StatusOK
+	}
+	rr.body.Write(data)
+	return rr.ResponseWriter.Write(data)
+}
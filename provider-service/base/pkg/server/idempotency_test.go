@@ -0,0 +1,116 @@
+//go:build unit
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/pkg/providers/base"
+)
+
+var _ = Describe("idempotentResponses", func() {
+	var (
+		responses  *idempotentResponses
+		calls      atomic.Int32
+		statusCode int
+		release    chan struct{}
+		handler    http.Handler
+	)
+
+	BeforeEach(func() {
+		responses = newIdempotentResponses()
+		calls.Store(0)
+		statusCode = http.StatusCreated
+		release = nil
+		handler = responses.middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			call := calls.Add(1)
+			if release != nil {
+				<-release
+			}
+			w.WriteHeader(statusCode)
+			w.Write([]byte{byte('0' + call)})
+		}))
+	})
+
+	send := func(key string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/resource/pipeline", nil)
+		if key != "" {
+			request.Header.Set(base.IdempotencyKeyHeader, key)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	It("responds to repeated requests with the response of the first", func() {
+		first := send("operation-id")
+		repeated := send("operation-id")
+
+		Expect(calls.Load()).To(Equal(int32(1)))
+		Expect(repeated.Code).To(Equal(http.StatusCreated))
+		Expect(repeated.Body.String()).To(Equal(first.Body.String()))
+	})
+
+	It("performs requests with other keys", func() {
+		send("operation-id")
+		send("another-operation-id")
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("performs requests without a key", func() {
+		send("")
+		send("")
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("performs requests again after a server error", func() {
+		statusCode = http.StatusInternalServerError
+		send("operation-id")
+		statusCode = http.StatusCreated
+		repeated := send("operation-id")
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+		Expect(repeated.Code).To(Equal(http.StatusCreated))
+	})
+
+	It("waits for the first request when it is still in progress", func() {
+		release = make(chan struct{})
+		first := make(chan *httptest.ResponseRecorder)
+		go func() {
+			defer GinkgoRecover()
+			first <- send("operation-id")
+		}()
+		Eventually(calls.Load).Should(Equal(int32(1)))
+
+		repeated := make(chan *httptest.ResponseRecorder)
+		go func() {
+			defer GinkgoRecover()
+			repeated <- send("operation-id")
+		}()
+		Consistently(repeated).ShouldNot(Receive())
+
+		close(release)
+
+		Expect((<-first).Body.String()).To(Equal("1"))
+		Expect((<-repeated).Body.String()).To(Equal("1"))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("forgets responses after the retention", func() {
+		now := time.Now()
+		responses.now = func() time.Time { return now }
+		send("operation-id")
+
+		now = now.Add(IdempotencyKeyRetention + time.Second)
+		send("operation-id")
+
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+})
@@ -140,6 +140,80 @@ func deleteHandler(ctx context.Context, hr resource.HttpHandledResource) http.Ha
 	}
 }
 
+func getHandler(ctx context.Context, hr resource.HttpHandledResource) http.HandlerFunc {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestCtx := logr.NewContext(r.Context(), logger)
+
+		id := chi.URLParam(r, "id")
+		decodedId, err := url.PathUnescape(id)
+		if err != nil {
+			writeErrorResponse(w, decodedId, err, http.StatusBadRequest)
+			return
+		}
+
+		resp, err := hr.Get(requestCtx, decodedId)
+
+		switch {
+		case err == nil:
+			writeResponse(w, resp, http.StatusOK)
+			return
+
+		case errors.As(err, new(*resource.NotFoundError)):
+			writeErrorResponse(w, decodedId, err, http.StatusNotFound)
+			return
+
+		case errors.As(err, new(*resource.UserError)):
+			writeErrorResponse(w, decodedId, err, http.StatusBadRequest)
+			return
+
+		case errors.As(err, new(*resource.UnimplementedError)):
+			writeErrorResponse(w, decodedId, err, http.StatusNotImplemented)
+			return
+
+		default:
+			writeErrorResponse(w, decodedId, err, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// listHandler lists the resources that carry all labels given as query
+// parameters.
+func listHandler(ctx context.Context, hr resource.HttpHandledResource) http.HandlerFunc {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestCtx := logr.NewContext(r.Context(), logger)
+
+		labels := map[string]string{}
+		for key, values := range r.URL.Query() {
+			labels[key] = values[0]
+		}
+
+		resp, err := hr.List(requestCtx, labels)
+
+		switch {
+		case err == nil:
+			writeResponse(w, resp, http.StatusOK)
+			return
+
+		case errors.As(err, new(*resource.UserError)):
+			writeErrorResponse(w, "", err, http.StatusBadRequest)
+			return
+
+		case errors.As(err, new(*resource.UnimplementedError)):
+			writeErrorResponse(w, "", err, http.StatusNotImplemented)
+			return
+
+		default:
+			writeErrorResponse(w, "", err, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
 func newHandlerFunc(
 	resource resource.HttpHandledResource,
 	handler http.Handler,
@@ -163,11 +237,15 @@ func newHandler(ctx context.Context, resources []resource.HttpHandledResource) h
 	mux.Get("/livez", livenessHandler)
 	mux.Get("/readyz", readinessHandler)
 
+	idempotent := newIdempotentResponses()
+
 	for _, resource := range resources {
 		mux.Route("/resource/"+resource.Type(), func(r chi.Router) {
-			r.Post("/", newHandlerFunc(resource, createHandler(ctx, resource), http.MethodPost))
-			r.Put("/{id}", newHandlerFunc(resource, updateHandler(ctx, resource), http.MethodPut))
-			r.Delete("/{id}", newHandlerFunc(resource, deleteHandler(ctx, resource), http.MethodDelete))
+			r.With(idempotent.middleware).Post("/", newHandlerFunc(resource, createHandler(ctx, resource), http.MethodPost))
+			r.Get("/", newHandlerFunc(resource, listHandler(ctx, resource), http.MethodGet))
+			r.Get("/{id}", newHandlerFunc(resource, getHandler(ctx, resource), http.MethodGet))
+			r.With(idempotent.middleware).Put("/{id}", newHandlerFunc(resource, updateHandler(ctx, resource), http.MethodPut))
+			r.With(idempotent.middleware).Delete("/{id}", newHandlerFunc(resource, deleteHandler(ctx, resource), http.MethodDelete))
 		})
 	}
 
@@ -202,7 +280,7 @@ func writeErrorResponse(w http.ResponseWriter, id string, providerError error, s
 	writeResponse(w, responseBody, statusCode)
 }
 
-func writeResponse(w http.ResponseWriter, responseBody base.Output, statusCode int) {
+func writeResponse(w http.ResponseWriter, responseBody any, statusCode int) {
 	marshalledResponse, err := json.Marshal(responseBody)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
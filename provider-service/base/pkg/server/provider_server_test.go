@@ -59,6 +59,24 @@ func (m *MockHandledResource) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockHandledResource) Get(ctx context.Context, id string) (base.Output, error) {
+	args := m.Called(ctx, id)
+	var response base.Output
+	if arg0 := args.Get(0); arg0 != nil {
+		response = arg0.(base.Output)
+	}
+	return response, args.Error(1)
+}
+
+func (m *MockHandledResource) List(ctx context.Context, labels map[string]string) ([]base.Output, error) {
+	args := m.Called(ctx, labels)
+	var response []base.Output
+	if arg0 := args.Get(0); arg0 != nil {
+		response = arg0.([]base.Output)
+	}
+	return response, args.Error(1)
+}
+
 type failReader struct{}
 
 func (f *failReader) Read(_ []byte) (int, error) {
@@ -503,5 +521,168 @@ var _ = Describe("Http Server Endpoints", func() {
 				})
 			})
 		})
+
+		Context("/{id} GET request getHandler", func() {
+			When("succeeds", func() {
+				It("returns 200 with valid response body", func() {
+					id := "mock-id/bla"
+					encodedId := url.PathEscape(id)
+					handledResource.On("Get", ignoreCtx, id).Return(base.Output{Id: id, Version: "v1"}, nil)
+
+					req := httptest.NewRequest(
+						http.MethodGet,
+						"/resource/"+resourceType+"/"+encodedId,
+						nil,
+					)
+					rr := httptest.NewRecorder()
+					server.Config.Handler.ServeHTTP(rr, req)
+					resp := rr.Result()
+
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := io.ReadAll(resp.Body)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(Equal(`{"id":"mock-id/bla","version":"v1"}`))
+				})
+			})
+
+			When("handledResource Get fails", func() {
+				When("the error is NotFoundError", func() {
+					It("returns 404 with error response body", func() {
+						id := "mock-id"
+						response := resource.NotFoundError{
+							Id:           id,
+							ResourceType: resourceType,
+						}
+						handledResource.On("Get", ignoreCtx, id).Return(nil, &response)
+						req := httptest.NewRequest(
+							http.MethodGet,
+							"/resource/"+resourceType+"/"+id,
+							nil,
+						)
+						rr := httptest.NewRecorder()
+						server.Config.Handler.ServeHTTP(rr, req)
+						resp := rr.Result()
+
+						Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+						body, err := io.ReadAll(resp.Body)
+
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(Equal(`{"id":"mock-id","providerError":"` + response.Error() + `"}`))
+					})
+				})
+
+				When("the error is UnimplementedError", func() {
+					It("returns 501 with error response body", func() {
+						id := "mock-id"
+						response := resource.UnimplementedError{
+							Method:       "Get",
+							ResourceType: resourceType,
+						}
+						handledResource.On("Get", ignoreCtx, id).Return(nil, &response)
+						req := httptest.NewRequest(
+							http.MethodGet,
+							"/resource/"+resourceType+"/"+id,
+							nil,
+						)
+						rr := httptest.NewRecorder()
+						server.Config.Handler.ServeHTTP(rr, req)
+						resp := rr.Result()
+
+						Expect(resp.StatusCode).To(Equal(http.StatusNotImplemented))
+					})
+				})
+
+				It("returns 500 with error response body", func() {
+					id := "mock-id"
+					response := "failed to get"
+					handledResource.On("Get", ignoreCtx, id).Return(nil, errors.New(response))
+					req := httptest.NewRequest(
+						http.MethodGet,
+						"/resource/"+resourceType+"/"+id,
+						nil,
+					)
+					rr := httptest.NewRecorder()
+					server.Config.Handler.ServeHTTP(rr, req)
+					resp := rr.Result()
+
+					Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+
+					body, err := io.ReadAll(resp.Body)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(Equal(`{"id":"mock-id","providerError":"` + response + `"}`))
+				})
+			})
+		})
+
+		Context("/ GET request listHandler", func() {
+			When("succeeds", func() {
+				It("returns 200 with the resources matching the query labels", func() {
+					labels := map[string]string{"provider-name": "provider", "provider-namespace": "namespace"}
+					handledResource.On("List", ignoreCtx, labels).Return([]base.Output{{Id: "a"}, {Id: "b"}}, nil)
+
+					req := httptest.NewRequest(
+						http.MethodGet,
+						"/resource/"+resourceType+"/?provider-name=provider&provider-namespace=namespace",
+						nil,
+					)
+					rr := httptest.NewRecorder()
+					server.Config.Handler.ServeHTTP(rr, req)
+					resp := rr.Result()
+
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := io.ReadAll(resp.Body)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(Equal(`[{"id":"a"},{"id":"b"}]`))
+				})
+			})
+
+			When("handledResource List fails", func() {
+				When("the error is UnimplementedError", func() {
+					It("returns 501 with error response body", func() {
+						response := resource.UnimplementedError{
+							Method:       "List",
+							ResourceType: resourceType,
+						}
+						handledResource.On("List", ignoreCtx, map[string]string{}).Return(nil, &response)
+						req := httptest.NewRequest(
+							http.MethodGet,
+							"/resource/"+resourceType+"/",
+							nil,
+						)
+						rr := httptest.NewRecorder()
+						server.Config.Handler.ServeHTTP(rr, req)
+						resp := rr.Result()
+
+						Expect(resp.StatusCode).To(Equal(http.StatusNotImplemented))
+					})
+				})
+
+				It("returns 500 with error response body", func() {
+					response := "failed to list"
+					handledResource.On("List", ignoreCtx, map[string]string{}).Return(nil, errors.New(response))
+					req := httptest.NewRequest(
+						http.MethodGet,
+						"/resource/"+resourceType+"/",
+						nil,
+					)
+					rr := httptest.NewRecorder()
+					server.Config.Handler.ServeHTTP(rr, req)
+					resp := rr.Result()
+
+					Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+
+					body, err := io.ReadAll(resp.Body)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(Equal(`{"providerError":"` + response + `"}`))
+				})
+			})
+		})
 	})
 })
@@ -66,3 +66,22 @@ func (e *Experiment) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (e *Experiment) Get(ctx context.Context, id string) (base.Output, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	version, err := e.Provider.GetExperiment(ctx, id)
+	if err != nil {
+		logger.Error(err, "GetExperiment failed", "id", id)
+		return base.Output{}, err
+	}
+	logger.Info("GetExperiment succeeded", "id", id, "version", version)
+
+	return base.Output{
+		Id:      id,
+		Version: version,
+	}, nil
+}
+
+func (e *Experiment) List(_ context.Context, _ map[string]string) ([]base.Output, error) {
+	return nil, &UnimplementedError{Method: "List", ResourceType: e.Type()}
+}
@@ -66,3 +66,22 @@ func (p *Pipeline) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (p *Pipeline) Get(ctx context.Context, id string) (base.Output, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	version, err := p.Provider.GetPipeline(ctx, id)
+	if err != nil {
+		logger.Error(err, "GetPipeline failed", "id", id)
+		return base.Output{}, err
+	}
+	logger.Info("GetPipeline succeeded", "id", id, "version", version)
+
+	return base.Output{
+		Id:      id,
+		Version: version,
+	}, nil
+}
+
+func (p *Pipeline) List(_ context.Context, _ map[string]string) ([]base.Output, error) {
+	return nil, &UnimplementedError{Method: "List", ResourceType: p.Type()}
+}
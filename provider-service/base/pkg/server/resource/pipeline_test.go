@@ -141,6 +141,41 @@ var _ = Describe("Pipeline", Ordered, func() {
 			})
 		})
 	})
+
+	Context("Get", func() {
+		When("the provider knows the resource", func() {
+			It("returns the id and version of the resource", func() {
+				id := "some-id"
+				mockProvider.On("GetPipeline", ignoreCtx, id).Return("some-version", nil)
+				response, err := p.Get(ctx, id)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal(base.Output{Id: id, Version: "some-version"}))
+			})
+		})
+
+		When("provider errors", func() {
+			It("errors", func() {
+				id := "some-id"
+				expectedErr := &NotFoundError{Id: id, ResourceType: p.Type()}
+				mockProvider.On("GetPipeline", ignoreCtx, id).Return("", expectedErr)
+				response, err := p.Get(ctx, id)
+
+				Expect(err).To(Equal(expectedErr))
+				Expect(response).To(Equal(base.Output{}))
+			})
+		})
+	})
+
+	Context("List", func() {
+		It("returns an Unimplemented error", func() {
+			response, err := p.List(ctx, map[string]string{})
+
+			var expectedErr *UnimplementedError
+			Expect(errors.As(err, &expectedErr)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+	})
 })
 
 type MockPipelineProvider struct {
@@ -171,3 +206,8 @@ func (m *MockPipelineProvider) DeletePipeline(
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockPipelineProvider) GetPipeline(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
@@ -25,6 +25,7 @@ type PipelineProvider interface {
 	CreatePipeline(ctx context.Context, ppd PipelineDefinitionWrapper) (string, error)
 	UpdatePipeline(ctx context.Context, ppd PipelineDefinitionWrapper, id string) (string, error)
 	DeletePipeline(ctx context.Context, id string) error
+	GetPipeline(ctx context.Context, id string) (string, error)
 }
 
 type RunProvider interface {
@@ -36,12 +37,15 @@ type RunScheduleProvider interface {
 	CreateRunSchedule(ctx context.Context, rsd base.RunScheduleDefinition) (string, error)
 	UpdateRunSchedule(ctx context.Context, rsd base.RunScheduleDefinition, id string) (string, error)
 	DeleteRunSchedule(ctx context.Context, id string) error
+	GetRunSchedule(ctx context.Context, id string) (string, error)
+	ListRunSchedules(ctx context.Context, labels map[string]string) ([]string, error)
 }
 
 type ExperimentProvider interface {
 	CreateExperiment(ctx context.Context, ed base.ExperimentDefinition) (string, error)
 	UpdateExperiment(ctx context.Context, ed base.ExperimentDefinition, id string) (string, error)
 	DeleteExperiment(ctx context.Context, id string) error
+	GetExperiment(ctx context.Context, id string) (string, error)
 }
 
 type UserError struct {
@@ -52,6 +56,17 @@ func (e *UserError) Error() string {
 	return e.E.Error()
 }
 
+// NotFoundError is returned by Get operations when the provider does not
+// know a resource with the requested id.
+type NotFoundError struct {
+	Id           string
+	ResourceType string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("Resource %s with id %s not found", e.ResourceType, e.Id)
+}
+
 type UnimplementedError struct {
 	Method       string
 	ResourceType string
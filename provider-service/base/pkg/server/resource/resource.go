@@ -10,4 +10,6 @@ type HttpHandledResource interface {
 	Create(ctx context.Context, body []byte) (base.Output, error)
 	Update(ctx context.Context, id string, body []byte) (base.Output, error)
 	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (base.Output, error)
+	List(ctx context.Context, labels map[string]string) ([]base.Output, error)
 }
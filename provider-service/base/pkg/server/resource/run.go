@@ -50,3 +50,11 @@ func (r *Run) Delete(ctx context.Context, id string) error {
 	logger.Info("DeleteRun succeeded", "id", id)
 	return nil
 }
+
+func (r *Run) Get(_ context.Context, _ string) (base.Output, error) {
+	return base.Output{}, &UnimplementedError{Method: "Get", ResourceType: r.Type()}
+}
+
+func (r *Run) List(_ context.Context, _ map[string]string) ([]base.Output, error) {
+	return nil, &UnimplementedError{Method: "List", ResourceType: r.Type()}
+}
@@ -83,6 +83,17 @@ var _ = Describe("Run", Ordered, func() {
 		})
 	})
 
+	Context("Get", func() {
+		It("returns an Unimplemented error", func() {
+			id := "some-id"
+			response, err := r.Get(ctx, id)
+
+			var expectedErr *UnimplementedError
+			Expect(errors.As(err, &expectedErr)).To(BeTrue())
+			Expect(response).To(Equal(base.Output{}))
+		})
+	})
+
 	Context("Delete", func() {
 		When("valid id is passed and provider operations succeed", func() {
 			It("return no error", func() {
@@ -105,6 +116,16 @@ var _ = Describe("Run", Ordered, func() {
 			})
 		})
 	})
+
+	Context("List", func() {
+		It("returns an Unimplemented error", func() {
+			response, err := r.List(ctx, map[string]string{})
+
+			var expectedErr *UnimplementedError
+			Expect(errors.As(err, &expectedErr)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+	})
 })
 
 type MockRunProvider struct {
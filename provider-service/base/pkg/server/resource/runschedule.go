@@ -66,3 +66,35 @@ func (rs *RunSchedule) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (rs *RunSchedule) Get(ctx context.Context, id string) (base.Output, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	version, err := rs.Provider.GetRunSchedule(ctx, id)
+	if err != nil {
+		logger.Error(err, "GetRunSchedule failed", "id", id)
+		return base.Output{}, err
+	}
+	logger.Info("GetRunSchedule succeeded", "id", id, "version", version)
+
+	return base.Output{
+		Id:      id,
+		Version: version,
+	}, nil
+}
+
+func (rs *RunSchedule) List(ctx context.Context, labels map[string]string) ([]base.Output, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	ids, err := rs.Provider.ListRunSchedules(ctx, labels)
+	if err != nil {
+		logger.Error(err, "ListRunSchedules failed", "labels", labels)
+		return nil, err
+	}
+	logger.Info("ListRunSchedules succeeded", "labels", labels, "count", len(ids))
+
+	outputs := make([]base.Output, 0, len(ids))
+	for _, id := range ids {
+		outputs = append(outputs, base.Output{Id: id})
+	}
+
+	return outputs, nil
+}
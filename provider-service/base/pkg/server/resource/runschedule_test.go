@@ -141,6 +141,53 @@ var _ = Describe("RunSchedule", Ordered, func() {
 			})
 		})
 	})
+
+	Context("Get", func() {
+		When("the provider knows the resource", func() {
+			It("returns the id and version of the resource", func() {
+				id := "some-id"
+				mockProvider.On("GetRunSchedule", ignoreCtx, id).Return("some-version", nil)
+				response, err := rs.Get(ctx, id)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal(base.Output{Id: id, Version: "some-version"}))
+			})
+		})
+
+		When("provider errors", func() {
+			It("errors", func() {
+				id := "some-id"
+				expectedErr := &NotFoundError{Id: id, ResourceType: rs.Type()}
+				mockProvider.On("GetRunSchedule", ignoreCtx, id).Return("", expectedErr)
+				response, err := rs.Get(ctx, id)
+
+				Expect(err).To(Equal(expectedErr))
+				Expect(response).To(Equal(base.Output{}))
+			})
+		})
+	})
+
+	Context("List", func() {
+		It("returns the ids of the labelled resources", func() {
+			labels := map[string]string{"provider-name": "provider"}
+			mockProvider.On("ListRunSchedules", ignoreCtx, labels).Return([]string{"a", "b"}, nil)
+			response, err := rs.List(ctx, labels)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal([]base.Output{{Id: "a"}, {Id: "b"}}))
+		})
+
+		When("provider errors", func() {
+			It("errors", func() {
+				expectedErr := errors.New("failed")
+				mockProvider.On("ListRunSchedules", ignoreCtx, mock.Anything).Return(nil, expectedErr)
+				response, err := rs.List(ctx, map[string]string{})
+
+				Expect(err).To(Equal(expectedErr))
+				Expect(response).To(BeNil())
+			})
+		})
+	})
 })
 
 type MockRunScheduleProvider struct {
@@ -168,3 +215,17 @@ func (m *MockRunScheduleProvider) DeleteRunSchedule(ctx context.Context, rsd str
 	args := m.Called(ctx, rsd)
 	return args.Error(0)
 }
+
+func (m *MockRunScheduleProvider) GetRunSchedule(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRunScheduleProvider) ListRunSchedules(ctx context.Context, labels map[string]string) ([]string, error) {
+	args := m.Called(ctx, labels)
+	var ids []string
+	if arg0 := args.Get(0); arg0 != nil {
+		ids = arg0.([]string)
+	}
+	return ids, args.Error(1)
+}
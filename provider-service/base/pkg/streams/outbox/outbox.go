@@ -0,0 +1,404 @@
+package outbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	. "github.com/sky-uk/kfp-operator/provider-service/base/pkg"
+	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var DefaultBackoff = Backoff{
+	Initial: time.Second,
+	Max:     5 * time.Minute,
+}
+
+// DefaultMaxAge is how long events are retried for before they are dropped.
+const DefaultMaxAge = 7 * 24 * time.Hour
+
+// Backoff doubles the delay between attempts up to the maximum. Delays are
+// randomised between half and all of their length so that providers do not
+// retry in lockstep after an outage of the operator.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+func (b Backoff) Delay(attempts int, random func() float64) time.Duration {
+	delay := b.Initial
+	for i := 1; i < attempts && delay < b.Max; i++ {
+		delay *= 2
+	}
+	delay = min(delay, b.Max)
+
+	return delay/2 + time.Duration(random()*float64(delay/2))
+}
+
+type pendingRecord struct {
+	Record
+	handlers []OnCompleteHandlers
+	inFlight bool
+}
+
+// Outbox stores run completion events until the operator has accepted them.
+// Events are passed on with handlers of the outbox, which retries them with
+// backoff on recoverable failures. Events that are older than the maximum
+// age are dropped instead of being retried again. The handlers of the source
+// are only called once an event has been delivered, discarded or dropped,
+// and events the source emits again while they are pending are not
+// duplicated.
+type Outbox struct {
+	store     Store
+	backoff   Backoff
+	maxAge    time.Duration
+	onDropped func()
+	in        chan StreamMessage[*common.RunCompletionEventData]
+	out       chan StreamMessage[*common.RunCompletionEventData]
+	wake      chan struct{}
+	mutex     sync.Mutex
+	pending   map[string]*pendingRecord
+	now       func() time.Time
+	random    func() float64
+}
+
+// NewOutbox loads the events of the store and starts delivering them. Events
+// are never dropped when the maximum age is not positive.
+func NewOutbox(ctx context.Context, store Store, backoff Backoff, maxAge time.Duration) (*Outbox, error) {
+	outbox, err := newOutbox(ctx, store, backoff, maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox.start(ctx)
+
+	return outbox, nil
+}
+
+func newOutbox(ctx context.Context, store Store, backoff Backoff, maxAge time.Duration) (*Outbox, error) {
+	outbox := &Outbox{
+		store:     store,
+		backoff:   backoff,
+		maxAge:    maxAge,
+		onDropped: func() {},
+		in:        make(chan StreamMessage[*common.RunCompletionEventData]),
+		out:       make(chan StreamMessage[*common.RunCompletionEventData]),
+		wake:      make(chan struct{}, 1),
+		pending:   map[string]*pendingRecord{},
+		now:       time.Now,
+		random:    rand.Float64,
+	}
+
+	records, unreadable, err := store.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load outbox: %w", err)
+	}
+	for _, err := range unreadable {
+		logr.FromContextOrDiscard(ctx).Error(err, "quarantined unreadable outbox record")
+	}
+	for _, record := range records {
+		outbox.pending[record.Key] = &pendingRecord{Record: record}
+	}
+
+	return outbox, nil
+}
+
+func (o *Outbox) start(ctx context.Context) {
+	go o.receive(ctx)
+	go o.dispatch(ctx)
+}
+
+func NewObservedOutbox(ctx context.Context, store Store, backoff Backoff, maxAge time.Duration) (*Outbox, error) {
+	outbox, err := newOutbox(ctx, store, backoff, maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	meter := otel.Meter("outbox")
+	depthGauge, err := meter.Int64ObservableGauge(
+		"provider_outbox_depth",
+		metric.WithDescription("Number of run completion events waiting to be delivered to the operator"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ageGauge, err := meter.Float64ObservableGauge(
+		"provider_outbox_oldest_event_age",
+		metric.WithDescription("Age of the oldest run completion event waiting to be delivered to the operator"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		depth, oldest := outbox.Stats()
+		observer.ObserveInt64(depthGauge, int64(depth))
+		observer.ObserveFloat64(ageGauge, oldest.Seconds())
+		return nil
+	}, depthGauge, ageGauge); err != nil {
+		return nil, err
+	}
+
+	droppedCounter, err := meter.Int64Counter(
+		"provider_outbox_dropped_events",
+		metric.WithDescription("Number of run completion events dropped because they could not be delivered to the operator within the maximum age"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	outbox.onDropped = func() {
+		droppedCounter.Add(ctx, 1)
+	}
+
+	outbox.start(ctx)
+
+	return outbox, nil
+}
+
+func (o *Outbox) In() chan<- StreamMessage[*common.RunCompletionEventData] {
+	return o.in
+}
+
+func (o *Outbox) Out() <-chan StreamMessage[*common.RunCompletionEventData] {
+	return o.out
+}
+
+func (o *Outbox) To(inlet streams.Inlet[StreamMessage[*common.RunCompletionEventData]]) {
+	go func() {
+		for message := range o.out {
+			inlet.In() <- message
+		}
+	}()
+}
+
+// Stats returns the number of pending events and the age of the oldest.
+func (o *Outbox) Stats() (depth int, oldest time.Duration) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	now := o.now()
+	for _, record := range o.pending {
+		oldest = max(oldest, now.Sub(record.CreatedAt))
+	}
+
+	return len(o.pending), oldest
+}
+
+// eventKey identifies the completion of a run so that events the source
+// emits again are not delivered twice. Events that report another status of
+// the same run are delivered on their own.
+func eventKey(event *common.RunCompletionEventData) string {
+	identity := fmt.Sprintf("%s/%s/%s/%s", event.Provider.Namespace, event.Provider.Name, event.RunId, event.Status)
+	if event.RunId == "" {
+		eventBytes, _ := json.Marshal(event)
+		identity = string(eventBytes)
+	}
+
+	hash := sha256.Sum256([]byte(identity))
+	return hex.EncodeToString(hash[:])
+}
+
+func (o *Outbox) receive(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	for message := range o.in {
+		if message.Message == nil {
+			// there is nothing to deliver, the sink decides what to do
+			o.out <- message
+			continue
+		}
+
+		if err := o.add(message); err != nil {
+			logger.Error(err, "failed to store run completion event", "runId", message.Message.RunId)
+			message.OnRecoverableFailure()
+			continue
+		}
+
+		o.notify()
+	}
+}
+
+func (o *Outbox) add(message StreamMessage[*common.RunCompletionEventData]) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	key := eventKey(message.Message)
+	if existing, ok := o.pending[key]; ok {
+		existing.handlers = append(existing.handlers, message.OnCompleteHandlers)
+		return nil
+	}
+
+	now := o.now()
+	record := Record{
+		Key:         key,
+		Event:       message.Message,
+		CreatedAt:   now,
+		NextAttempt: now,
+	}
+	if err := o.store.Put(record); err != nil {
+		return err
+	}
+
+	o.pending[key] = &pendingRecord{Record: record, handlers: []OnCompleteHandlers{message.OnCompleteHandlers}}
+
+	return nil
+}
+
+func (o *Outbox) notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) dispatch(ctx context.Context) {
+	for {
+		o.dropExpired(ctx)
+		record, wait := o.next()
+
+		if record != nil {
+			select {
+			case o.out <- o.deliveryMessage(ctx, *record):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+
+		select {
+		case <-timer:
+		case <-o.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dropExpired drops the records that are older than the maximum age and
+// are not being delivered.
+func (o *Outbox) dropExpired(ctx context.Context) {
+	if o.maxAge <= 0 {
+		return
+	}
+
+	logger := logr.FromContextOrDiscard(ctx)
+
+	o.mutex.Lock()
+	now := o.now()
+	var expired []*pendingRecord
+	for _, record := range o.pending {
+		if !record.inFlight && now.Sub(record.CreatedAt) >= o.maxAge {
+			expired = append(expired, record)
+		}
+	}
+	o.mutex.Unlock()
+
+	for _, record := range expired {
+		logger.Info("dropping run completion event that could not be delivered within the maximum age", "runId", record.Event.RunId, "attempts", record.Attempts)
+		for _, handlers := range o.remove(ctx, record.Key) {
+			handlers.OnUnrecoverableFailure()
+		}
+		o.onDropped()
+	}
+}
+
+// next returns the record that is due next, or how long to wait for it. It
+// waits indefinitely when nothing is pending.
+func (o *Outbox) next() (*Record, time.Duration) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	var next *pendingRecord
+	for _, record := range o.pending {
+		if !record.inFlight && (next == nil || record.NextAttempt.Before(next.NextAttempt)) {
+			next = record
+		}
+	}
+
+	if next == nil {
+		return nil, 0
+	}
+
+	if wait := next.NextAttempt.Sub(o.now()); wait > 0 {
+		return nil, wait
+	}
+
+	next.inFlight = true
+	record := next.Record
+
+	return &record, 0
+}
+
+func (o *Outbox) deliveryMessage(ctx context.Context, record Record) StreamMessage[*common.RunCompletionEventData] {
+	return StreamMessage[*common.RunCompletionEventData]{
+		Message: record.Event,
+		OnCompleteHandlers: OnCompleteHandlers{
+			OnSuccessHandler: func() {
+				for _, handlers := range o.remove(ctx, record.Key) {
+					handlers.OnSuccess()
+				}
+			},
+			OnRecoverableFailureHandler: func() {
+				o.retry(ctx, record.Key)
+			},
+			OnUnrecoverableFailureHandler: func() {
+				for _, handlers := range o.remove(ctx, record.Key) {
+					handlers.OnUnrecoverableFailure()
+				}
+			},
+		},
+	}
+}
+
+// remove deletes a delivered or discarded record and returns the handlers of
+// the source.
+func (o *Outbox) remove(ctx context.Context, key string) []OnCompleteHandlers {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	record, ok := o.pending[key]
+	if !ok {
+		return nil
+	}
+	delete(o.pending, key)
+
+	if err := o.store.Delete(key); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to delete run completion event from outbox", "runId", record.Event.RunId)
+	}
+
+	return record.handlers
+}
+
+func (o *Outbox) retry(ctx context.Context, key string) {
+	o.mutex.Lock()
+	defer o.notify()
+	defer o.mutex.Unlock()
+
+	record, ok := o.pending[key]
+	if !ok {
+		return
+	}
+
+	record.inFlight = false
+	record.Attempts++
+	record.NextAttempt = o.now().Add(o.backoff.Delay(record.Attempts, o.random))
+
+	if err := o.store.Put(record.Record); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to update run completion event in outbox", "runId", record.Event.RunId)
+	}
+}
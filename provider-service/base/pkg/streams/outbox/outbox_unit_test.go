@@ -0,0 +1,299 @@
+//go:build unit
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+	. "github.com/sky-uk/kfp-operator/provider-service/base/pkg"
+)
+
+func TestOutboxUnitSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Outbox Unit Suite")
+}
+
+type failingStore struct{}
+
+func (fs failingStore) Put(_ Record) error {
+	return errors.New("disk full")
+}
+
+func (fs failingStore) Delete(_ string) error {
+	return nil
+}
+
+func (fs failingStore) List() ([]Record, []error, error) {
+	return nil, nil, nil
+}
+
+type unlistableStore struct {
+	failingStore
+}
+
+func (us unlistableStore) List() ([]Record, []error, error) {
+	return nil, nil, errors.New("permission denied")
+}
+
+var _ = Context("Backoff", func() {
+	backoff := Backoff{Initial: time.Second, Max: 10 * time.Second}
+	never := func() float64 { return 0 }
+	always := func() float64 { return 1 }
+
+	DescribeTable("doubles the delay up to the maximum", func(attempts int, expected time.Duration) {
+		Expect(backoff.Delay(attempts, always)).To(Equal(expected))
+		Expect(backoff.Delay(attempts, never)).To(Equal(expected / 2))
+	},
+		Entry("first attempt", 1, time.Second),
+		Entry("second attempt", 2, 2*time.Second),
+		Entry("third attempt", 3, 4*time.Second),
+		Entry("capped", 5, 10*time.Second),
+		Entry("many attempts", 100, 10*time.Second),
+	)
+})
+
+var _ = Context("Outbox", func() {
+	var (
+		ctx     context.Context
+		store   *MemoryStore
+		outbox  *Outbox
+		handled chan string
+	)
+
+	backoff := Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		store = NewMemoryStore()
+		handled = make(chan string, 10)
+	})
+
+	start := func() {
+		var err error
+		outbox, err = NewOutbox(ctx, store, backoff, 0)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	sourceMessage := func(event *common.RunCompletionEventData, name string) StreamMessage[*common.RunCompletionEventData] {
+		return StreamMessage[*common.RunCompletionEventData]{
+			Message: event,
+			OnCompleteHandlers: OnCompleteHandlers{
+				OnSuccessHandler:              func() { handled <- name + ":success" },
+				OnRecoverableFailureHandler:   func() { handled <- name + ":recoverable" },
+				OnUnrecoverableFailureHandler: func() { handled <- name + ":unrecoverable" },
+			},
+		}
+	}
+
+	When("the operator accepts an event", func() {
+		It("acknowledges the source and removes the event", func() {
+			start()
+			event := randomRecord().Event
+			outbox.In() <- sourceMessage(event, "source")
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			Expect(delivered.Message).To(Equal(event))
+			Expect(store.List()).To(HaveLen(1))
+			Consistently(handled).ShouldNot(Receive())
+
+			delivered.OnSuccess()
+
+			Expect(handled).To(Receive(Equal("source:success")))
+			Expect(store.List()).To(BeEmpty())
+			Expect(outbox.Stats()).To(Equal(0))
+		})
+	})
+
+	When("the operator rejects an event permanently", func() {
+		It("discards the event", func() {
+			start()
+			outbox.In() <- sourceMessage(randomRecord().Event, "source")
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			delivered.OnUnrecoverableFailure()
+
+			Expect(handled).To(Receive(Equal("source:unrecoverable")))
+			Expect(store.List()).To(BeEmpty())
+		})
+	})
+
+	When("delivering an event fails", func() {
+		It("retries the event without involving the source", func() {
+			start()
+			event := randomRecord().Event
+			outbox.In() <- sourceMessage(event, "source")
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			delivered.OnRecoverableFailure()
+
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			Expect(delivered.Message).To(Equal(event))
+			Expect(handled).NotTo(Receive())
+			Expect(store.List()).To(ConsistOf(HaveField("Attempts", 1)))
+
+			delivered.OnSuccess()
+			Expect(handled).To(Receive(Equal("source:success")))
+		})
+	})
+
+	When("the source emits an event again while it is pending", func() {
+		It("delivers the event once and acknowledges both messages", func() {
+			start()
+			event := randomRecord().Event
+			outbox.In() <- sourceMessage(event, "first")
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+
+			outbox.In() <- sourceMessage(event, "second")
+			Consistently(outbox.Out()).ShouldNot(Receive())
+
+			delivered.OnSuccess()
+
+			Expect(handled).To(Receive(Equal("first:success")))
+			Expect(handled).To(Receive(Equal("second:success")))
+		})
+	})
+
+	When("the source emits another status of a pending run", func() {
+		It("delivers both events", func() {
+			start()
+			event := randomRecord().Event
+			outbox.In() <- sourceMessage(event, "first")
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			Expect(delivered.Message).To(Equal(event))
+
+			failed := *event
+			failed.Status = common.RunCompletionStatuses.Failed
+			outbox.In() <- sourceMessage(&failed, "second")
+
+			var redelivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&redelivered))
+			Expect(redelivered.Message).To(Equal(&failed))
+
+			delivered.OnSuccess()
+			redelivered.OnSuccess()
+			Expect(handled).To(Receive(Equal("first:success")))
+			Expect(handled).To(Receive(Equal("second:success")))
+		})
+	})
+
+	When("events were stored before a restart", func() {
+		It("delivers them", func() {
+			record := randomRecord()
+			Expect(store.Put(record)).To(Succeed())
+			start()
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			Expect(delivered.Message).To(Equal(record.Event))
+
+			delivered.OnSuccess()
+			Eventually(store.List).Should(BeEmpty())
+		})
+	})
+
+	When("the event cannot be stored", func() {
+		It("fails the message of the source", func() {
+			var err error
+			outbox, err = NewOutbox(ctx, failingStore{}, backoff, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			outbox.In() <- sourceMessage(randomRecord().Event, "source")
+
+			Eventually(handled).Should(Receive(Equal("source:recoverable")))
+			Consistently(outbox.Out()).ShouldNot(Receive())
+		})
+	})
+
+	When("stored events cannot be read", func() {
+		It("quarantines them and delivers the others", func() {
+			directory := GinkgoT().TempDir()
+			directoryStore := DirectoryStore{directory: directory}
+			record := randomRecord()
+			Expect(directoryStore.Put(record)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(directory, "corrupt.json"), []byte("{"), 0o600)).To(Succeed())
+
+			var err error
+			outbox, err = NewOutbox(ctx, directoryStore, backoff, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			Expect(delivered.Message).To(Equal(record.Event))
+			Expect(filepath.Join(directory, QuarantineDirectory, "corrupt.json")).To(BeARegularFile())
+		})
+	})
+
+	When("stored events cannot be listed", func() {
+		It("fails", func() {
+			_, err := NewOutbox(ctx, unlistableStore{}, backoff, 0)
+
+			Expect(err).To(MatchError(ContainSubstring("permission denied")))
+		})
+	})
+
+	When("an event is older than the maximum age", func() {
+		It("drops the event and discards the message of the source", func() {
+			record := randomRecord()
+			record.CreatedAt = time.Now().Add(-time.Hour)
+			Expect(store.Put(record)).To(Succeed())
+
+			var err error
+			outbox, err = newOutbox(ctx, store, backoff, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			outbox.pending[record.Key].handlers = []OnCompleteHandlers{sourceMessage(record.Event, "source").OnCompleteHandlers}
+			dropped := make(chan struct{}, 1)
+			outbox.onDropped = func() { dropped <- struct{}{} }
+			outbox.start(ctx)
+
+			Eventually(dropped).Should(Receive())
+			Expect(handled).To(Receive(Equal("source:unrecoverable")))
+			Consistently(outbox.Out()).ShouldNot(Receive())
+			Expect(store.List()).To(BeEmpty())
+		})
+	})
+
+	When("a message has no event", func() {
+		It("passes it on", func() {
+			start()
+			outbox.In() <- sourceMessage(nil, "source")
+
+			var delivered StreamMessage[*common.RunCompletionEventData]
+			Eventually(outbox.Out()).Should(Receive(&delivered))
+			Expect(delivered.Message).To(BeNil())
+		})
+	})
+
+	It("reports the number and age of pending events", func() {
+		start()
+		now := time.Now()
+		record := randomRecord()
+		record.CreatedAt = now.Add(-time.Minute)
+
+		outbox.mutex.Lock()
+		outbox.now = func() time.Time { return now }
+		outbox.pending[record.Key] = &pendingRecord{Record: record, inFlight: true}
+		outbox.mutex.Unlock()
+
+		depth, oldest := outbox.Stats()
+		Expect(depth).To(Equal(1))
+		Expect(oldest).To(Equal(time.Minute))
+	})
+})
@@ -0,0 +1,196 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sky-uk/kfp-operator/pkg/common"
+)
+
+const (
+	recordFileSuffix = ".json"
+	// QuarantineDirectory is where records that cannot be read are moved to,
+	// relative to the directory of the store.
+	QuarantineDirectory = "quarantine"
+)
+
+// Record is a run completion event that has not been delivered yet.
+type Record struct {
+	Key         string                         `json:"key"`
+	Event       *common.RunCompletionEventData `json:"event"`
+	Attempts    int                            `json:"attempts"`
+	CreatedAt   time.Time                      `json:"createdAt"`
+	NextAttempt time.Time                      `json:"nextAttempt"`
+}
+
+type Store interface {
+	Put(record Record) error
+	Delete(key string) error
+	// List returns all records that can be read and the errors of those that
+	// cannot. The error is only returned when the records cannot be listed
+	// at all.
+	List() (records []Record, unreadable []error, err error)
+}
+
+// NewStore returns a store that keeps records in the directory, or in memory
+// when no directory is given.
+func NewStore(directory string) (Store, error) {
+	if directory == "" {
+		return NewMemoryStore(), nil
+	}
+
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	return DirectoryStore{directory: directory}, nil
+}
+
+// DirectoryStore keeps every record in a file of its own so that records
+// survive restarts of the provider service when the directory is on a
+// volume.
+type DirectoryStore struct {
+	directory string
+}
+
+func (ds DirectoryStore) path(key string) string {
+	return filepath.Join(ds.directory, key+recordFileSuffix)
+}
+
+// Put writes the record to a temporary file first so that records are never
+// left half-written.
+func (ds DirectoryStore) Put(record Record) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(ds.directory, record.Key+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(recordBytes); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), ds.path(record.Key))
+}
+
+func (ds DirectoryStore) Delete(key string) error {
+	if err := os.Remove(ds.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns all records that can be read. Records that cannot be read are
+// moved to the quarantine directory so that they are neither retried nor
+// block the records that can.
+func (ds DirectoryStore) List() ([]Record, []error, error) {
+	files, err := os.ReadDir(ds.directory)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []Record
+	var unreadable []error
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), recordFileSuffix) {
+			continue
+		}
+
+		record, err := ds.read(file.Name())
+		if err != nil {
+			unreadable = append(unreadable, errors.Join(err, ds.quarantine(file.Name())))
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records, unreadable, nil
+}
+
+func (ds DirectoryStore) read(fileName string) (Record, error) {
+	recordBytes, err := os.ReadFile(filepath.Join(ds.directory, fileName))
+	if err != nil {
+		return Record{}, err
+	}
+
+	record := Record{}
+	if err := json.Unmarshal(recordBytes, &record); err != nil {
+		return Record{}, fmt.Errorf("failed to read outbox record %s: %w", fileName, err)
+	}
+
+	return record, nil
+}
+
+func (ds DirectoryStore) quarantine(fileName string) error {
+	quarantineDirectory := filepath.Join(ds.directory, QuarantineDirectory)
+	if err := os.MkdirAll(quarantineDirectory, 0o700); err != nil {
+		return fmt.Errorf("failed to quarantine outbox record %s: %w", fileName, err)
+	}
+
+	if err := os.Rename(filepath.Join(ds.directory, fileName), filepath.Join(quarantineDirectory, fileName)); err != nil {
+		return fmt.Errorf("failed to quarantine outbox record %s: %w", fileName, err)
+	}
+
+	return nil
+}
+
+// MemoryStore keeps records until the provider service restarts.
+type MemoryStore struct {
+	mutex   sync.Mutex
+	records map[string]Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string]Record{}}
+}
+
+func (ms *MemoryStore) Put(record Record) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.records[record.Key] = record
+
+	return nil
+}
+
+func (ms *MemoryStore) Delete(key string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	delete(ms.records, key)
+
+	return nil
+}
+
+func (ms *MemoryStore) List() ([]Record, []error, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	records := make([]Record, 0, len(ms.records))
+	for _, record := range ms.records {
+		records = append(records, record)
+	}
+
+	return records, nil, nil
+}
@@ -0,0 +1,127 @@
+//go:build unit
+
+package outbox
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/pkg/common"
+)
+
+func randomRecord() Record {
+	event := &common.RunCompletionEventData{
+		Status:   common.RunCompletionStatuses.Succeeded,
+		RunId:    common.RandomString(),
+		Provider: common.RandomNamespacedName(),
+	}
+
+	return Record{
+		Key:         eventKey(event),
+		Event:       event,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		NextAttempt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+var _ = Context("NewStore", func() {
+	It("keeps records in memory when no directory is given", func() {
+		store, err := NewStore("")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeAssignableToTypeOf(&MemoryStore{}))
+	})
+
+	It("creates the directory", func() {
+		directory := filepath.Join(GinkgoT().TempDir(), "outbox")
+
+		store, err := NewStore(directory)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(Equal(DirectoryStore{directory: directory}))
+		Expect(directory).To(BeADirectory())
+	})
+})
+
+var _ = Context("DirectoryStore", func() {
+	var directory string
+	var store DirectoryStore
+
+	BeforeEach(func() {
+		directory = GinkgoT().TempDir()
+		store = DirectoryStore{directory: directory}
+	})
+
+	It("lists stored records", func() {
+		record := randomRecord()
+		Expect(store.Put(record)).To(Succeed())
+
+		records, _, err := store.List()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(ConsistOf(record))
+	})
+
+	It("replaces records with the same key", func() {
+		record := randomRecord()
+		Expect(store.Put(record)).To(Succeed())
+		record.Attempts = 1
+		Expect(store.Put(record)).To(Succeed())
+
+		records, _, err := store.List()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(ConsistOf(record))
+		Expect(os.ReadDir(directory)).To(HaveLen(1))
+	})
+
+	It("deletes records", func() {
+		record := randomRecord()
+		Expect(store.Put(record)).To(Succeed())
+
+		Expect(store.Delete(record.Key)).To(Succeed())
+		Expect(store.Delete(record.Key)).To(Succeed())
+
+		Expect(store.List()).To(BeEmpty())
+	})
+
+	It("quarantines unreadable records", func() {
+		record := randomRecord()
+		Expect(store.Put(record)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(directory, "corrupt.json"), []byte("{"), 0o600)).To(Succeed())
+
+		records, unreadable, err := store.List()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unreadable).To(HaveLen(1))
+		Expect(records).To(ConsistOf(record))
+		Expect(filepath.Join(directory, "corrupt.json")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(directory, QuarantineDirectory, "corrupt.json")).To(BeARegularFile())
+
+		Expect(store.List()).To(ConsistOf(record))
+	})
+
+	It("fails when the directory cannot be listed", func() {
+		store = DirectoryStore{directory: filepath.Join(directory, "missing")}
+
+		_, _, err := store.List()
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Context("MemoryStore", func() {
+	It("stores, lists and deletes records", func() {
+		store := NewMemoryStore()
+		record := randomRecord()
+
+		Expect(store.Put(record)).To(Succeed())
+		Expect(store.List()).To(ConsistOf(record))
+
+		Expect(store.Delete(record.Key)).To(Succeed())
+		Expect(store.List()).To(BeEmpty())
+	})
+})
@@ -3,8 +3,12 @@ package sinks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/go-resty/resty/v2"
@@ -31,18 +35,22 @@ type MessageHandler interface {
 type WebhookSink struct {
 	client          *resty.Client
 	operatorWebhook string
-	in              chan StreamMessage[*common.RunCompletionEventData]
+	// tokenPath is the file of the ServiceAccount token that authenticates
+	// the provider with the operator. The token is read for every event as
+	// it is rotated by the kubelet.
+	tokenPath string
+	in        chan StreamMessage[*common.RunCompletionEventData]
 }
 
-func NewWebhookSink(ctx context.Context, client *resty.Client, operatorWebhook string, inChan chan StreamMessage[*common.RunCompletionEventData]) *WebhookSink {
-	webhookSink := &WebhookSink{client: client, operatorWebhook: operatorWebhook, in: inChan}
+func NewWebhookSink(ctx context.Context, client *resty.Client, operatorWebhook string, tokenPath string, inChan chan StreamMessage[*common.RunCompletionEventData]) *WebhookSink {
+	webhookSink := &WebhookSink{client: client, operatorWebhook: operatorWebhook, tokenPath: tokenPath, in: inChan}
 
 	go webhookSink.SendEvents(ctx, webhookSink)
 
 	return webhookSink
 }
 
-func NewObservedWebhookSink(ctx context.Context, client *resty.Client, operatorWebhook string, inChan chan StreamMessage[*common.RunCompletionEventData]) (*ObservedWebhookSink, error) {
+func NewObservedWebhookSink(ctx context.Context, client *resty.Client, operatorWebhook string, tokenPath string, inChan chan StreamMessage[*common.RunCompletionEventData]) (*ObservedWebhookSink, error) {
 	meter := otel.Meter("webhook_sink")
 	sendEventsCounter, err := meter.Int64Counter(
 		"provider_webhook_send_events_count",
@@ -53,7 +61,7 @@ func NewObservedWebhookSink(ctx context.Context, client *resty.Client, operatorW
 		return nil, err
 	}
 
-	delegateWebSink := WebhookSink{client: client, operatorWebhook: operatorWebhook, in: inChan}
+	delegateWebSink := WebhookSink{client: client, operatorWebhook: operatorWebhook, tokenPath: tokenPath, in: inChan}
 	observed := ObservedWebhookSink{
 		delegate:          delegateWebSink,
 		meter:             meter,
@@ -94,7 +102,9 @@ func (ws WebhookSink) SendEvents(ctx context.Context, handler MessageHandler) {
 				switch response.StatusCode() {
 				case http.StatusOK:
 					handler.OnSuccess(ctx, message)
-				case http.StatusGone:
+				// events that the operator rejects as invalid can never be
+				// delivered, unlike those rejected by authentication or throttling
+				case http.StatusGone, http.StatusBadRequest, http.StatusUnsupportedMediaType:
 					handler.OnUnrecoverableFailure(ctx, message)
 				default:
 					handler.OnRecoverableFailure(ctx, message)
@@ -112,7 +122,17 @@ func (ws WebhookSink) send(rced common.RunCompletionEventData) (error, *resty.Re
 		return err, nil
 	}
 
-	response, err := ws.client.R().SetHeader("Content-Type", "application/json").SetBody(rcedBytes).Post(ws.operatorWebhook)
+	token, err := ws.token()
+	if err != nil {
+		return err, nil
+	}
+
+	request := ws.client.R().SetHeader("Content-Type", "application/json").SetBody(rcedBytes)
+	if token != "" {
+		request.SetAuthToken(token)
+	}
+
+	response, err := request.Post(ws.operatorWebhook)
 	if err != nil {
 		return err, nil
 	}
@@ -120,6 +140,23 @@ func (ws WebhookSink) send(rced common.RunCompletionEventData) (error, *resty.Re
 	return nil, response
 }
 
+// token returns the ServiceAccount token of the provider. Events are sent
+// without credentials when no token is mounted.
+func (ws WebhookSink) token() (string, error) {
+	if ws.tokenPath == "" {
+		return "", nil
+	}
+
+	token, err := os.ReadFile(ws.tokenPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
 type ObservedWebhookSink struct {
 	delegate          WebhookSink
 	meter             metric.Meter
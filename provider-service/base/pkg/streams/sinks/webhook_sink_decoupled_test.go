@@ -86,6 +86,8 @@ var _ = Context("Webhook Sink", Ordered, func() {
 		rcf := webhook.NewRunCompletionFeed(
 			fakeClient,
 			handlers,
+			nil,
+			nil,
 		)
 
 		go func() {
@@ -121,7 +123,7 @@ var _ = Context("Webhook Sink", Ordered, func() {
 		It("should call its OnSuccessHandler", func() {
 			inChan := make(chan StreamMessage[*common.RunCompletionEventData])
 
-			_ = NewWebhookSink(ctx, httpClient, fmt.Sprintf("http://localhost:%d/events", port), inChan)
+			_ = NewWebhookSink(ctx, httpClient, fmt.Sprintf("http://localhost:%d/events", port), "", inChan)
 
 			runCompletionEventData := webhook.RandomRunCompletionEventData()
 			runCompletionEventData.RunConfigurationName = &common.NamespacedName{
@@ -146,7 +148,7 @@ var _ = Context("Webhook Sink", Ordered, func() {
 		It("should call its OnRecoverableFailureHandler", func() {
 			inChan := make(chan StreamMessage[*common.RunCompletionEventData])
 
-			_ = NewWebhookSink(ctx, httpClient, fmt.Sprintf("http://localhost:%d/events", port), inChan)
+			_ = NewWebhookSink(ctx, httpClient, fmt.Sprintf("http://localhost:%d/events", port), "", inChan)
 
 			emptyRunCompletionData := common.RunCompletionEventData{}
 
@@ -166,7 +168,7 @@ var _ = Context("Webhook Sink", Ordered, func() {
 		It("should call its OnUnrecoverableFailureHandler", func() {
 			inChan := make(chan StreamMessage[*common.RunCompletionEventData])
 
-			_ = NewWebhookSink(ctx, httpClient, fmt.Sprintf("http://localhost:%d/events", port), inChan)
+			_ = NewWebhookSink(ctx, httpClient, fmt.Sprintf("http://localhost:%d/events", port), "", inChan)
 
 			runCompletionEventData := webhook.RandomRunCompletionEventData()
 			runCompletionEventData.RunConfigurationName = &common.NamespacedName{
@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -65,51 +67,110 @@ var _ = Context("SendEvents", func() {
 		})
 	})
 
-	When("receives a recoverable error response from webhook server", func() {
-		It("should call the message's OnRecoverableFailureHandler", func() {
+	DescribeTable("calls the message's OnRecoverableFailureHandler on recoverable error responses", func(recoverableResponseCode int) {
+		client := resty.New()
+		httpmock.ActivateNonDefault(client.GetClient())
+		webhookUrl := "/operator-webhook"
+		httpmock.RegisterResponder(http.MethodPost, webhookUrl, httpmock.NewStringResponder(recoverableResponseCode, ""))
+
+		in := make(chan StreamMessage[*common.RunCompletionEventData])
+		webhookSink := &WebhookSink{client: client, operatorWebhook: webhookUrl, in: in}
+
+		go webhookSink.SendEvents(ctx, webhookSink)
+
+		streamMessage := StreamMessage[*common.RunCompletionEventData]{
+			Message:            &runCompletionEventData,
+			OnCompleteHandlers: onCompHandlers,
+		}
+		in <- streamMessage
+
+		Eventually(func() int { return httpmock.GetCallCountInfo()[fmt.Sprintf("%s %s", http.MethodPost, webhookUrl)] }).Should(Equal(1))
+		Eventually(handlerCall).Should(Receive(Equal("recoverable_failure_called")))
+	},
+		Entry("internal server error", http.StatusInternalServerError),
+		Entry("service unavailable", http.StatusServiceUnavailable),
+		Entry("unauthorized", http.StatusUnauthorized),
+		Entry("forbidden", http.StatusForbidden),
+		Entry("too many requests", http.StatusTooManyRequests),
+	)
+
+	DescribeTable("calls the message's OnUnrecoverableFailureHandler on unrecoverable error responses", func(unrecoverableResponseCode int) {
+		client := resty.New()
+		httpmock.ActivateNonDefault(client.GetClient())
+		webhookUrl := "/operator-webhook"
+		httpmock.RegisterResponder(http.MethodPost, webhookUrl, httpmock.NewStringResponder(unrecoverableResponseCode, ""))
+
+		in := make(chan StreamMessage[*common.RunCompletionEventData])
+		webhookSink := &WebhookSink{client: client, operatorWebhook: webhookUrl, in: in}
+
+		go webhookSink.SendEvents(ctx, webhookSink)
+
+		streamMessage := StreamMessage[*common.RunCompletionEventData]{
+			Message:            &runCompletionEventData,
+			OnCompleteHandlers: onCompHandlers,
+		}
+		in <- streamMessage
+
+		Eventually(func() int { return httpmock.GetCallCountInfo()[fmt.Sprintf("%s %s", http.MethodPost, webhookUrl)] }).Should(Equal(1))
+		Eventually(handlerCall).Should(Receive(Equal("unrecoverable_failure_called")))
+	},
+		Entry("gone", http.StatusGone),
+		Entry("bad request", http.StatusBadRequest),
+		Entry("unsupported media type", http.StatusUnsupportedMediaType),
+	)
+
+	When("a service account token is mounted", func() {
+		It("sends the token as a bearer token", func() {
+			tokenPath := filepath.Join(GinkgoT().TempDir(), "token")
+			Expect(os.WriteFile(tokenPath, []byte("a-token\n"), 0600)).To(Succeed())
+
 			client := resty.New()
 			httpmock.ActivateNonDefault(client.GetClient())
 			webhookUrl := "/operator-webhook"
-			recoverableResponseCode := http.StatusInternalServerError
-			httpmock.RegisterResponder(http.MethodPost, webhookUrl, httpmock.NewStringResponder(recoverableResponseCode, ""))
+			authorization := make(chan string, 1)
+			httpmock.RegisterResponder(http.MethodPost, webhookUrl, func(request *http.Request) (*http.Response, error) {
+				authorization <- request.Header.Get("Authorization")
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			})
 
 			in := make(chan StreamMessage[*common.RunCompletionEventData])
-			webhookSink := &WebhookSink{client: client, operatorWebhook: webhookUrl, in: in}
+			webhookSink := &WebhookSink{client: client, operatorWebhook: webhookUrl, tokenPath: tokenPath, in: in}
 
 			go webhookSink.SendEvents(ctx, webhookSink)
 
-			streamMessage := StreamMessage[*common.RunCompletionEventData]{
+			in <- StreamMessage[*common.RunCompletionEventData]{
 				Message:            &runCompletionEventData,
 				OnCompleteHandlers: onCompHandlers,
 			}
-			in <- streamMessage
 
-			Eventually(func() int { return httpmock.GetCallCountInfo()[fmt.Sprintf("%s %s", http.MethodPost, webhookUrl)] }).Should(Equal(1))
-			Eventually(handlerCall).Should(Receive(Equal("recoverable_failure_called")))
+			Eventually(authorization).Should(Receive(Equal("Bearer a-token")))
+			Eventually(handlerCall).Should(Receive(Equal("success_called")))
 		})
 	})
 
-	When("receives an unrecoverable error response from the webhook server", func() {
-		It("should call the message's OnUnrecoverableFailureHandler", func() {
+	When("no service account token is mounted", func() {
+		It("sends the event without credentials", func() {
 			client := resty.New()
 			httpmock.ActivateNonDefault(client.GetClient())
 			webhookUrl := "/operator-webhook"
-			unrecoverableResponseCode := http.StatusGone
-			httpmock.RegisterResponder(http.MethodPost, webhookUrl, httpmock.NewStringResponder(unrecoverableResponseCode, ""))
+			authorization := make(chan string, 1)
+			httpmock.RegisterResponder(http.MethodPost, webhookUrl, func(request *http.Request) (*http.Response, error) {
+				authorization <- request.Header.Get("Authorization")
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			})
 
 			in := make(chan StreamMessage[*common.RunCompletionEventData])
-			webhookSink := &WebhookSink{client: client, operatorWebhook: webhookUrl, in: in}
+			webhookSink := &WebhookSink{client: client, operatorWebhook: webhookUrl, tokenPath: filepath.Join(GinkgoT().TempDir(), "missing"), in: in}
 
 			go webhookSink.SendEvents(ctx, webhookSink)
 
-			streamMessage := StreamMessage[*common.RunCompletionEventData]{
+			in <- StreamMessage[*common.RunCompletionEventData]{
 				Message:            &runCompletionEventData,
 				OnCompleteHandlers: onCompHandlers,
 			}
-			in <- streamMessage
 
-			Eventually(func() int { return httpmock.GetCallCountInfo()[fmt.Sprintf("%s %s", http.MethodPost, webhookUrl)] }).Should(Equal(1))
-			Eventually(handlerCall).Should(Receive(Equal("unrecoverable_failure_called")))
+			Eventually(authorization).Should(Receive(BeEmpty()))
+			Eventually(handlerCall).Should(Receive(Equal("success_called")))
 		})
 	})
 })
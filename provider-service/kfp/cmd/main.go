@@ -6,6 +6,7 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg"
+	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams/outbox"
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams/sinks"
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams/sources"
 	"github.com/sky-uk/kfp-operator/provider-service/kfp/internal/client"
@@ -41,6 +42,10 @@ func main() {
 
 	serviceConfig, err := baseConfig.LoadConfig(
 		baseConfig.Config{
+			OperatorWebhookTokenPath: baseConfig.DefaultOperatorWebhookTokenPath,
+			Outbox: baseConfig.OutboxConfig{
+				MaxAge: outbox.DefaultMaxAge,
+			},
 			Server: baseConfig.Server{
 				Host: "0.0.0.0",
 				Port: 8080,
@@ -124,13 +129,23 @@ func runEventing(ctx context.Context, k8sClient pkg.K8sClient, baseConfig *baseC
 		panic(err)
 	}
 
-	sink, err := sinks.NewObservedWebhookSink(ctx, resty.New(), baseConfig.OperatorWebhook, make(chan pkg.StreamMessage[*common.RunCompletionEventData]))
+	sink, err := sinks.NewObservedWebhookSink(ctx, resty.New(), baseConfig.OperatorWebhook, baseConfig.OperatorWebhookTokenPath, make(chan pkg.StreamMessage[*common.RunCompletionEventData]))
 	if err != nil {
 		panic(fmt.Errorf("failed to create webhook sink: %w", err))
 	}
 	errorSink := sinks.NewErrorSink(ctx, make(chan error))
 
+	outboxStore, err := outbox.NewStore(baseConfig.Outbox.Directory)
+	if err != nil {
+		panic(err)
+	}
+	eventOutbox, err := outbox.NewObservedOutbox(ctx, outboxStore, outbox.DefaultBackoff, baseConfig.Outbox.MaxAge)
+	if err != nil {
+		panic(fmt.Errorf("failed to create outbox: %w", err))
+	}
+
 	connectedFlow := flow.From(source)
-	connectedFlow.To(sink)
+	connectedFlow.To(eventOutbox)
+	eventOutbox.To(sink)
 	connectedFlow.Error(errorSink)
 }
@@ -15,6 +15,12 @@ type ExperimentServiceClient interface {
 		opts ...grpc.CallOption,
 	) (*go_client.Experiment, error)
 
+	GetExperiment(
+		ctx context.Context,
+		in *go_client.GetExperimentRequest,
+		opts ...grpc.CallOption,
+	) (*go_client.Experiment, error)
+
 	ListExperiments(
 		ctx context.Context,
 		in *go_client.ListExperimentsRequest,
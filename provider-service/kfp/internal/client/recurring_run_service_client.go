@@ -26,4 +26,10 @@ type RecurringRunServiceClient interface {
 		in *go_client.DeleteRecurringRunRequest,
 		opts ...grpc.CallOption,
 	) (*emptypb.Empty, error)
+
+	ListRecurringRuns(
+		ctx context.Context,
+		in *go_client.ListRecurringRunsRequest,
+		opts ...grpc.CallOption,
+	) (*go_client.ListRecurringRunsResponse, error)
 }
@@ -21,6 +21,14 @@ func (m *MockExperimentService) CreateExperiment(
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockExperimentService) GetExperiment(
+	_ context.Context,
+	id string,
+) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockExperimentService) DeleteExperiment(
 	_ context.Context,
 	id string,
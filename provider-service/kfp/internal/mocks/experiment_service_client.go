@@ -28,6 +28,19 @@ func (m *MockExperimentServiceClient) CreateExperiment(
 	return exp, args.Error(1)
 }
 
+func (m *MockExperimentServiceClient) GetExperiment(
+	_ context.Context,
+	in *go_client.GetExperimentRequest,
+	_ ...grpc.CallOption,
+) (*go_client.Experiment, error) {
+	args := m.Called(in)
+	var exp *go_client.Experiment
+	if arg0 := args.Get(0); arg0 != nil {
+		exp = arg0.(*go_client.Experiment)
+	}
+	return exp, args.Error(1)
+}
+
 func (m *MockExperimentServiceClient) ListExperiments(
 	_ context.Context,
 	in *go_client.ListExperimentsRequest,
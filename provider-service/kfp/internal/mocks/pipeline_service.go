@@ -43,3 +43,15 @@ func (m *MockPipelineService) PipelineVersionIdForDisplayName(
 	args := m.Called(versionName, pipelineId)
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockPipelineService) PipelineVersionDisplayNames(
+	_ context.Context,
+	id string,
+) ([]string, error) {
+	args := m.Called(id)
+	var displayNames []string
+	if arg0 := args.Get(0); arg0 != nil {
+		displayNames = arg0.([]string)
+	}
+	return displayNames, args.Error(1)
+}
@@ -39,3 +39,15 @@ func (m *MockRecurringRunService) DeleteRecurringRun(
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockRecurringRunService) ListRecurringRuns(
+	_ context.Context,
+	labels map[string]string,
+) ([]string, error) {
+	args := m.Called(labels)
+	var ids []string
+	if arg0 := args.Get(0); arg0 != nil {
+		ids = arg0.([]string)
+	}
+	return ids, args.Error(1)
+}
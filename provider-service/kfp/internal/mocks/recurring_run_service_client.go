@@ -49,3 +49,16 @@ func (m *MockRecurringRunServiceClient) DeleteRecurringRun(
 	args := m.Called(in)
 	return &emptypb.Empty{}, args.Error(0)
 }
+
+func (m *MockRecurringRunServiceClient) ListRecurringRuns(
+	_ context.Context,
+	in *go_client.ListRecurringRunsRequest,
+	_ ...grpc.CallOption,
+) (*go_client.ListRecurringRunsResponse, error) {
+	args := m.Called(in)
+	var response *go_client.ListRecurringRunsResponse
+	if arg0 := args.Get(0); arg0 != nil {
+		response = arg0.(*go_client.ListRecurringRunsResponse)
+	}
+	return response, args.Error(1)
+}
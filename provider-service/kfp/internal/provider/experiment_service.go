@@ -20,6 +20,8 @@ type ExperimentService interface {
 		description string,
 	) (string, error)
 
+	GetExperiment(ctx context.Context, id string) (string, error)
+
 	DeleteExperiment(ctx context.Context, id string) error
 
 	ExperimentIdByDisplayName(ctx context.Context, experiment common.NamespacedName) (string, error)
@@ -94,6 +96,19 @@ func (es *DefaultExperimentService) CreateExperiment(
 	return result.ExperimentId, nil
 }
 
+// GetExperiment takes an experiment id and returns the experiment description.
+func (es *DefaultExperimentService) GetExperiment(ctx context.Context, id string) (string, error) {
+	experiment, err := es.client.GetExperiment(
+		ctx,
+		&go_client.GetExperimentRequest{ExperimentId: id},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return experiment.Description, nil
+}
+
 // Delete Experiment deletes an experiment by experiment id
 func (es *DefaultExperimentService) DeleteExperiment(ctx context.Context, id string) error {
 	_, err := es.client.DeleteExperiment(
@@ -17,6 +17,7 @@ type PipelineService interface {
 	DeletePipelineVersions(ctx context.Context, id string) error
 	PipelineIdForDisplayName(ctx context.Context, pipelineName string) (string, error)
 	PipelineVersionIdForDisplayName(ctx context.Context, versionName string, pipelineId string) (string, error)
+	PipelineVersionDisplayNames(ctx context.Context, id string) ([]string, error)
 }
 
 type DefaultPipelineService struct {
@@ -146,3 +147,25 @@ func (ps *DefaultPipelineService) PipelineVersionIdForDisplayName(
 	}
 	return res.PipelineVersions[0].PipelineVersionId, nil
 }
+
+// PipelineVersionDisplayNames gets the display names of all pipeline versions
+// for a given pipeline id.
+func (ps *DefaultPipelineService) PipelineVersionDisplayNames(
+	ctx context.Context,
+	id string,
+) ([]string, error) {
+	res, err := ps.client.ListPipelineVersions(
+		ctx,
+		&go_client.ListPipelineVersionsRequest{PipelineId: id},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	displayNames := make([]string, 0, len(res.PipelineVersions))
+	for _, pipelineVersion := range res.PipelineVersions {
+		displayNames = append(displayNames, pipelineVersion.DisplayName)
+	}
+
+	return displayNames, nil
+}
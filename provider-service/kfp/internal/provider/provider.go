@@ -16,6 +16,8 @@ import (
 	"github.com/sky-uk/kfp-operator/provider-service/kfp/internal/client"
 	"github.com/sky-uk/kfp-operator/provider-service/kfp/internal/config"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -201,6 +203,27 @@ func (p *KfpProvider) DeletePipeline(
 	return p.pipelineService.DeletePipeline(ctx, id)
 }
 
+// GetPipeline returns the name of the single pipeline version that
+// UpdatePipeline keeps for a pipeline.
+func (p *KfpProvider) GetPipeline(
+	ctx context.Context,
+	id string,
+) (string, error) {
+	versions, err := p.pipelineService.PipelineVersionDisplayNames(ctx, id)
+	if err != nil {
+		return "", asNotFoundError(err, id, "pipeline")
+	}
+
+	switch len(versions) {
+	case 0:
+		return "", &resource.NotFoundError{Id: id, ResourceType: "pipeline"}
+	case 1:
+		return versions[0], nil
+	default:
+		return "", fmt.Errorf("found %d pipeline versions, expected exactly one", len(versions))
+	}
+}
+
 func (p *KfpProvider) resolveExperimentName(
 	name common.NamespacedName,
 ) common.NamespacedName {
@@ -322,6 +345,24 @@ func (p *KfpProvider) DeleteRunSchedule(
 	return p.recurringRunService.DeleteRecurringRun(ctx, id)
 }
 
+func (p *KfpProvider) GetRunSchedule(
+	ctx context.Context,
+	id string,
+) (string, error) {
+	if _, err := p.recurringRunService.GetRecurringRun(ctx, id); err != nil {
+		return "", asNotFoundError(err, id, "runschedule")
+	}
+
+	return "", nil
+}
+
+func (p *KfpProvider) ListRunSchedules(
+	ctx context.Context,
+	labels map[string]string,
+) ([]string, error) {
+	return p.recurringRunService.ListRecurringRuns(ctx, labels)
+}
+
 func (p *KfpProvider) CreateExperiment(
 	ctx context.Context,
 	ed base.ExperimentDefinition,
@@ -356,3 +397,22 @@ func (p *KfpProvider) DeleteExperiment(
 ) error {
 	return p.experimentService.DeleteExperiment(ctx, id)
 }
+
+func (p *KfpProvider) GetExperiment(
+	ctx context.Context,
+	id string,
+) (string, error) {
+	if _, err := p.experimentService.GetExperiment(ctx, id); err != nil {
+		return "", asNotFoundError(err, id, "experiment")
+	}
+
+	return "", nil
+}
+
+// asNotFoundError converts gRPC NotFound errors into resource.NotFoundError.
+func asNotFoundError(err error, id string, resourceType string) error {
+	if status.Code(err) == codes.NotFound {
+		return &resource.NotFoundError{Id: id, ResourceType: resourceType}
+	}
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/label"
+	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/server/resource"
 	"github.com/stretchr/testify/mock"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -18,6 +19,8 @@ import (
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/util"
 	"github.com/sky-uk/kfp-operator/provider-service/kfp/internal/config"
 	"github.com/sky-uk/kfp-operator/provider-service/kfp/internal/mocks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var _ = Describe("Provider", func() {
@@ -297,6 +300,46 @@ var _ = Describe("Provider", func() {
 				})
 			})
 		})
+
+		Context("GetPipeline", func() {
+			It("should return the name of the pipeline version", func() {
+				pipelineService.On("PipelineVersionDisplayNames", id).Return([]string{"version"}, nil)
+				result, err := provider.GetPipeline(ctx, id)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal("version"))
+			})
+
+			When("the pipeline has no versions", func() {
+				It("should return a NotFoundError", func() {
+					pipelineService.On("PipelineVersionDisplayNames", id).Return([]string{}, nil)
+					_, err := provider.GetPipeline(ctx, id)
+
+					var notFoundErr *resource.NotFoundError
+					Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+				})
+			})
+
+			When("pipeline service returns NotFound", func() {
+				It("should return a NotFoundError", func() {
+					pipelineService.On("PipelineVersionDisplayNames", id).Return(nil, status.Error(codes.NotFound, "not found"))
+					_, err := provider.GetPipeline(ctx, id)
+
+					var notFoundErr *resource.NotFoundError
+					Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+				})
+			})
+
+			When("pipeline service errors", func() {
+				It("should return error", func() {
+					expectedErr := errors.New("failed")
+					pipelineService.On("PipelineVersionDisplayNames", id).Return(nil, expectedErr)
+					_, err := provider.GetPipeline(ctx, id)
+
+					Expect(err).To(Equal(expectedErr))
+				})
+			})
+		})
 	})
 
 	Context("RunSchedule", func() {
@@ -457,6 +500,37 @@ var _ = Describe("Provider", func() {
 				})
 			})
 		})
+
+		Context("GetRunSchedule", func() {
+			It("should not error if the recurring run exists", func() {
+				recurringRunService.On("GetRecurringRun", recurringRunId).Return("description", nil)
+				result, err := provider.GetRunSchedule(ctx, recurringRunId)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+			})
+
+			When("recurring run service returns NotFound", func() {
+				It("should return a NotFoundError", func() {
+					recurringRunService.On("GetRecurringRun", recurringRunId).Return("", status.Error(codes.NotFound, "not found"))
+					_, err := provider.GetRunSchedule(ctx, recurringRunId)
+
+					var notFoundErr *resource.NotFoundError
+					Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("ListRunSchedules", func() {
+			It("should return the ids of the labelled recurring runs", func() {
+				labels := map[string]string{"provider-name": "provider"}
+				recurringRunService.On("ListRecurringRuns", labels).Return([]string{recurringRunId}, nil)
+				result, err := provider.ListRunSchedules(ctx, labels)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal([]string{recurringRunId}))
+			})
+		})
 	})
 
 	Context("Experiment", func() {
@@ -535,6 +609,26 @@ var _ = Describe("Provider", func() {
 				})
 			})
 		})
+
+		Context("GetExperiment", func() {
+			It("should not error if the experiment exists", func() {
+				experimentService.On("GetExperiment", id).Return("description", nil)
+				result, err := provider.GetExperiment(ctx, id)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+			})
+
+			When("experiment service returns NotFound", func() {
+				It("should return a NotFoundError", func() {
+					experimentService.On("GetExperiment", id).Return("", status.Error(codes.NotFound, "not found"))
+					_, err := provider.GetExperiment(ctx, id)
+
+					var notFoundErr *resource.NotFoundError
+					Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Context("extractPipelineSpec", func() {
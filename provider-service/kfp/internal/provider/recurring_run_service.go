@@ -26,6 +26,7 @@ type RecurringRunService interface {
 	) (string, error)
 	GetRecurringRun(ctx context.Context, id string) (string, error)
 	DeleteRecurringRun(ctx context.Context, id string) error
+	ListRecurringRuns(ctx context.Context, labels map[string]string) ([]string, error)
 }
 
 type DefaultRecurringRunService struct {
@@ -161,6 +162,49 @@ func (rrs *DefaultRecurringRunService) DeleteRecurringRun(
 	return nil
 }
 
+// ListRecurringRuns returns the ids of all recurring runs whose runtime
+// parameters contain the given labels.
+func (rrs *DefaultRecurringRunService) ListRecurringRuns(
+	ctx context.Context,
+	labels map[string]string,
+) ([]string, error) {
+	var ids []string
+	pageToken := ""
+
+	for {
+		res, err := rrs.client.ListRecurringRuns(
+			ctx,
+			&go_client.ListRecurringRunsRequest{PageToken: pageToken},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, recurringRun := range res.RecurringRuns {
+			if hasLabels(recurringRun, labels) {
+				ids = append(ids, recurringRun.RecurringRunId)
+			}
+		}
+
+		if res.NextPageToken == "" {
+			return ids, nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
+
+func hasLabels(recurringRun *go_client.RecurringRun, labels map[string]string) bool {
+	parameters := recurringRun.GetRuntimeConfig().GetParameters()
+
+	for key, value := range labels {
+		if parameters[key].GetStringValue() != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 func createAPICronSchedule(
 	rsd base.RunScheduleDefinition,
 ) (*go_client.CronSchedule, error) {
@@ -230,6 +230,57 @@ var _ = Describe("DefaultRecurringRunService", func() {
 		})
 	})
 
+	Context("ListRecurringRuns", func() {
+		recurringRun := func(id string, provider string) *go_client.RecurringRun {
+			return &go_client.RecurringRun{
+				RecurringRunId: id,
+				RuntimeConfig: &go_client.RuntimeConfig{
+					Parameters: map[string]*structpb.Value{
+						"provider-name": structpb.NewStringValue(provider),
+					},
+				},
+			}
+		}
+
+		It("should return the ids of recurring runs with matching labels across pages", func() {
+			mockClient.On("ListRecurringRuns", &go_client.ListRecurringRunsRequest{}).Return(
+				&go_client.ListRecurringRunsResponse{
+					RecurringRuns: []*go_client.RecurringRun{
+						recurringRun("matching-1", "provider"),
+						recurringRun("other", "other-provider"),
+					},
+					NextPageToken: "next",
+				},
+				nil,
+			)
+			mockClient.On("ListRecurringRuns", &go_client.ListRecurringRunsRequest{PageToken: "next"}).Return(
+				&go_client.ListRecurringRunsResponse{
+					RecurringRuns: []*go_client.RecurringRun{
+						recurringRun("matching-2", "provider"),
+						{RecurringRunId: "unlabelled"},
+					},
+				},
+				nil,
+			)
+
+			res, err := recurringRunService.ListRecurringRuns(ctx, map[string]string{"provider-name": "provider"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]string{"matching-1", "matching-2"}))
+		})
+
+		When("recurring run service client ListRecurringRuns returns error", func() {
+			It("should return error", func() {
+				mockClient.On("ListRecurringRuns", mock.Anything).Return(nil, errors.New("failed"))
+
+				res, err := recurringRunService.ListRecurringRuns(ctx, map[string]string{})
+
+				Expect(err).To(HaveOccurred())
+				Expect(res).To(BeNil())
+			})
+		})
+	})
+
 	Context("DeleteRecurringRun", func() {
 		It("should not error if recurring run is deleted", func() {
 			expectedReq := &go_client.DeleteRecurringRunRequest{RecurringRunId: recurringRunId}
@@ -163,9 +163,21 @@ func (ef *EventFlow) eventForWorkflow(ctx context.Context, workflow *unstructure
 		Provider:             ef.ProviderConfig.ProviderName,
 		RunStartTime:         resourceReferences.CreatedAt,
 		RunEndTime:           resourceReferences.FinishedAt,
+		FailureReason:        failureReason(workflow, status),
 	}, nil
 }
 
+// failureReason is the message Argo records on the status of a workflow that
+// has failed or errored.
+func failureReason(workflow *unstructured.Unstructured, status common.RunCompletionStatus) string {
+	if status != common.RunCompletionStatuses.Failed {
+		return ""
+	}
+
+	message, _, _ := unstructured.NestedString(workflow.Object, "status", "message")
+	return message
+}
+
 func runCompletionStatus(workflow *unstructured.Unstructured) (common.RunCompletionStatus, bool) {
 	switch workflow.GetLabels()[workflowPhaseLabel] {
 	case string(argo.WorkflowSucceeded):
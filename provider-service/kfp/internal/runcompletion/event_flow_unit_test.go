@@ -193,6 +193,26 @@ var _ = Context("Eventing Flow", func() {
 		Entry("workflow errored", argo.WorkflowError),
 	)
 
+	Describe("failureReason", func() {
+		workflowWithMessage := func(message string) *unstructured.Unstructured {
+			workflow := &unstructured.Unstructured{Object: map[string]any{}}
+			Expect(unstructured.SetNestedField(workflow.Object, message, "status", "message")).To(Succeed())
+			return workflow
+		}
+
+		It("returns the status message of a failed workflow", func() {
+			Expect(failureReason(workflowWithMessage("child failed"), common.RunCompletionStatuses.Failed)).To(Equal("child failed"))
+		})
+
+		It("returns empty for a succeeded workflow", func() {
+			Expect(failureReason(workflowWithMessage("child failed"), common.RunCompletionStatuses.Succeeded)).To(BeEmpty())
+		})
+
+		It("returns empty when the workflow has no status message", func() {
+			Expect(failureReason(&unstructured.Unstructured{Object: map[string]any{}}, common.RunCompletionStatuses.Failed)).To(BeEmpty())
+		})
+	})
+
 })
 
 func setPipelineNameInSpec(workflow *unstructured.Unstructured, pipelineName string) {
@@ -61,6 +61,10 @@ func (p *StubProvider) DeletePipeline(_ context.Context, id string) error {
 	}
 }
 
+func (p *StubProvider) GetPipeline(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
 func (p *StubProvider) CreateRun(
 	_ context.Context,
 	rd base.RunDefinition,
@@ -155,6 +159,14 @@ func (p *StubProvider) DeleteRunSchedule(_ context.Context, id string) error {
 	}
 }
 
+func (p *StubProvider) GetRunSchedule(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func (p *StubProvider) ListRunSchedules(_ context.Context, _ map[string]string) ([]string, error) {
+	return nil, nil
+}
+
 func (p *StubProvider) CreateExperiment(
 	_ context.Context,
 	ed base.ExperimentDefinition,
@@ -185,3 +197,7 @@ func (p *StubProvider) DeleteExperiment(_ context.Context, id string) error {
 		return nil
 	}
 }
+
+func (p *StubProvider) GetExperiment(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sky-uk/kfp-operator/pkg/common"
 	baseConfig "github.com/sky-uk/kfp-operator/provider-service/base/pkg/config"
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/server"
+	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams/outbox"
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams/sinks"
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/streams/sources"
 	vaiConfig "github.com/sky-uk/kfp-operator/provider-service/vai/internal/config"
@@ -44,6 +45,10 @@ func main() {
 	ctx := logr.NewContext(rootCtx, logger)
 
 	serviceConfig, err := baseConfig.LoadConfig(baseConfig.Config{
+		OperatorWebhookTokenPath: baseConfig.DefaultOperatorWebhookTokenPath,
+		Outbox: baseConfig.OutboxConfig{
+			MaxAge: outbox.DefaultMaxAge,
+		},
 		Server: baseConfig.Server{
 			Host: "0.0.0.0",
 			Port: 8080,
@@ -128,15 +133,27 @@ func runEventing(ctx context.Context, logger logr.Logger, baseConfig *baseConfig
 		flow.Start(ctx)
 	}()
 
-	sink, err := sinks.NewObservedWebhookSink(ctx, resty.New(), baseConfig.OperatorWebhook, make(chan StreamMessage[*common.RunCompletionEventData]))
+	sink, err := sinks.NewObservedWebhookSink(ctx, resty.New(), baseConfig.OperatorWebhook, baseConfig.OperatorWebhookTokenPath, make(chan StreamMessage[*common.RunCompletionEventData]))
 	if err != nil {
 		logger.Error(err, "failed to create webhook sink")
 		panic(err)
 	}
 	errorSink := sinks.NewErrorSink(ctx, make(chan error))
 
+	outboxStore, err := outbox.NewStore(baseConfig.Outbox.Directory)
+	if err != nil {
+		logger.Error(err, "failed to create outbox store")
+		panic(err)
+	}
+	eventOutbox, err := outbox.NewObservedOutbox(ctx, outboxStore, outbox.DefaultBackoff, baseConfig.Outbox.MaxAge)
+	if err != nil {
+		logger.Error(err, "failed to create outbox")
+		panic(err)
+	}
+
 	logger.Info("starting vai event flow")
-	flow.From(source).To(sink)
+	flow.From(source).To(eventOutbox)
+	eventOutbox.To(sink)
 	flow.Error(errorSink)
 }
 
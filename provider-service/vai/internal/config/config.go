@@ -35,6 +35,16 @@ func (vaipc VAIProviderConfig) PipelineJobName(name string) string {
 	return fmt.Sprintf("%s/pipelineJobs/%s", vaipc.Parent(), name)
 }
 
+// PipelineRunUrl is the link to a pipeline run in the Google Cloud console.
+func (vaipc VAIProviderConfig) PipelineRunUrl(runId string) string {
+	return fmt.Sprintf(
+		"https://console.cloud.google.com/vertex-ai/locations/%s/pipelines/runs/%s?project=%s",
+		vaipc.Parameters.VaiLocation,
+		runId,
+		vaipc.Parameters.VaiProject,
+	)
+}
+
 func (vaipc VAIProviderConfig) GetMaxConcurrentRunCountOrDefault() int64 {
 	const defaultMaxConcurrentRunCount = 10
 	if vaipc.Parameters.MaxConcurrentRunCount <= 0 {
@@ -27,3 +27,8 @@ func (m *MockFileHandler) Read(_ context.Context, bucket string, filePath string
 	}
 	return data, args.Error(1)
 }
+
+func (m *MockFileHandler) Exists(_ context.Context, id string, bucket string) (bool, error) {
+	args := m.Called(id, bucket)
+	return args.Bool(0), args.Error(1)
+}
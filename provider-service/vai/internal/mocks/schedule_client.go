@@ -50,3 +50,16 @@ func (m *MockScheduleClient) UpdateSchedule(
 	}
 	return schedule, args.Error(1)
 }
+
+func (m *MockScheduleClient) GetSchedule(
+	_ context.Context,
+	req *aiplatformpb.GetScheduleRequest,
+	_ ...gax.CallOption,
+) (*aiplatformpb.Schedule, error) {
+	args := m.Called(req)
+	var schedule *aiplatformpb.Schedule
+	if arg1 := args.Get(0); arg1 != nil {
+		schedule = arg1.(*aiplatformpb.Schedule)
+	}
+	return schedule, args.Error(1)
+}
@@ -0,0 +1,24 @@
+//go:build unit
+
+package mocks
+
+import (
+	"context"
+
+	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockScheduleLister struct{ mock.Mock }
+
+func (m *MockScheduleLister) ListSchedules(
+	_ context.Context,
+	req *aiplatformpb.ListSchedulesRequest,
+) ([]*aiplatformpb.Schedule, error) {
+	args := m.Called(req)
+	var schedules []*aiplatformpb.Schedule
+	if arg0 := args.Get(0); arg0 != nil {
+		schedules = arg0.([]*aiplatformpb.Schedule)
+	}
+	return schedules, args.Error(1)
+}
@@ -82,6 +82,20 @@ func (g *GcsFileHandler) Delete(ctx context.Context, id string, bucket string) e
 	return nil
 }
 
+// Exists reports whether any files exist for the GCS bucket name and id.
+func (g *GcsFileHandler) Exists(ctx context.Context, id string, bucket string) (bool, error) {
+	query := &storage.Query{Prefix: fmt.Sprintf("%s/", id)}
+
+	_, err := g.gcsClient.Bucket(bucket).Objects(ctx, query).Next()
+	if errors.Is(err, iterator.Done) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Read reads and returns the unmarshalled from the location inferred by the
 // GCS bucket name and file path.
 func (g *GcsFileHandler) Read(
@@ -15,6 +15,7 @@ import (
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/server/resource"
 	"github.com/sky-uk/kfp-operator/provider-service/vai/internal/config"
 	"github.com/sky-uk/kfp-operator/provider-service/vai/internal/util"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -45,12 +46,50 @@ type scheduleClient interface {
 		req *aiplatformpb.UpdateScheduleRequest,
 		opts ...gax.CallOption,
 	) (*aiplatformpb.Schedule, error)
+	GetSchedule(
+		ctx context.Context,
+		req *aiplatformpb.GetScheduleRequest,
+		opts ...gax.CallOption,
+	) (*aiplatformpb.Schedule, error)
+}
+
+// scheduleLister lists all schedules of a parent. It hides the iterator that
+// the schedule client returns.
+type scheduleLister interface {
+	ListSchedules(
+		ctx context.Context,
+		req *aiplatformpb.ListSchedulesRequest,
+	) ([]*aiplatformpb.Schedule, error)
+}
+
+type iteratingScheduleLister struct {
+	client *aiplatform.ScheduleClient
+}
+
+func (isl iteratingScheduleLister) ListSchedules(
+	ctx context.Context,
+	req *aiplatformpb.ListSchedulesRequest,
+) ([]*aiplatformpb.Schedule, error) {
+	var schedules []*aiplatformpb.Schedule
+
+	it := isl.client.ListSchedules(ctx, req)
+	for {
+		schedule, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return schedules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
 }
 
 type fileHandler interface {
 	Write(ctx context.Context, content []byte, bucket string, filePath string) error
 	Delete(ctx context.Context, id string, bucket string) error
 	Read(ctx context.Context, bucket string, filePath string) (map[string]any, error)
+	Exists(ctx context.Context, id string, bucket string) (bool, error)
 }
 
 type jobEnricher interface {
@@ -82,6 +121,7 @@ type VAIProvider struct {
 	fileHandler    fileHandler
 	pipelineClient pipelineJobCreator
 	scheduleClient scheduleClient
+	scheduleLister scheduleLister
 	jobBuilder     jobBuilder
 	jobEnricher    jobEnricher
 }
@@ -116,6 +156,7 @@ func NewVAIProvider(
 		fileHandler:    &fh,
 		pipelineClient: pc,
 		scheduleClient: sc,
+		scheduleLister: iteratingScheduleLister{client: sc},
 		jobBuilder: DefaultJobBuilder{
 			serviceAccount:      config.Parameters.VaiJobServiceAccount,
 			pipelineRootStorage: config.PipelineRootStorage,
@@ -177,6 +218,23 @@ func (vaip *VAIProvider) DeletePipeline(ctx context.Context, id string) error {
 	return nil
 }
 
+// GetPipeline checks that pipeline files exist for the id. All versions of a
+// pipeline are kept, so the current version cannot be determined.
+func (vaip *VAIProvider) GetPipeline(ctx context.Context, id string) (string, error) {
+	exists, err := vaip.fileHandler.Exists(
+		ctx,
+		id,
+		vaip.config.Parameters.PipelineBucket,
+	)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", &resource.NotFoundError{Id: id, ResourceType: "pipeline"}
+	}
+	return "", nil
+}
+
 func (vaip *VAIProvider) CreateRun(ctx context.Context, rd base.RunDefinition) (string, error) {
 	logger := logr.FromContextOrDiscard(ctx)
 
@@ -364,6 +422,58 @@ func (vaip *VAIProvider) DeleteRunSchedule(ctx context.Context, id string) error
 	return ignoreNotFound(schedule.Wait(ctx))
 }
 
+func (vaip *VAIProvider) GetRunSchedule(ctx context.Context, id string) (string, error) {
+	if _, err := vaip.scheduleClient.GetSchedule(
+		ctx,
+		&aiplatformpb.GetScheduleRequest{
+			Name: id,
+		},
+	); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", &resource.NotFoundError{Id: id, ResourceType: "runschedule"}
+		}
+		return "", err
+	}
+	return "", nil
+}
+
+// ListRunSchedules returns the names of all schedules whose pipeline jobs
+// carry the given labels. Labels are sanitized in the same way as the labels
+// of submitted pipeline jobs before they are compared.
+func (vaip *VAIProvider) ListRunSchedules(
+	ctx context.Context,
+	labels map[string]string,
+) ([]string, error) {
+	schedules, err := vaip.scheduleLister.ListSchedules(
+		ctx,
+		&aiplatformpb.ListSchedulesRequest{
+			Parent: vaip.config.Parent(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitizedLabels := DefaultLabelSanitizer{}.Sanitize(labels)
+
+	var names []string
+	for _, schedule := range schedules {
+		jobLabels := schedule.GetCreatePipelineJobRequest().GetPipelineJob().GetLabels()
+		matches := true
+		for key, value := range sanitizedLabels {
+			if jobLabels[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			names = append(names, schedule.Name)
+		}
+	}
+
+	return names, nil
+}
+
 func ignoreNotFound(err error) error {
 	if status.Code(err) == codes.NotFound {
 		return nil
@@ -392,3 +502,10 @@ func (vaip *VAIProvider) DeleteExperiment(
 ) error {
 	return errors.New("not implemented")
 }
+
+func (vaip *VAIProvider) GetExperiment(
+	_ context.Context,
+	_ string,
+) (string, error) {
+	return "", &resource.UnimplementedError{Method: "Get", ResourceType: "experiment"}
+}
@@ -14,9 +14,12 @@ import (
 	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/server/resource"
 	"github.com/sky-uk/kfp-operator/provider-service/vai/internal/config"
 	"github.com/sky-uk/kfp-operator/provider-service/vai/internal/mocks"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
@@ -25,6 +28,7 @@ var _ = Describe("Provider", func() {
 		mockFileHandler    mocks.MockFileHandler
 		mockPipelineClient mocks.MockPipelineJobClient
 		mockScheduleClient mocks.MockScheduleClient
+		mockScheduleLister mocks.MockScheduleLister
 		mockJobBuilder     mocks.MockJobBuilder
 		mockJobEnricher    mocks.MockJobEnricher
 		vaiProvider        VAIProvider
@@ -35,6 +39,7 @@ var _ = Describe("Provider", func() {
 		mockFileHandler = mocks.MockFileHandler{}
 		mockPipelineClient = mocks.MockPipelineJobClient{}
 		mockScheduleClient = mocks.MockScheduleClient{}
+		mockScheduleLister = mocks.MockScheduleLister{}
 		mockJobBuilder = mocks.MockJobBuilder{}
 		mockJobEnricher = mocks.MockJobEnricher{}
 		vaiProvider = VAIProvider{
@@ -42,6 +47,7 @@ var _ = Describe("Provider", func() {
 			fileHandler:    &mockFileHandler,
 			pipelineClient: &mockPipelineClient,
 			scheduleClient: &mockScheduleClient,
+			scheduleLister: &mockScheduleLister,
 			jobBuilder:     &mockJobBuilder,
 			jobEnricher:    &mockJobEnricher,
 		}
@@ -154,6 +160,31 @@ var _ = Describe("Provider", func() {
 		})
 	})
 
+	Context("GetPipeline", func() {
+		When("getting a pipeline", func() {
+			It("should not return an error when pipeline files exist", func() {
+				mockFileHandler.On(
+					"Exists",
+					"pipelineId",
+					vaiProvider.config.Parameters.PipelineBucket,
+				).Return(true, nil)
+				_, err := vaiProvider.GetPipeline(ctx, "pipelineId")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("return a NotFoundError when no pipeline files exist", func() {
+				mockFileHandler.On(
+					"Exists",
+					"pipelineId",
+					vaiProvider.config.Parameters.PipelineBucket,
+				).Return(false, nil)
+				_, err := vaiProvider.GetPipeline(ctx, "pipelineId")
+				var notFoundErr *resource.NotFoundError
+				Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+			})
+		})
+	})
+
 	Context("CreateRun", func() {
 		When("creating a run", func() {
 			It("return a run ID", func() {
@@ -510,4 +541,64 @@ var _ = Describe("Provider", func() {
 			})
 		})
 	})
+
+	Context("GetRunSchedule", func() {
+		When("getting a run schedule", func() {
+			It("should not return an error when the schedule exists", func() {
+				mockScheduleClient.On(
+					"GetSchedule",
+					&aiplatformpb.GetScheduleRequest{Name: "scheduleId"},
+				).Return(&aiplatformpb.Schedule{Name: "scheduleId"}, nil)
+				_, err := vaiProvider.GetRunSchedule(ctx, "scheduleId")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("return a NotFoundError when the schedule does not exist", func() {
+				mockScheduleClient.On(
+					"GetSchedule",
+					&aiplatformpb.GetScheduleRequest{Name: "scheduleId"},
+				).Return(nil, status.Error(codes.NotFound, "not found"))
+				_, err := vaiProvider.GetRunSchedule(ctx, "scheduleId")
+				var notFoundErr *resource.NotFoundError
+				Expect(errors.As(err, &notFoundErr)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("ListRunSchedules", func() {
+		schedule := func(name string, labels map[string]string) *aiplatformpb.Schedule {
+			return &aiplatformpb.Schedule{
+				Name: name,
+				Request: &aiplatformpb.Schedule_CreatePipelineJobRequest{
+					CreatePipelineJobRequest: &aiplatformpb.CreatePipelineJobRequest{
+						PipelineJob: &aiplatformpb.PipelineJob{Labels: labels},
+					},
+				},
+			}
+		}
+
+		It("should return the names of schedules with matching sanitized labels", func() {
+			mockScheduleLister.On(
+				"ListSchedules",
+				&aiplatformpb.ListSchedulesRequest{Parent: vaiProvider.config.Parent()},
+			).Return([]*aiplatformpb.Schedule{
+				schedule("matching", map[string]string{"provider-name": "myprovider", "pipeline-name": "a"}),
+				schedule("other", map[string]string{"provider-name": "other"}),
+				{Name: "unlabelled"},
+			}, nil)
+
+			names, err := vaiProvider.ListRunSchedules(ctx, map[string]string{"provider-name": "MyProvider"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"matching"}))
+		})
+
+		It("should return an error when listing fails", func() {
+			mockScheduleLister.On("ListSchedules", mock.Anything).Return(nil, errors.New("failed"))
+
+			_, err := vaiProvider.ListRunSchedules(ctx, map[string]string{})
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
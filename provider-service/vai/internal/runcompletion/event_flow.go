@@ -222,5 +222,7 @@ func (vef *EventFlow) toRunCompletionEventData(ctx context.Context, job *aiplatf
 		Provider:             vef.ProviderConfig.ProviderName,
 		RunStartTime:         runStartTime,
 		RunEndTime:           runEndTime,
+		RunUrl:               vef.ProviderConfig.PipelineRunUrl(runId),
+		FailureReason:        job.GetError().GetMessage(),
 	}, nil
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/sky-uk/kfp-operator/provider-service/base/pkg/label"
 	"github.com/sky-uk/kfp-operator/provider-service/vai/internal/config"
 	"github.com/sky-uk/kfp-operator/provider-service/vai/internal/mocks"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -93,7 +94,7 @@ var _ = Context("VaiEventingServer", func() {
 		Entry("Paused", aiplatformpb.PipelineState_PIPELINE_STATE_PAUSED),
 	)
 
-	DescribeTable("toRunCompletionEventData for job that has completed", func(pipelineState aiplatformpb.PipelineState, status common.RunCompletionStatus) {
+	DescribeTable("toRunCompletionEventData for job that has completed", func(pipelineState aiplatformpb.PipelineState, jobError *rpcstatus.Status, status common.RunCompletionStatus) {
 		runConfigurationName := common.RandomNamespacedName()
 		pipelineName := common.RandomNamespacedName()
 		pipelineRunName := common.RandomNamespacedName()
@@ -109,6 +110,7 @@ var _ = Context("VaiEventingServer", func() {
 				label.RunNamespace:              pipelineRunName.Namespace,
 			},
 			State: pipelineState,
+			Error: jobError,
 			JobDetail: &aiplatformpb.PipelineJobDetail{
 				TaskDetails: []*aiplatformpb.PipelineTaskDetail{
 					{
@@ -130,6 +132,8 @@ var _ = Context("VaiEventingServer", func() {
 			RunId:                pipelineRunName.Name,
 			Status:               status,
 			Provider:             eventingFlow.ProviderConfig.ProviderName,
+			RunUrl:               eventingFlow.ProviderConfig.PipelineRunUrl(pipelineRunName.Name),
+			FailureReason:        jobError.GetMessage(),
 			PipelineComponents: []common.PipelineComponent{
 				{
 					Name: "my-task-name",
@@ -151,9 +155,9 @@ var _ = Context("VaiEventingServer", func() {
 			},
 		}))
 	},
-		Entry("Unspecified", aiplatformpb.PipelineState_PIPELINE_STATE_SUCCEEDED, common.RunCompletionStatuses.Succeeded),
-		Entry("Unspecified", aiplatformpb.PipelineState_PIPELINE_STATE_FAILED, common.RunCompletionStatuses.Failed),
-		Entry("Pending", aiplatformpb.PipelineState_PIPELINE_STATE_CANCELLED, common.RunCompletionStatuses.Failed),
+		Entry("Unspecified", aiplatformpb.PipelineState_PIPELINE_STATE_SUCCEEDED, nil, common.RunCompletionStatuses.Succeeded),
+		Entry("Unspecified", aiplatformpb.PipelineState_PIPELINE_STATE_FAILED, &rpcstatus.Status{Message: "pipeline failed"}, common.RunCompletionStatuses.Failed),
+		Entry("Pending", aiplatformpb.PipelineState_PIPELINE_STATE_CANCELLED, &rpcstatus.Status{Message: "pipeline cancelled"}, common.RunCompletionStatuses.Failed),
 	)
 
 	Describe("artifactsFilterData", func() {
@@ -336,6 +340,7 @@ var _ = Context("VaiEventingServer", func() {
 					Provider:             eventingFlow.ProviderConfig.ProviderName,
 					RunStartTime:         &timeNow,
 					RunEndTime:           &timeNow,
+					RunUrl:               eventingFlow.ProviderConfig.PipelineRunUrl(runId),
 				}
 
 				Eventually(outChan).Should(Receive(WithTransform(func(msg StreamMessage[*common.RunCompletionEventData]) interface{} {
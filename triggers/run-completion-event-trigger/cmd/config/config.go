@@ -15,7 +15,9 @@ type Config struct {
 }
 
 type NATSConfig struct {
-	Subject      string       `mapstructure:"subject"`
+	Subject string `mapstructure:"subject"`
+	// Format of published events, either `legacy` or `cloudevents`.
+	Format       string       `mapstructure:"format"`
 	ServerConfig ServerConfig `mapstructure:"serverConfig"`
 }
 
@@ -25,6 +25,7 @@ var _ = Context("LoadConfig", func() {
 			expectedConfig := Config{
 				NATSConfig: NATSConfig{
 					Subject: "events",
+					Format:  "legacy",
 					ServerConfig: ServerConfig{
 						Host: "localhost",
 						Port: "4222",
@@ -51,6 +52,7 @@ var _ = Context("LoadConfig", func() {
 			expectedConfig := Config{
 				NATSConfig: NATSConfig{
 					Subject: "events",
+					Format:  "legacy",
 					ServerConfig: ServerConfig{
 						Host: "localhost",
 						Port: "5000",
@@ -55,7 +55,13 @@ func main() {
 	}
 	defer nc.Close()
 
-	natsPublisher := publisher.NewNatsPublisher(ctx, nc, config.NATSConfig.Subject)
+	eventFormat, err := publisher.ParseEventFormat(config.NATSConfig.Format)
+	if err != nil {
+		logger.Error(err, "Invalid event format")
+		panic(err)
+	}
+
+	natsPublisher := publisher.NewNatsPublisher(ctx, nc, config.NATSConfig.Subject, eventFormat)
 
 	srvMetrics := server.NewServerMetrics()
 
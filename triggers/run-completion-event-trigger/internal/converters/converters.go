@@ -2,6 +2,7 @@ package converters
 
 import (
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 	pb "github.com/sky-uk/kfp-operator/triggers/run-completion-event-trigger/proto"
 	"time"
 )
@@ -50,9 +51,26 @@ func ProtoRunCompletionToCommon(protoRunCompletion *pb.RunCompletionEvent) (comm
 		Provider:             provider,
 		RunStartTime:         startTime,
 		RunEndTime:           endTime,
+		PipelineVersion:      protoRunCompletion.PipelineVersion,
+		Parameters:           protoRunCompletion.Parameters,
+		TriggerIndicator:     protoToTriggerIndicator(protoRunCompletion.TriggerIndicator),
+		RunUrl:               protoRunCompletion.RunUrl,
+		FailureReason:        protoRunCompletion.FailureReason,
 	}, nil
 }
 
+func protoToTriggerIndicator(indicator *pb.TriggerIndicator) *triggers.Indicator {
+	if indicator == nil {
+		return nil
+	}
+
+	return &triggers.Indicator{
+		Type:            indicator.Type,
+		Source:          indicator.Source,
+		SourceNamespace: indicator.SourceNamespace,
+	}
+}
+
 func protoToArtifacts(artifacts []*pb.Artifact) []common.Artifact {
 	commonArtifacts := []common.Artifact{}
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/sky-uk/kfp-operator/pkg/common"
+	"github.com/sky-uk/kfp-operator/pkg/common/triggers"
 	pb "github.com/sky-uk/kfp-operator/triggers/run-completion-event-trigger/proto"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -38,9 +39,18 @@ var _ = Context("ProtoRunCompletionToCommon", func() {
 					},
 				},
 
-				Status:       pb.Status_SUCCEEDED,
-				RunStartTime: timestampNow,
-				RunEndTime:   timestampNow,
+				Status:          pb.Status_SUCCEEDED,
+				RunStartTime:    timestampNow,
+				RunEndTime:      timestampNow,
+				PipelineVersion: "some-version",
+				Parameters:      map[string]string{"some-parameter": "some-value"},
+				TriggerIndicator: &pb.TriggerIndicator{
+					Type:            "schedule",
+					Source:          "some-run-configuration-name",
+					SourceNamespace: "namespace",
+				},
+				RunUrl:        "some-url",
+				FailureReason: "some-reason",
 			}
 
 			expectedCommonRunCompletionEvent := common.RunCompletionEvent{
@@ -68,8 +78,17 @@ var _ = Context("ProtoRunCompletionToCommon", func() {
 					Namespace: "namespace",
 					Name:      "some-provider",
 				},
-				RunStartTime: &timeNow,
-				RunEndTime:   &timeNow,
+				RunStartTime:    &timeNow,
+				RunEndTime:      &timeNow,
+				PipelineVersion: "some-version",
+				Parameters:      map[string]string{"some-parameter": "some-value"},
+				TriggerIndicator: &triggers.Indicator{
+					Type:            "schedule",
+					Source:          "some-run-configuration-name",
+					SourceNamespace: "namespace",
+				},
+				RunUrl:        "some-url",
+				FailureReason: "some-reason",
 			}
 
 			Expect(ProtoRunCompletionToCommon(&protoRunCompletionEvent)).To(Equal(expectedCommonRunCompletionEvent))
@@ -3,6 +3,7 @@ package publisher
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	"github.com/nats-io/nats.go"
@@ -34,28 +35,51 @@ type natsConn interface {
 	IsConnected() bool
 }
 
+type EventFormat string
+
+var EventFormats = struct {
+	Legacy      EventFormat
+	CloudEvents EventFormat
+}{
+	Legacy:      "legacy",
+	CloudEvents: "cloudevents",
+}
+
+// ParseEventFormat defaults to the legacy format when no format is given.
+func ParseEventFormat(format string) (EventFormat, error) {
+	switch EventFormat(format) {
+	case "", EventFormats.Legacy:
+		return EventFormats.Legacy, nil
+	case EventFormats.CloudEvents:
+		return EventFormats.CloudEvents, nil
+	default:
+		return "", fmt.Errorf("unsupported event format [%s], want `%s` or `%s`", format, EventFormats.Legacy, EventFormats.CloudEvents)
+	}
+}
+
 type NatsPublisher struct {
 	NatsConn natsConn
 	Subject  string
+	Format   EventFormat
 }
 
 type DataWrapper struct {
 	Data common.RunCompletionEvent `json:"data"`
 }
 
-func NewNatsPublisher(ctx context.Context, nc *nats.Conn, subject string) *NatsPublisher {
+func NewNatsPublisher(ctx context.Context, nc *nats.Conn, subject string, format EventFormat) *NatsPublisher {
 	logger := logr.FromContextOrDiscard(ctx)
 
-	logger.Info("New nats publisher:", "Subject", subject, "Server", nc.ConnectedUrl())
+	logger.Info("New nats publisher:", "Subject", subject, "Format", format, "Server", nc.ConnectedUrl())
 	return &NatsPublisher{
 		NatsConn: nc,
 		Subject:  subject,
+		Format:   format,
 	}
 }
 
 func (nc *NatsPublisher) Publish(runCompletionEvent common.RunCompletionEvent) error {
-	dataWrapper := DataWrapper{Data: runCompletionEvent}
-	eventData, err := json.Marshal(dataWrapper)
+	eventData, err := nc.marshal(runCompletionEvent)
 	if err != nil {
 		return &MarshallingError{err.Error()}
 	}
@@ -65,6 +89,20 @@ func (nc *NatsPublisher) Publish(runCompletionEvent common.RunCompletionEvent) e
 	return nil
 }
 
+// marshal wraps the event in a structured CloudEvent or, in the legacy
+// format, in a DataWrapper.
+func (nc *NatsPublisher) marshal(runCompletionEvent common.RunCompletionEvent) ([]byte, error) {
+	if nc.Format == EventFormats.CloudEvents {
+		cloudEvent, err := runCompletionEvent.ToCloudEvent()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(cloudEvent)
+	}
+
+	return json.Marshal(DataWrapper{Data: runCompletionEvent})
+}
+
 func (nc *NatsPublisher) Name() string {
 	return "nats-publisher"
 }
@@ -66,6 +66,33 @@ var _ = Describe("PublisherHandler", func() {
 			})
 		})
 
+		When("publishing CloudEvents", func() {
+			It("should wrap the event in a CloudEvent", func() {
+				publisher.Format = EventFormats.CloudEvents
+				runConfigurationName := common.NamespacedName{Namespace: "namespace", Name: "rc"}
+				event = common.RunCompletionEvent{
+					RunId:                "run-id",
+					RunConfigurationName: &runConfigurationName,
+					Provider:             common.NamespacedName{Namespace: "namespace", Name: "provider"},
+				}
+
+				var published common.CloudEvent[common.RunCompletionEvent]
+				mockNatsConn.On("Publish", subject, mock.MatchedBy(func(data []byte) bool {
+					return json.Unmarshal(data, &published) == nil
+				})).Return(nil)
+
+				err := publisher.Publish(event)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(published.SpecVersion).To(Equal(common.CloudEventsSpecVersion))
+				Expect(published.Type).To(Equal(common.RunCompletionEventType))
+				Expect(published.Id).To(Equal("run-id"))
+				Expect(published.Source).To(Equal("kfp-operator/providers/namespace/provider"))
+				Expect(published.Subject).To(Equal("namespace/rc"))
+				Expect(published.Data).To(Equal(event))
+			})
+		})
+
 		When("NatsConn.Publish errors", func() {
 			It("should return an error", func() {
 				dataWrapper := DataWrapper{Data: event}
@@ -82,6 +109,22 @@ var _ = Describe("PublisherHandler", func() {
 		})
 	})
 
+	Context("ParseEventFormat", func() {
+		It("defaults to the legacy format", func() {
+			Expect(ParseEventFormat("")).To(Equal(EventFormats.Legacy))
+		})
+
+		It("parses known formats", func() {
+			Expect(ParseEventFormat("legacy")).To(Equal(EventFormats.Legacy))
+			Expect(ParseEventFormat("cloudevents")).To(Equal(EventFormats.CloudEvents))
+		})
+
+		It("rejects unknown formats", func() {
+			_, err := ParseEventFormat("xml")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("IsHealthy", func() {
 		When("nats connection is connected", func() {
 			It("should return true", func() {
@@ -80,6 +80,11 @@ type RunCompletionEvent struct {
 	Artifacts            []*Artifact            `protobuf:"bytes,7,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
 	RunStartTime         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=run_start_time,json=runStartTime,proto3" json:"run_start_time,omitempty"`
 	RunEndTime           *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=run_end_time,json=runEndTime,proto3" json:"run_end_time,omitempty"`
+	PipelineVersion      string                 `protobuf:"bytes,11,opt,name=pipeline_version,json=pipelineVersion,proto3" json:"pipeline_version,omitempty"`
+	Parameters           map[string]string      `protobuf:"bytes,12,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TriggerIndicator     *TriggerIndicator      `protobuf:"bytes,13,opt,name=trigger_indicator,json=triggerIndicator,proto3" json:"trigger_indicator,omitempty"`
+	RunUrl               string                 `protobuf:"bytes,14,opt,name=run_url,json=runUrl,proto3" json:"run_url,omitempty"`
+	FailureReason        string                 `protobuf:"bytes,15,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -177,6 +182,41 @@ func (x *RunCompletionEvent) GetRunEndTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RunCompletionEvent) GetPipelineVersion() string {
+	if x != nil {
+		return x.PipelineVersion
+	}
+	return ""
+}
+
+func (x *RunCompletionEvent) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *RunCompletionEvent) GetTriggerIndicator() *TriggerIndicator {
+	if x != nil {
+		return x.TriggerIndicator
+	}
+	return nil
+}
+
+func (x *RunCompletionEvent) GetRunUrl() string {
+	if x != nil {
+		return x.RunUrl
+	}
+	return ""
+}
+
+func (x *RunCompletionEvent) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
 type Artifact struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
@@ -229,11 +269,71 @@ func (x *Artifact) GetName() string {
 	return ""
 }
 
+type TriggerIndicator struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Source          string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	SourceNamespace string                 `protobuf:"bytes,3,opt,name=source_namespace,json=sourceNamespace,proto3" json:"source_namespace,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TriggerIndicator) Reset() {
+	*x = TriggerIndicator{}
+	mi := &file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerIndicator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerIndicator) ProtoMessage() {}
+
+func (x *TriggerIndicator) ProtoReflect() protoreflect.Message {
+	mi := &file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerIndicator.ProtoReflect.Descriptor instead.
+func (*TriggerIndicator) Descriptor() ([]byte, []int) {
+	return file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TriggerIndicator) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TriggerIndicator) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TriggerIndicator) GetSourceNamespace() string {
+	if x != nil {
+		return x.SourceNamespace
+	}
+	return ""
+}
+
 var File_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto protoreflect.FileDescriptor
 
 const file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_rawDesc = "" +
 	"\n" +
-	"Ntriggers/run-completion-event-trigger/proto/run_completion_event_trigger.proto\x12\x1crun_completion_event_trigger\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x03\n" +
+	"Ntriggers/run-completion-event-trigger/proto/run_completion_event_trigger.proto\x12\x1crun_completion_event_trigger\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x06\n" +
 	"\x12RunCompletionEvent\x12#\n" +
 	"\rpipeline_name\x18\x01 \x01(\tR\fpipelineName\x12\x1a\n" +
 	"\bprovider\x18\x02 \x01(\tR\bprovider\x124\n" +
@@ -245,10 +345,24 @@ const file_triggers_run_completion_event_trigger_proto_run_completion_event_trig
 	"\x0erun_start_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\frunStartTime\x12<\n" +
 	"\frun_end_time\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"runEndTimeJ\x04\b\b\x10\tR\x17serving_model_artifacts\":\n" +
+	"runEndTime\x12)\n" +
+	"\x10pipeline_version\x18\v \x01(\tR\x0fpipelineVersion\x12`\n" +
+	"\n" +
+	"parameters\x18\f \x03(\v2@.run_completion_event_trigger.RunCompletionEvent.ParametersEntryR\n" +
+	"parameters\x12[\n" +
+	"\x11trigger_indicator\x18\r \x01(\v2..run_completion_event_trigger.TriggerIndicatorR\x10triggerIndicator\x12\x17\n" +
+	"\arun_url\x18\x0e \x01(\tR\x06runUrl\x12%\n" +
+	"\x0efailure_reason\x18\x0f \x01(\tR\rfailureReason\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\b\x10\tR\x17serving_model_artifacts\":\n" +
 	"\bArtifact\x12\x1a\n" +
 	"\blocation\x18\x01 \x01(\tR\blocation\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name*#\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"i\n" +
+	"\x10TriggerIndicator\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12)\n" +
+	"\x10source_namespace\x18\x03 \x01(\tR\x0fsourceNamespace*#\n" +
 	"\x06Status\x12\r\n" +
 	"\tSUCCEEDED\x10\x00\x12\n" +
 	"\n" +
@@ -269,26 +383,30 @@ func file_triggers_run_completion_event_trigger_proto_run_completion_event_trigg
 }
 
 var file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_goTypes = []any{
 	(Status)(0),                   // 0: run_completion_event_trigger.Status
 	(*RunCompletionEvent)(nil),    // 1: run_completion_event_trigger.RunCompletionEvent
 	(*Artifact)(nil),              // 2: run_completion_event_trigger.Artifact
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 4: google.protobuf.Empty
+	(*TriggerIndicator)(nil),      // 3: run_completion_event_trigger.TriggerIndicator
+	nil,                           // 4: run_completion_event_trigger.RunCompletionEvent.ParametersEntry
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 6: google.protobuf.Empty
 }
 var file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_depIdxs = []int32{
 	0, // 0: run_completion_event_trigger.RunCompletionEvent.status:type_name -> run_completion_event_trigger.Status
 	2, // 1: run_completion_event_trigger.RunCompletionEvent.artifacts:type_name -> run_completion_event_trigger.Artifact
-	3, // 2: run_completion_event_trigger.RunCompletionEvent.run_start_time:type_name -> google.protobuf.Timestamp
-	3, // 3: run_completion_event_trigger.RunCompletionEvent.run_end_time:type_name -> google.protobuf.Timestamp
-	1, // 4: run_completion_event_trigger.RunCompletionEventTrigger.ProcessEventFeed:input_type -> run_completion_event_trigger.RunCompletionEvent
-	4, // 5: run_completion_event_trigger.RunCompletionEventTrigger.ProcessEventFeed:output_type -> google.protobuf.Empty
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 2: run_completion_event_trigger.RunCompletionEvent.run_start_time:type_name -> google.protobuf.Timestamp
+	5, // 3: run_completion_event_trigger.RunCompletionEvent.run_end_time:type_name -> google.protobuf.Timestamp
+	4, // 4: run_completion_event_trigger.RunCompletionEvent.parameters:type_name -> run_completion_event_trigger.RunCompletionEvent.ParametersEntry
+	3, // 5: run_completion_event_trigger.RunCompletionEvent.trigger_indicator:type_name -> run_completion_event_trigger.TriggerIndicator
+	1, // 6: run_completion_event_trigger.RunCompletionEventTrigger.ProcessEventFeed:input_type -> run_completion_event_trigger.RunCompletionEvent
+	6, // 7: run_completion_event_trigger.RunCompletionEventTrigger.ProcessEventFeed:output_type -> google.protobuf.Empty
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() {
@@ -304,7 +422,7 @@ func file_triggers_run_completion_event_trigger_proto_run_completion_event_trigg
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_rawDesc), len(file_triggers_run_completion_event_trigger_proto_run_completion_event_trigger_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},